@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/FACorreiaa/goforge/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var addCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add code to a generated project",
+	Long:  `Add code to an existing GoForge project. Run it from the project root or pass --dir.`,
+}
+
+var addWorkerCmd = &cobra.Command{
+	Use:   "worker <name>",
+	Short: "Add a background job worker (projects generated with --jobs)",
+	Long: `Add a job type, its handler and a test to internal/jobs, and register the
+handler in internal/jobs/registry.go.
+
+Example:
+  goforge add worker send-welcome-email`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAddWorker,
+}
+
+var addCommandCmd = &cobra.Command{
+	Use:   "command <name>",
+	Short: "Add a subcommand to the project's maintenance CLI (cmd/cli)",
+	Long: `Add a cobra subcommand to cmd/cli for maintenance scripts and data
+backfills. The CLI is created on first use; commands load the project config
+and, when the project has a database, a connection pool.
+
+Example:
+  goforge add command backfill-slugs
+  go run ./cmd/cli backfill-slugs --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAddCommand,
+}
+
+var addEndpointCmd = &cobra.Command{
+	Use:   "endpoint <method> <path>",
+	Short: "Add a JSON API endpoint to the /api route group",
+	Long: `Add a handler with request/response DTOs and validation, register it in the
+/api route group and add a table-driven test. Projects generated with
+--openapi also get the operation and schemas in api/openapi.yaml.
+
+Example:
+  goforge add endpoint POST /api/users
+  goforge add endpoint GET /api/users/{id}`,
+	Args: cobra.ExactArgs(2),
+	RunE: runAddEndpoint,
+}
+
+var addModuleCmd = &cobra.Command{
+	Use:   "module <name>",
+	Short: "Add a feature module (handler, service, repository, views) under internal/modules",
+	Long: `Add a self-contained feature module to internal/modules/<name>: a handler,
+a service with the business rules, a repository, Templ views, a service test
+and a Routes function, whose routes join the registry in Server.Routes.
+Projects with a database also get a migration; the others keep the module's
+data in memory.
+
+Example:
+  goforge add module invoices
+  templ generate && go test ./internal/modules/...`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAddModule,
+}
+
+// projectDirFlag is the generated project the add commands work on
+var projectDirFlag string
+
+func init() {
+	addCmd.PersistentFlags().StringVar(&projectDirFlag, "dir", ".", "Project directory")
+	addCmd.AddCommand(addWorkerCmd)
+	addCmd.AddCommand(addCommandCmd)
+	addCmd.AddCommand(addEndpointCmd)
+	addCmd.AddCommand(addModuleCmd)
+	rootCmd.AddCommand(addCmd)
+}
+
+func runAddWorker(cmd *cobra.Command, args []string) error {
+	files, err := generator.AddWorker(projectDirFlag, args[0])
+	if err != nil {
+		return err
+	}
+	printChanged(files)
+	fmt.Println("\nRun 'go test ./internal/jobs/...' and fill in the payload and handler.")
+	return nil
+}
+
+func runAddCommand(cmd *cobra.Command, args []string) error {
+	files, err := generator.AddCommand(projectDirFlag, args[0])
+	if err != nil {
+		return err
+	}
+	printChanged(files)
+	fmt.Println("\nRun 'go mod tidy', then 'go run ./cmd/cli --help'.")
+	return nil
+}
+
+func runAddEndpoint(cmd *cobra.Command, args []string) error {
+	files, err := generator.AddEndpoint(projectDirFlag, args[0], args[1])
+	if err != nil {
+		return err
+	}
+	printChanged(files)
+	fmt.Println("\nFill in the DTOs and handler, then run 'go test ./internal/server/...'.")
+	return nil
+}
+
+func runAddModule(cmd *cobra.Command, args []string) error {
+	files, err := generator.AddModule(projectDirFlag, args[0])
+	if err != nil {
+		return err
+	}
+	printChanged(files)
+	fmt.Println("\nRun 'templ generate' and 'go test ./internal/modules/...', then add a navbar link.")
+	return nil
+}
+
+// printChanged lists the files an add command created or modified
+func printChanged(files []string) {
+	fmt.Println("✅ Updated project:")
+	for _, f := range files {
+		fmt.Printf("   %s\n", f)
+	}
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/FACorreiaa/goforge/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var assetsCmd = &cobra.Command{
+	Use:   "assets",
+	Short: "Check a generated project's downloaded frontend assets",
+	Long:  `Check the third-party frontend files of a GoForge project. Run it from the project root or pass --dir.`,
+}
+
+var assetsVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify downloaded frontend assets against their pinned SHA-256 checksums",
+	Long: `Check the files make setup downloaded (htmx, Alpine.js, hyperscript,
+Basecoat, the DaisyUI plugin) against the SHA-256 checksums goforge pinned
+in assets.sha256. Fails, naming each file, when one is missing or differs:
+a CDN serving different bytes for a pinned version is a supply-chain red
+flag. make setup runs this after downloading.`,
+	Args: cobra.NoArgs,
+	RunE: runAssetsVerify,
+}
+
+var assetsChecksumsCmd = &cobra.Command{
+	Use:    "checksums",
+	Short:  "Print the checksums of every pinned frontend asset",
+	Long:   `For goforge maintainers bumping an asset version: download every pinned asset URL and print internal/generator/assets.sum.`,
+	Args:   cobra.NoArgs,
+	Hidden: true,
+	RunE:   runAssetsChecksums,
+}
+
+func init() {
+	assetsCmd.PersistentFlags().StringVar(&projectDirFlag, "dir", ".", "Project directory")
+	assetsCmd.AddCommand(assetsVerifyCmd)
+	assetsCmd.AddCommand(assetsChecksumsCmd)
+	rootCmd.AddCommand(assetsCmd)
+}
+
+func runAssetsVerify(cmd *cobra.Command, args []string) error {
+	verified, err := generator.VerifyAssets(projectDirFlag)
+	for _, f := range verified {
+		fmt.Printf("  ✓ %s\n", f)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ %d frontend assets match %s\n", len(verified), generator.AssetChecksumsFile)
+	return nil
+}
+
+func runAssetsChecksums(cmd *cobra.Command, args []string) error {
+	sums, err := generator.RecordAssetChecksums(&http.Client{Timeout: time.Minute})
+	if err != nil {
+		return err
+	}
+	for url, pinned := range generator.AssetURLs() {
+		if !pinned {
+			fmt.Fprintf(os.Stderr, "skipped %s: not a pinned version\n", url)
+		}
+	}
+	fmt.Print(sums)
+	return nil
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"runtime/debug"
+	"strings"
+
+	"github.com/FACorreiaa/goforge/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+// issuesURL is where bugs in goforge are reported
+const issuesURL = "https://github.com/FACorreiaa/goforge/issues/new"
+
+// runLog records this run for goforge diagnose
+var runLog = generator.NewRunLog(os.Args[1:])
+
+var (
+	diagnoseOutputFlag   string
+	diagnoseTemplateFlag bool
+)
+
+var diagnoseCmd = &cobra.Command{
+	Use:   "diagnose",
+	Short: "Package the last run into a redacted zip for a bug report",
+	Long: `goforge keeps a record of its last run: the command line, the options of
+the projects it generated, the files it wrote and the error or crash it
+ended with. Project names, module paths, template variables, your home
+directory and credentials are redacted from it. diagnose zips that record
+with the goforge and Go versions, the OS and the Go and GOFORGE_ environment
+variables, to attach to a bug report. Review the zip before sharing it.
+
+Example:
+  goforge diagnose
+  goforge diagnose --template    # also the source of the template that failed
+  goforge diagnose -o bug.zip`,
+	Args: cobra.NoArgs,
+	RunE: runDiagnose,
+}
+
+func init() {
+	diagnoseCmd.Flags().StringVarP(&diagnoseOutputFlag, "output", "o", "", "Zip file to write (default: goforge-diagnose-<time of the run>.zip)")
+	diagnoseCmd.Flags().BoolVar(&diagnoseTemplateFlag, "template", false, "Include the source of the template the run failed to render")
+	rootCmd.AddCommand(diagnoseCmd)
+}
+
+func runDiagnose(cmd *cobra.Command, args []string) error {
+	dir, err := generator.RunLogDir()
+	if err != nil {
+		return err
+	}
+	last, err := generator.LoadRunLog(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		cmd.SilenceUsage = true // nothing to package, not a usage mistake
+		return errors.New("no goforge run is recorded yet: run the command that failed again, then goforge diagnose")
+	}
+	if err != nil {
+		return err
+	}
+
+	path := diagnoseOutputFlag
+	if path == "" {
+		path = "goforge-diagnose-" + last.Started.Local().Format("20060102-150405") + ".zip"
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	files, err := generator.WriteDiagnosis(f, last, diagnoseTemplateFlag)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return err
+	}
+
+	outcome := "succeeded"
+	switch {
+	case last.Panic != "":
+		outcome = "crashed"
+	case len(last.Errors) > 0:
+		outcome = "failed"
+	}
+	fmt.Printf("✅ Wrote %s, from the run of %s that %s:\n", path, last.Started.Local().Format("2006-01-02 15:04:05"), outcome)
+	fmt.Printf("   goforge %s\n", strings.Join(last.Args, " "))
+	for _, name := range files {
+		fmt.Printf("  ✓ %s\n", name)
+	}
+	if diagnoseTemplateFlag && last.Template == "" {
+		fmt.Println("\nThe run did not fail on a template, so --template added nothing.")
+	} else if !diagnoseTemplateFlag && last.Template != "" {
+		fmt.Printf("\nThe run failed on the template %s: add --template to include its source.\n", last.Template)
+	}
+	fmt.Printf("\nReview it, then attach it to an issue: %s\n", issuesURL)
+	return nil
+}
+
+// saveRunLog keeps the record of this run for goforge diagnose
+func saveRunLog() error {
+	dir, err := generator.RunLogDir()
+	if err != nil {
+		return err
+	}
+	return runLog.Save(dir)
+}
+
+// recoverCrash is the CLI's panic handler: it records the panic in the run
+// log and points to goforge diagnose rather than ending on a stack trace
+func recoverCrash() {
+	v := recover()
+	if v == nil {
+		return
+	}
+	runLog.Crash(v, debug.Stack())
+	fmt.Fprintf(os.Stderr, "\n💥 goforge crashed: %v\n", v)
+	if err := saveRunLog(); err != nil {
+		fmt.Fprintf(os.Stderr, "The crash could not be recorded (%v).\n", err)
+		fmt.Fprintf(os.Stderr, "This is a bug in goforge: please report it with the command you ran at %s\n", issuesURL)
+	} else {
+		fmt.Fprintln(os.Stderr, "This is a bug in goforge. 'goforge diagnose' packages a redacted report of this run;")
+		fmt.Fprintf(os.Stderr, "attach it to an issue at %s\n", issuesURL)
+	}
+	os.Exit(2)
+}
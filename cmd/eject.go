@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/FACorreiaa/goforge/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var ejectCmd = &cobra.Command{
+	Use:   "eject",
+	Short: "Stop managing a project with goforge",
+	Long: `Make a clean break from goforge once a project has grown up: delete
+.goforge.yaml (options and file checksums) and .goforge/, and strip the
+goforge:* marker comments the add commands insert at. The code is otherwise
+untouched and builds as before, but goforge can no longer upgrade, regenerate
+or extend the project.
+
+Example:
+  goforge eject --dry-run
+  goforge eject`,
+	Args: cobra.NoArgs,
+	RunE: runEject,
+}
+
+var ejectDryRunFlag bool
+
+// ejectLosses is what a project gives up by ejecting
+var ejectLosses = []string{
+	"goforge upgrade: template fixes from new releases",
+	"goforge regen: restoring scaffold files from their templates",
+	"goforge add worker|command|endpoint|module and generate model",
+	"goforge rename-module and manifest validate (edit and drift checks)",
+}
+
+func init() {
+	ejectCmd.Flags().StringVar(&projectDirFlag, "dir", ".", "Project directory")
+	ejectCmd.Flags().BoolVar(&ejectDryRunFlag, "dry-run", false, "Report the changes without writing them")
+	rootCmd.AddCommand(ejectCmd)
+}
+
+func runEject(cmd *cobra.Command, args []string) error {
+	report, err := generator.Eject(projectDirFlag, ejectDryRunFlag)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range report.Removed {
+		fmt.Printf("   removed:  %s\n", f)
+	}
+	files := make([]string, 0, len(report.Markers))
+	for f := range report.Markers {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+	for _, f := range files {
+		fmt.Printf("   stripped: %s (%d markers)\n", f, report.Markers[f])
+	}
+
+	fmt.Println("\nNo longer available for this project:")
+	for _, loss := range ejectLosses {
+		fmt.Printf("   - %s\n", loss)
+	}
+	if len(report.Leftovers) > 0 {
+		fmt.Println("\nMerge or delete these leftovers from earlier upgrades and regens:")
+		for _, f := range report.Leftovers {
+			fmt.Printf("   %s\n", f)
+		}
+	}
+	if len(report.References) > 0 {
+		fmt.Println("\nComments and docs still mention goforge commands:")
+		for _, ref := range report.References {
+			fmt.Printf("   %s\n", ref)
+		}
+	}
+
+	if ejectDryRunFlag {
+		fmt.Println("\nDry run: nothing was written.")
+		return nil
+	}
+	fmt.Println("\n✅ Ejected. Run 'go build ./...' and commit the result.")
+	return nil
+}
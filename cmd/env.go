@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/FACorreiaa/goforge/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var envCmd = &cobra.Command{
+	Use:   "env",
+	Short: "Check a generated project's environment variables",
+	Long:  `Check the environment variables of a GoForge project. Run it from the project root or pass --dir.`,
+}
+
+var envCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Cross-check the variables the code reads with .env.example and the deployment manifests",
+	Long: `Scan the Go code of a GoForge project for the environment variables it
+reads, through os.Getenv, os.LookupEnv or a helper passing its argument on to
+them (config.getEnv), and cross-check them with the files that set them.
+
+  missing   read by the code, but neither .env.example nor a profile in
+            internal/config/env sets it: nobody deploying the app learns
+            it exists
+  orphaned  set in .env.example, a profile, the app service of a compose
+            file, the [env] table of fly.toml or the systemd environment
+            file, but read by nothing: a leftover, or a typo (the closest
+            variable the code reads is suggested)
+
+Variables the Makefile, Dockerfile, compose files or scripts expand count as
+read. Each finding comes with a suggested fix. Exits non-zero when it finds
+one; make env-check runs it, in CI too.
+
+Example:
+  goforge env check
+  goforge env check --dir ./myapp`,
+	Args: cobra.NoArgs,
+	RunE: runEnvCheck,
+}
+
+func init() {
+	envCmd.PersistentFlags().StringVar(&projectDirFlag, "dir", ".", "Project directory")
+	envCmd.AddCommand(envCheckCmd)
+	rootCmd.AddCommand(envCmd)
+}
+
+func runEnvCheck(cmd *cobra.Command, args []string) error {
+	report, err := generator.CheckEnv(projectDirFlag)
+	if err != nil {
+		return err
+	}
+	if len(report.Missing) == 0 && len(report.Orphaned) == 0 {
+		fmt.Printf("✅ The %d variables the code reads are in .env.example, and every one set is read\n", report.Read)
+		return nil
+	}
+
+	for _, group := range []struct {
+		label    string
+		findings []generator.EnvFinding
+	}{
+		{"Missing from .env.example", report.Missing},
+		{"Orphaned: set but never read", report.Orphaned},
+	} {
+		if len(group.findings) == 0 {
+			continue
+		}
+		fmt.Println(group.label)
+		for _, f := range group.findings {
+			fmt.Printf("  %-24s %s\n", f.Name, f.File)
+			fmt.Printf("  %-24s → %s\n", "", f.Fix)
+		}
+	}
+	cmd.SilenceUsage = true // the findings are the output, not a usage mistake
+	return fmt.Errorf("%d missing, %d orphaned variables", len(report.Missing), len(report.Orphaned))
+}
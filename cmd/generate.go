@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/FACorreiaa/goforge/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var generateCmd = &cobra.Command{
+	Use:     "generate",
+	Aliases: []string{"g"},
+	Short:   "Generate code in a project from a schema",
+	Long:    `Generate code in an existing GoForge project. Run it from the project root or pass --dir.`,
+}
+
+var generateModelCmd = &cobra.Command{
+	Use:   "model <struct.go|table.sql>",
+	Short: "Generate a migration and repository from a Go struct or CREATE TABLE",
+	Long: `Generate a goose migration and a pgx repository (Create, Get, List, Update,
+Delete) in internal/repository from either a Go struct or a CREATE TABLE
+statement (.sql files). With --form, also generate a Templ form component.
+
+Go structs map fields to columns via db tags (snake_case otherwise); an ID
+field becomes the primary key and CreatedAt/UpdatedAt default to now().
+
+Example:
+  goforge generate model schema/note.go --form
+  goforge generate model schema/notes.sql`,
+	Args: cobra.ExactArgs(1),
+	RunE: runGenerateModel,
+}
+
+var (
+	modelTypeFlag string
+	modelFormFlag bool
+)
+
+func init() {
+	generateCmd.PersistentFlags().StringVar(&projectDirFlag, "dir", ".", "Project directory")
+	generateModelCmd.Flags().StringVar(&modelTypeFlag, "type", "", "Struct to use when the Go file declares several")
+	generateModelCmd.Flags().BoolVar(&modelFormFlag, "form", false, "Also generate a Templ form component in views/components")
+	generateCmd.AddCommand(generateModelCmd)
+	rootCmd.AddCommand(generateCmd)
+}
+
+func runGenerateModel(cmd *cobra.Command, args []string) error {
+	files, err := generator.GenerateModel(projectDirFlag, args[0], generator.ModelOptions{
+		Type: modelTypeFlag,
+		Form: modelFormFlag,
+	})
+	if err != nil {
+		return err
+	}
+	printChanged(files)
+	fmt.Println("\nRun 'make db-up' to apply the migration.")
+	return nil
+}
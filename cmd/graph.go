@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/FACorreiaa/goforge/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Graph a generated project's routes, handlers, Templ pages and components",
+	Long: `Print how a GoForge project serves its pages: each route in the registry
+(internal/server/routes.go) points to its handler, each handler to the Templ
+components it renders, directly or through a helper, and each component to
+the ones it calls (@components.Navbar()). Only components reachable from a
+route are shown.
+
+--format mermaid (the default) prints a flowchart that GitHub and most
+Markdown viewers render; --format dot prints a Graphviz graph.
+
+Example:
+  goforge graph > docs/routes.mmd
+  goforge graph --format dot | dot -Tsvg > routes.svg`,
+	Args: cobra.NoArgs,
+	RunE: runGraph,
+}
+
+var graphFormatFlag string
+
+func init() {
+	graphCmd.Flags().StringVar(&projectDirFlag, "dir", ".", "Project directory")
+	graphCmd.Flags().StringVar(&graphFormatFlag, "format", generator.GraphMermaid, "Output format: mermaid or dot")
+	rootCmd.AddCommand(graphCmd)
+}
+
+func runGraph(cmd *cobra.Command, args []string) error {
+	if graphFormatFlag != generator.GraphMermaid && graphFormatFlag != generator.GraphDOT {
+		return fmt.Errorf("invalid format %q: use %s or %s", graphFormatFlag, generator.GraphMermaid, generator.GraphDOT)
+	}
+	g, err := generator.BuildGraph(projectDirFlag)
+	if err != nil {
+		return err
+	}
+	if graphFormatFlag == generator.GraphDOT {
+		fmt.Print(g.DOT())
+	} else {
+		fmt.Print(g.Mermaid())
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/FACorreiaa/goforge/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+var langFlag string
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&langFlag, "lang", i18n.Detect(),
+		"Language of prompts, output and the generated README's quick start: "+strings.Join(i18n.Languages, ", ")+" (default from LANGUAGE, LC_ALL, LC_MESSAGES or LANG)")
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		return i18n.Set(langFlag)
+	}
+}
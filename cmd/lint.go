@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/FACorreiaa/goforge/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var lintProjectCmd = &cobra.Command{
+	Use:   "lint-project",
+	Short: "Check a generated project against the scaffold's conventions",
+	Long: `A scaffold-aware go vet: check a GoForge project for the mistakes the Go
+tools do not catch, or only catch later with a less helpful message.
+
+  routes      routes in internal/server/routes.go whose handler is not
+              declared in internal/server
+  templ       .templ files never generated, or changed since: run make templ
+  env         environment variables the code reads that neither .env.example
+              nor a profile in internal/config/env sets
+  migrations  goose migrations without a version, with one another file
+              uses, without -- +goose Up, or with a timestamp version among
+              numbered ones (it sorts after every migration added later)
+
+Exits non-zero when it finds an issue, so CI can run it next to go vet.
+
+Example:
+  goforge lint-project
+  goforge lint-project --dir ./myapp`,
+	Args: cobra.NoArgs,
+	RunE: runLintProject,
+}
+
+func init() {
+	lintProjectCmd.Flags().StringVar(&projectDirFlag, "dir", ".", "Project directory")
+	rootCmd.AddCommand(lintProjectCmd)
+}
+
+// lintCheckLabels head each group of the lint-project output
+var lintCheckLabels = map[string]string{
+	generator.LintRoutes:     "Routes",
+	generator.LintTempl:      "Templ",
+	generator.LintEnv:        "Environment",
+	generator.LintMigrations: "Migrations",
+}
+
+func runLintProject(cmd *cobra.Command, args []string) error {
+	issues, err := generator.LintProject(projectDirFlag)
+	if err != nil {
+		return err
+	}
+	if len(issues) == 0 {
+		fmt.Println("✅ The project follows the scaffold's conventions")
+		return nil
+	}
+
+	for _, check := range generator.LintChecks() {
+		header := false
+		for _, issue := range issues {
+			if issue.Check != check {
+				continue
+			}
+			if !header {
+				fmt.Println(lintCheckLabels[check])
+				header = true
+			}
+			fmt.Printf("  %s: %s\n", issue.File, issue.Message)
+		}
+	}
+	cmd.SilenceUsage = true // the issues are the output, not a usage mistake
+	if len(issues) == 1 {
+		return fmt.Errorf("1 issue found")
+	}
+	return fmt.Errorf("%d issues found", len(issues))
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/FACorreiaa/goforge/internal/generator"
+	"github.com/FACorreiaa/goforge/internal/manifest"
+	"github.com/spf13/cobra"
+)
+
+var manifestCmd = &cobra.Command{
+	Use:   "manifest",
+	Short: "Inspect the project manifest (.goforge.yaml)",
+	Long: `Every generated project records how it was generated in .goforge.yaml:
+the goforge version, template source, options, enabled features and a
+checksum of each generated file. The add, generate and rename-module
+commands require it and keep it up to date.`,
+}
+
+var manifestValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check .goforge.yaml against this goforge and the project",
+	Long: `Check that .goforge.yaml is well formed, uses a supported schema version,
+records known options and features and matches the module in go.mod.
+Generated files edited or removed since goforge wrote them are listed too;
+that is not an error.`,
+	Args: cobra.NoArgs,
+	RunE: runManifestValidate,
+}
+
+var manifestInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Create .goforge.yaml for a project generated by an older goforge",
+	Long: `Create .goforge.yaml for a project generated before goforge recorded a
+manifest. The generation options are unknown, so only the module and the
+checksums of the current files are recorded.`,
+	Args: cobra.NoArgs,
+	RunE: runManifestInit,
+}
+
+var manifestMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Upgrade .goforge.yaml to the current schema version",
+	Long: `Upgrade a .goforge.yaml written by an older goforge to the current schema
+version, applying each migration in order. Other commands read older
+manifests too and save them in the current schema when they update them.`,
+	Args: cobra.NoArgs,
+	RunE: runManifestMigrate,
+}
+
+var migrateDryRunFlag bool
+
+func init() {
+	manifestMigrateCmd.Flags().BoolVar(&migrateDryRunFlag, "dry-run", false, "Print the migrations without writing the manifest")
+	manifestCmd.PersistentFlags().StringVar(&projectDirFlag, "dir", ".", "Project directory")
+	manifestCmd.AddCommand(manifestValidateCmd)
+	manifestCmd.AddCommand(manifestInitCmd)
+	manifestCmd.AddCommand(manifestMigrateCmd)
+	rootCmd.AddCommand(manifestCmd)
+}
+
+func runManifestValidate(cmd *cobra.Command, args []string) error {
+	m, errs := generator.ValidateManifest(projectDirFlag)
+	if len(errs) > 0 {
+		fmt.Printf("❌ %s is invalid:\n", manifest.FileName)
+		for _, err := range errs {
+			fmt.Printf("   %v\n", err)
+		}
+		return fmt.Errorf("%d problem(s) in %s", len(errs), manifest.FileName)
+	}
+
+	modified, missing, err := generator.ManifestDrift(projectDirFlag, m)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ %s is valid (schema %d, goforge %s, %d features, %d files)\n",
+		manifest.FileName, m.SchemaVersion, m.Generator.Version, len(m.Features), len(m.Files))
+	if from := m.MigratedFrom(); from != 0 {
+		fmt.Printf("   schema %d on disk: run 'goforge manifest migrate' to upgrade it\n", from)
+	}
+	for _, f := range modified {
+		fmt.Printf("   modified: %s\n", f)
+	}
+	for _, f := range missing {
+		fmt.Printf("   missing:  %s\n", f)
+	}
+	return nil
+}
+
+func runManifestInit(cmd *cobra.Command, args []string) error {
+	m, err := generator.InitManifest(projectDirFlag)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ Created %s (%d files)\n", manifest.FileName, len(m.Files))
+	return nil
+}
+
+func runManifestMigrate(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(manifest.Path(projectDirFlag))
+	if err != nil {
+		return err
+	}
+	m, applied, err := manifest.Migrate(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", manifest.FileName, err)
+	}
+	if len(applied) == 0 {
+		fmt.Printf("✅ %s is already at schema %d\n", manifest.FileName, manifest.SchemaVersion)
+		return nil
+	}
+
+	for _, step := range applied {
+		fmt.Printf("   %s\n", step)
+	}
+	if migrateDryRunFlag {
+		fmt.Println("\nDry run: manifest not written.")
+		return nil
+	}
+	if err := m.Save(projectDirFlag); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Migrated %s to schema %d\n", manifest.FileName, manifest.SchemaVersion)
+	return nil
+}
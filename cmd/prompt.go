@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/FACorreiaa/goforge/internal/i18n"
+	"github.com/charmbracelet/huh"
+)
+
+// simplePromptsEnv turns --simple-prompts on by default
+const simplePromptsEnv = "GOFORGE_SIMPLE_PROMPTS"
+
+var simplePromptsFlag bool
+
+// promptInput is stdin for the simple prompts, shared by every form
+var promptInput = &lineReader{r: bufio.NewReader(os.Stdin)}
+
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&simplePromptsFlag, "simple-prompts", defaultSimplePrompts(),
+		"Ask questions as numbered plain-text prompts instead of the TUI, for screen readers and limited terminals (default on with TERM=dumb or $"+simplePromptsEnv+"=true)")
+}
+
+// defaultSimplePrompts is on when the terminal cannot redraw a TUI, or the
+// user asked for plain prompts through the environment
+func defaultSimplePrompts() bool {
+	if on, err := strconv.ParseBool(os.Getenv(simplePromptsEnv)); err == nil {
+		return on
+	}
+	return os.Getenv("TERM") == "dumb"
+}
+
+// runForm asks the questions in form. With --simple-prompts it uses huh's
+// accessible mode: the same fields in the same order, printed line by line
+// and answered on stdin (a number for a choice, y/n for a confirmation), with
+// nothing redrawn or driven by arrow keys.
+func runForm(form *huh.Form) error {
+	if !simplePromptsFlag {
+		return form.Run()
+	}
+	if err := form.WithAccessible(true).WithInput(promptInput).Run(); err != nil {
+		return err
+	}
+	// Without an answer huh keeps the default, which skips validation
+	if promptInput.eof {
+		return errors.New(i18n.T("prompt.stdin_ended"))
+	}
+	return nil
+}
+
+// lineReader hands out its input one line per Read. huh reads each answer
+// through a new bufio.Scanner, which would otherwise swallow the answers to
+// later questions when they are piped in.
+type lineReader struct {
+	r       *bufio.Reader
+	pending []byte
+	eof     bool
+}
+
+func (l *lineReader) Read(p []byte) (int, error) {
+	if len(l.pending) == 0 {
+		line, err := l.r.ReadBytes('\n')
+		if len(line) == 0 {
+			l.eof = errors.Is(err, io.EOF)
+			return 0, err
+		}
+		if line[len(line)-1] != '\n' {
+			line = append(line, '\n') // End the last answer so it is read on its own
+		}
+		l.pending = line
+	}
+	n := copy(p, l.pending)
+	l.pending = l.pending[n:]
+	return n, nil
+}
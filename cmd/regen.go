@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/FACorreiaa/goforge/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var regenCmd = &cobra.Command{
+	Use:   "regen <path>",
+	Short: "Render one scaffold file again from its template",
+	Long: `Restore a scaffold file (a broken Makefile, a deleted Dockerfile) by rendering
+its template again with the options and template bundle version recorded in
+.goforge.yaml. The current version is kept next to it as <path>.goforge-bak;
+a file that already matches its template is left alone.
+
+Example:
+  goforge regen Makefile
+  goforge regen internal/server/routes.go`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRegen,
+}
+
+func init() {
+	regenCmd.Flags().StringVar(&projectDirFlag, "dir", ".", "Project directory")
+	rootCmd.AddCommand(regenCmd)
+}
+
+func runRegen(cmd *cobra.Command, args []string) error {
+	result, err := generator.Regen(projectDirFlag, args[0])
+	if err != nil {
+		return err
+	}
+	if result.Unchanged {
+		fmt.Printf("✅ %s already matches its template\n", result.Path)
+		return nil
+	}
+	fmt.Printf("   regenerated: %s\n", result.Path)
+	if result.Backup != "" {
+		fmt.Printf("   backup:      %s\n", result.Backup)
+	}
+	fmt.Println("\n✅ Done. Compare with the backup and move your changes over before deleting it.")
+	return nil
+}
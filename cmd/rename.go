@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/FACorreiaa/goforge/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var renameModuleCmd = &cobra.Command{
+	Use:   "rename-module <new-module>",
+	Short: "Rename the module path of a generated project",
+	Long: `Rewrite the module path across go.mod, Go imports, Templ files and config of a
+generated project, with the same replacement generation uses. Longer paths
+that merely share the old prefix are left alone.
+
+Example:
+  goforge rename-module github.com/acme/shop`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRenameModule,
+}
+
+func init() {
+	renameModuleCmd.Flags().StringVar(&projectDirFlag, "dir", ".", "Project directory")
+	rootCmd.AddCommand(renameModuleCmd)
+}
+
+func runRenameModule(cmd *cobra.Command, args []string) error {
+	files, err := generator.RenameModule(projectDirFlag, args[0])
+	if err != nil {
+		return err
+	}
+	printChanged(files)
+	fmt.Println("\nRun 'make templ && go build ./...' to regenerate Templ code and check the build.")
+	return nil
+}
@@ -1,14 +1,22 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strconv"
 	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/FACorreiaa/goforge/internal/generator"
+	"github.com/FACorreiaa/goforge/internal/i18n"
 	"github.com/charmbracelet/huh"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var (
@@ -40,6 +48,87 @@ const (
 const (
 	DeployNone         = "none"
 	DeployHetznerCaddy = "hetzner-caddy"
+	DeploySystemd      = "systemd"
+)
+
+// Database options
+const (
+	DatabasePostgres = "postgres"
+	DatabaseMySQL    = "mysql"
+	DatabaseSQLite   = "sqlite"
+	DatabaseNone     = "none"
+)
+
+// Search backend options
+const (
+	SearchNone        = "none"
+	SearchPgTrgm      = "pg_trgm"
+	SearchMeilisearch = "meilisearch"
+	SearchBleve       = "bleve"
+)
+
+// Analytics provider options
+const (
+	AnalyticsNone      = "none"
+	AnalyticsPlausible = "plausible"
+	AnalyticsUmami     = "umami"
+)
+
+// Error reporting options
+const (
+	ErrorsNone      = "none"
+	ErrorsSentry    = "sentry"
+	ErrorsGlitchTip = "glitchtip"
+)
+
+// Load testing tool options
+const (
+	LoadTestNone   = "none"
+	LoadTestK6     = "k6"
+	LoadTestVegeta = "vegeta"
+)
+
+// Mock generator options
+const (
+	MocksNone    = "none"
+	MocksMockery = "mockery"
+	MocksGomock  = "gomock"
+)
+
+// Reverse proxy options
+const (
+	ProxyNone    = "none"
+	ProxyCaddy   = "caddy"
+	ProxyNginx   = "nginx"
+	ProxyTraefik = "traefik"
+)
+
+// SQLite replication options
+const (
+	SQLiteReplicationNone   = "none"
+	SQLiteReplicationLiteFS = "litefs"
+	SQLiteReplicationTurso  = "turso"
+)
+
+// Development database options
+const (
+	DevDBCompose        = "compose"
+	DevDBTestcontainers = "testcontainers"
+)
+
+// Example resource options
+const (
+	ExampleUsers = "users"
+	ExampleNote  = "note"
+	ExampleTodo  = "todo"
+	ExampleNone  = "none"
+)
+
+// golangci-lint presets
+const (
+	LintStrict   = "strict"
+	LintStandard = "standard"
+	LintMinimal  = "minimal"
 )
 
 var rootCmd = &cobra.Command{
@@ -69,31 +158,182 @@ var (
 	cssFrameworkFlag   string
 	themeFlag          string
 	deployProviderFlag string
+	dbFlag             string
 	noDBFlag           bool
+	dbReplicasFlag     bool
 	includeHooksFlag   bool
+	viteFlag           bool
+	typescriptFlag     bool
+	previewFlag        bool
+	seoFlag            bool
+	staticExportFlag   bool
+	contentFlag        bool
+	searchFlag         string
+	geoipFlag          bool
+	pdfFlag            bool
+	imagesFlag         bool
+	notificationsFlag  bool
+	activityFlag       bool
+	settingsFlag       bool
+	onboardingFlag     bool
+	teamsFlag          bool
+	billingFlag        bool
+	demoFlag           bool
+	singleBinaryFlag   bool
+	litestreamFlag     bool
+	sqliteReplFlag     string
+	postgisFlag        bool
+	autoMigrateFlag    bool
+	devDBFlag          string
+	factoriesFlag      bool
+	mocksFlag          string
+	fuzzFlag           bool
+	snapshotsFlag      bool
+	analyticsFlag      string
+	gdprFlag           bool
+	errorsFlag         string
+	pprofFlag          bool
+	statusPageFlag     bool
+	loadTestFlag       string
+	lintFlag           string
+	goVersionFlag      string
+	e2eFlag            bool
+	openAPIFlag        bool
+	jobsFlag           bool
+	sbomFlag           bool
+	signFlag           bool
+	httpsDevFlag       bool
+	embedAssetsFlag    bool
+	proxyFlag          string
+	exampleFlag        string
+	datatableFlag      bool
+	importExportFlag   bool
+	ftsFlag            bool
+	basePathFlag       string
+	assetMirrorFlag    string
+	binariesFlag       string
+	channelFlag        string
+	atFlag             string
+	leanFlag           bool
+	configFlag         string
+	batchFlag          string
+	parallelFlag       int
+	varsFlag           string
 )
 
 func init() {
 	newCmd.Flags().StringVarP(&frontendFlag, "frontend", "f", "", "Frontend stack: htmx, htmx-hyperscript, htmx-alpine, htmx-surreal")
 	newCmd.Flags().StringVarP(&cssFrameworkFlag, "css", "c", "", "CSS framework: daisyui, templui, basecoat")
 	newCmd.Flags().StringVarP(&themeFlag, "theme", "t", "", "Theme: none, caffeine (only for basecoat)")
-	newCmd.Flags().StringVarP(&deployProviderFlag, "deploy", "d", "", "Deployment provider: none, hetzner-caddy")
-	newCmd.Flags().BoolVar(&noDBFlag, "no-db", false, "Skip database setup (Postgres + Goose)")
+	newCmd.Flags().StringVarP(&deployProviderFlag, "deploy", "d", "", "Deployment provider: none, hetzner-caddy, systemd")
+	newCmd.Flags().StringVar(&dbFlag, "db", "", "Database: postgres (pgx), mysql (database/sql), sqlite (one file, migrations applied on start), none")
+	newCmd.Flags().BoolVar(&noDBFlag, "no-db", false, "Skip database setup (same as --db none)")
+	newCmd.Flags().BoolVar(&postgisFlag, "postgis", false, "Run Postgres with PostGIS and include a places table with a point column, a nearby query (ST_DWithin), a GeoJSON endpoint and a Leaflet map page")
+	newCmd.Flags().BoolVar(&autoMigrateFlag, "auto-migrate", false, "Embed the Goose migrations into the server and apply them on start under an advisory lock (AUTO_MIGRATE=false turns it off)")
+	newCmd.Flags().StringVar(&devDBFlag, "dev-db", "", "Where make dev finds Postgres: compose (docker-compose up), testcontainers (a disposable container started by cmd/devstack and removed on exit)")
+	newCmd.Flags().BoolVar(&factoriesFlag, "factories", false, "Include pkg/factory (a test data builder per model) and dbtest, which runs each database test in a transaction rolled back at its end")
+	newCmd.Flags().StringVar(&mocksFlag, "mocks", "", "Mock generator for the server's boundary interfaces (database, example store): none, mockery, gomock (make mocks)")
+	newCmd.Flags().BoolVar(&dbReplicasFlag, "db-replicas", false, "Include PgBouncer in docker-compose and separate primary/read-replica pools with read/write routing")
 	newCmd.Flags().BoolVar(&includeHooksFlag, "hooks", false, "Include pre-commit hooks (templ fmt, gofumpt, golangci-lint)")
+	newCmd.Flags().BoolVar(&viteFlag, "vite", false, "Include Vite for TypeScript islands (dev proxy + manifest-based script tags)")
+	newCmd.Flags().BoolVar(&typescriptFlag, "typescript", false, "Include TypeScript in src/ts bundled with esbuild (no Node.js required)")
+	newCmd.Flags().BoolVar(&previewFlag, "preview", false, "Include a dev-only component preview server (cmd/preview)")
+	newCmd.Flags().BoolVar(&seoFlag, "seo", false, "Include SEO baseline (meta/OpenGraph partials, sitemap.xml, robots.txt, canonical URLs)")
+	newCmd.Flags().BoolVar(&staticExportFlag, "static-export", false, "Include 'make export' to render GET routes to static HTML in dist/")
+	newCmd.Flags().BoolVar(&contentFlag, "content", false, "Include a Markdown blog (goldmark, front matter, posts pages, RSS feed)")
+	newCmd.Flags().StringVar(&searchFlag, "search", "", "Search backend: none, pg_trgm, meilisearch, bleve")
+	newCmd.Flags().BoolVar(&geoipFlag, "geoip", false, "Include GeoIP middleware (trusted-proxy client IP, MaxMind country, locale in request context)")
+	newCmd.Flags().BoolVar(&pdfFlag, "pdf", false, "Include PDF generation with headless Chromium (chromedp): an example invoice rendered from Templ, a download route and a Chromium service in docker-compose")
+	newCmd.Flags().BoolVar(&imagesFlag, "images", false, "Include image uploads: resizing to WebP/JPEG variants in pure Go, signed cacheable URLs and a Templ <picture> component with srcset")
+	newCmd.Flags().BoolVar(&notificationsFlag, "notifications", false, "Include in-app notifications: a table and migration, a navbar bell updated over server-sent events, mark-as-read endpoints and send hooks for jobs and webhooks")
+	newCmd.Flags().BoolVar(&activityFlag, "activity", false, "Include an activity feed: a polymorphic events table, a recording helper and a Templ timeline with infinite scroll (notifies event recipients with --notifications)")
+	newCmd.Flags().BoolVar(&settingsFlag, "settings", false, "Include an account settings page on the users table: profile, password change, theme preference and API tokens, ready for your sign-in")
+	newCmd.Flags().BoolVar(&onboardingFlag, "onboarding", false, "Include an onboarding wizard: multi-step HTMX forms with a progress indicator, per-step validation and progress saved in the database to resume later")
+	newCmd.Flags().BoolVar(&teamsFlag, "teams", false, "Include organizations on the users table: memberships with owner/admin/member roles and invitation links, ready for your sign-in")
+	newCmd.Flags().BoolVar(&billingFlag, "billing", false, "Include Stripe subscriptions on the users table: plans, lifecycle webhooks, feature gating by plan, a pricing page and the customer portal")
+	newCmd.Flags().BoolVar(&demoFlag, "demo", false, "Include a read-only demo mode (DEMO_MODE=true blocks writes with a toast), demo content for the example tables and a make screenshots target (rod)")
+	newCmd.Flags().BoolVar(&singleBinaryFlag, "single-binary", false, "One deployable binary with zero external services: SQLite (--db sqlite), migrations embedded and applied on start, embedded assets")
+	newCmd.Flags().StringVar(&sqliteReplFlag, "sqlite-replication", "", "Distribute the SQLite database: none, litefs (Fly.io, writes forwarded to the primary), turso (libsql driver)")
+	newCmd.Flags().BoolVar(&litestreamFlag, "litestream", false, "Include a litestream.yml and make targets streaming the SQLite database to object storage for backups")
+	newCmd.Flags().StringVar(&analyticsFlag, "analytics", "", "Analytics provider: none, plausible, umami")
+	newCmd.Flags().BoolVar(&gdprFlag, "gdpr", false, "Include a cookie consent banner and privacy/terms pages")
+	newCmd.Flags().StringVar(&errorsFlag, "errors", "", "Error reporting: none, sentry, glitchtip")
+	newCmd.Flags().BoolVar(&pprofFlag, "pprof", false, "Include pprof and expvar on an internal diagnostics port (make profile-cpu)")
+	newCmd.Flags().BoolVar(&statusPageFlag, "status-page", false, "Include an internal /status page (build, uptime, database pool, job queue, last migrations) refreshed over HTMX polling, behind STATUS_PASSWORD in production")
+	newCmd.Flags().StringVar(&loadTestFlag, "loadtest", "", "Load testing tool: none, k6, vegeta")
+	newCmd.Flags().BoolVar(&e2eFlag, "e2e", false, "Include a Playwright end-to-end test suite with a compose test profile and CI workflow")
+	newCmd.Flags().BoolVar(&fuzzFlag, "fuzz", false, "Include fuzz tests for the input handling code (validation helpers, forms, CSV imports, blog posts), make fuzz and a CI workflow")
+	newCmd.Flags().BoolVar(&snapshotsFlag, "snapshots", false, "Include snapshot tests comparing rendered Templ components and pages with golden files (make snapshots-update)")
+	newCmd.Flags().BoolVar(&openAPIFlag, "openapi", false, "Include an OpenAPI spec for the JSON API with contract tests (route drift test, schemathesis, oasdiff)")
+	newCmd.Flags().BoolVar(&jobsFlag, "jobs", false, "Include an in-process background jobs runner (extend it with 'goforge add worker')")
+	newCmd.Flags().BoolVar(&sbomFlag, "sbom", false, "Include CycloneDX SBOM generation with Syft (make sbom, CI workflow, GoReleaser SBOMs)")
+	newCmd.Flags().BoolVar(&signFlag, "sign", false, "Include a tag-triggered release workflow publishing cosign-signed binaries and image with SLSA provenance")
+	newCmd.Flags().BoolVar(&httpsDevFlag, "https-dev", false, "Serve HTTPS in development with mkcert certificates (make certs), scheme-aware Secure cookies and HSTS")
+	newCmd.Flags().BoolVar(&embedAssetsFlag, "embed-assets", false, "Serve embedded assets with build-time gzip/brotli variants and ETags; the image ships only the binary")
+	newCmd.Flags().StringVar(&proxyFlag, "proxy", "", "Reverse proxy in docker-compose: none, caddy, nginx, traefik (TLS, compression, asset caching, WebSocket/SSE passthrough)")
+	newCmd.Flags().StringVar(&exampleFlag, "example-resource", "", "Example resource: users (default, users table migration), note, todo (migration, repository, handlers and pages), none")
+	newCmd.Flags().BoolVar(&datatableFlag, "datatable", false, "Include a data table page for the note or todo example: server-side sorting, filtering, pagination, column visibility and CSV export")
+	newCmd.Flags().BoolVar(&ftsFlag, "fts", false, "Include Postgres full-text search for the note or todo example: a tsvector column kept by a trigger, ranked and highlighted results and an HTMX live-search page")
+	newCmd.Flags().BoolVar(&importExportFlag, "import-export", false, "Include CSV import with per-row validation errors and streamed CSV/Excel export for the note or todo example, with a progress bar for background exports")
+	newCmd.Flags().StringVar(&binariesFlag, "binaries", "server", "Comma-separated cmd/ entrypoints to generate: server, worker, cli (each gets a Dockerfile target, compose service and make target)")
+	newCmd.Flags().StringVar(&basePathFlag, "base-path", "", "Serve the app under a subpath such as /app: routes, asset URLs, HTMX endpoints, PWA scope and proxy configs")
+	newCmd.Flags().StringVar(&assetMirrorFlag, "asset-mirror", os.Getenv(generator.AssetMirrorEnv), "Mirror base URL for htmx, Tailwind and other downloads in make setup, the Dockerfile and CI: https://host/path is fetched from <mirror>/host/path (default $"+generator.AssetMirrorEnv+")")
+	newCmd.Flags().StringVar(&lintFlag, "lint", "", "golangci-lint preset: strict, standard, minimal")
+	newCmd.Flags().StringVar(&goVersionFlag, "go-version", "", "Go release for go.mod, the Docker image and golangci-lint, 1.N or 1.N.P (default: the local go version)")
+	newCmd.Flags().StringVar(&channelFlag, "channel", generator.ChannelStable, "Template channel: stable, edge (newer Go and APIs before they reach stable)")
+	newCmd.Flags().StringVar(&atFlag, "at", "", "Older template bundle version of the channel to generate with, as recorded in a project's .goforge.yaml (rebuilt from changesets or fetched from the goforge release that shipped it)")
+	newCmd.Flags().BoolVar(&leanFlag, "lean", false, "Minimal scaffold for microservices: no PWA assets, landing page demo sections, sample migration or README boilerplate")
+	newCmd.Flags().StringVar(&configFlag, "config", "", "Stack config (YAML) defining several services generated into subdirectories with a root docker-compose.yml")
+	newCmd.Flags().StringVar(&batchFlag, "batch", "", "CSV file of projects to generate in one run: a name,module header, then goforge new options by flag name")
+	newCmd.Flags().IntVar(&parallelFlag, "parallel", 0, "Projects --batch generates at a time (default: one per CPU)")
+	newCmd.Flags().StringVar(&varsFlag, "vars", "", "JSON file of template variables, used by templates as {{ var \"name\" }}")
 	rootCmd.AddCommand(newCmd)
 }
 
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	defer recoverCrash()
+	ran, err := rootCmd.ExecuteC()
+	// diagnose packages the previous run, so it does not replace it
+	if ran != diagnoseCmd && ran != rootCmd {
+		runLog.Fail(err)
+		saveRunLog() // best-effort: a run is never failed by its log
+	}
+	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
 }
 
 func runNew(cmd *cobra.Command, args []string) error {
+	if batchFlag != "" {
+		return runNewBatch(cmd, args)
+	}
+	if configFlag != "" {
+		return runNewStack(cmd, args)
+	}
+	var vars map[string]string
+	if varsFlag != "" {
+		var err error
+		if vars, err = generator.LoadVars(varsFlag); err != nil {
+			return err
+		}
+	}
+
 	var projectName, modulePath, frontend, cssFramework, theme, deployProvider string
-	var includeDB = !noDBFlag
+	var database = dbFlag
 	var includeHooks = includeHooksFlag
+	if noDBFlag {
+		if database != "" && database != DatabaseNone {
+			return errors.New(i18n.T("error.db_no_db"))
+		}
+		database = DatabaseNone
+	}
+	if singleBinaryFlag {
+		if database != "" && database != DatabaseSQLite {
+			return errors.New(i18n.T("error.single_binary_db", database))
+		}
+		database = DatabaseSQLite
+	}
 
 	// Handle arguments
 	if len(args) >= 2 {
@@ -105,13 +345,13 @@ func runNew(cmd *cobra.Command, args []string) error {
 		form := huh.NewForm(
 			huh.NewGroup(
 				huh.NewInput().
-					Title("Module Path").
-					Description("Go module path (e.g., github.com/username/project)").
+					Title(i18n.T("prompt.module_path.title")).
+					Description(i18n.T("prompt.module_path.description")).
 					Value(&modulePath).
 					Validate(validateModulePath),
 			),
 		)
-		if err := form.Run(); err != nil {
+		if err := runForm(form); err != nil {
 			return err
 		}
 	} else {
@@ -119,18 +359,18 @@ func runNew(cmd *cobra.Command, args []string) error {
 		form := huh.NewForm(
 			huh.NewGroup(
 				huh.NewInput().
-					Title("Project Name").
-					Description("Name of your project directory").
+					Title(i18n.T("prompt.project_name.title")).
+					Description(i18n.T("prompt.project_name.description")).
 					Value(&projectName).
 					Validate(validateProjectName),
 				huh.NewInput().
-					Title("Module Path").
-					Description("Go module path (e.g., github.com/username/project)").
+					Title(i18n.T("prompt.module_path.title")).
+					Description(i18n.T("prompt.module_path.description")).
 					Value(&modulePath).
 					Validate(validateModulePath),
 			),
 		)
-		if err := form.Run(); err != nil {
+		if err := runForm(form); err != nil {
 			return err
 		}
 	}
@@ -143,18 +383,18 @@ func runNew(cmd *cobra.Command, args []string) error {
 		form := huh.NewForm(
 			huh.NewGroup(
 				huh.NewSelect[string]().
-					Title("Frontend Stack").
-					Description("Choose your frontend enhancement library").
+					Title(i18n.T("prompt.frontend.title")).
+					Description(i18n.T("prompt.frontend.description")).
 					Options(
-						huh.NewOption("HTMX only", FrontendHTMX),
-						huh.NewOption("HTMX + Hyperscript (_hyperscript)", FrontendHTMXHyperscript),
-						huh.NewOption("HTMX + Alpine.js", FrontendHTMXAlpine),
-						huh.NewOption("HTMX + Surreal", FrontendHTMXSurreal),
+						huh.NewOption(i18n.T("prompt.frontend.htmx"), FrontendHTMX),
+						huh.NewOption(i18n.T("prompt.frontend.htmx_hyperscript"), FrontendHTMXHyperscript),
+						huh.NewOption(i18n.T("prompt.frontend.htmx_alpine"), FrontendHTMXAlpine),
+						huh.NewOption(i18n.T("prompt.frontend.htmx_surreal"), FrontendHTMXSurreal),
 					).
 					Value(&frontend),
 			),
 		)
-		if err := form.Run(); err != nil {
+		if err := runForm(form); err != nil {
 			return err
 		}
 	}
@@ -172,17 +412,17 @@ func runNew(cmd *cobra.Command, args []string) error {
 		form := huh.NewForm(
 			huh.NewGroup(
 				huh.NewSelect[string]().
-					Title("CSS Framework").
-					Description("Choose your CSS component framework").
+					Title(i18n.T("prompt.css.title")).
+					Description(i18n.T("prompt.css.description")).
 					Options(
-						huh.NewOption("DaisyUI - Component library with themes", CSSFrameworkDaisyUI),
-						huh.NewOption("TemplUI - Go/Templ component library", CSSFrameworkTemplUI),
-						huh.NewOption("Basecoat - shadcn/ui-style components", CSSFrameworkBasecoat),
+						huh.NewOption(i18n.T("prompt.css.daisyui"), CSSFrameworkDaisyUI),
+						huh.NewOption(i18n.T("prompt.css.templui"), CSSFrameworkTemplUI),
+						huh.NewOption(i18n.T("prompt.css.basecoat"), CSSFrameworkBasecoat),
 					).
 					Value(&cssFramework),
 			),
 		)
-		if err := form.Run(); err != nil {
+		if err := runForm(form); err != nil {
 			return err
 		}
 	}
@@ -201,16 +441,16 @@ func runNew(cmd *cobra.Command, args []string) error {
 			form := huh.NewForm(
 				huh.NewGroup(
 					huh.NewSelect[string]().
-						Title("Theme").
-						Description("Choose a color theme for your project").
+						Title(i18n.T("prompt.theme.title")).
+						Description(i18n.T("prompt.theme.description")).
 						Options(
-							huh.NewOption("None - Default Basecoat colors", ThemeNone),
-							huh.NewOption("Caffeine - Premium amber/orange theme", ThemeCaffeine),
+							huh.NewOption(i18n.T("prompt.theme.none"), ThemeNone),
+							huh.NewOption(i18n.T("prompt.theme.caffeine"), ThemeCaffeine),
 						).
 						Value(&theme),
 				),
 			)
-			if err := form.Run(); err != nil {
+			if err := runForm(form); err != nil {
 				return err
 			}
 		}
@@ -222,21 +462,27 @@ func runNew(cmd *cobra.Command, args []string) error {
 		theme = ThemeNone
 	}
 
-	// Handle DB selection if flag not set
-	if !cmd.Flags().Changed("no-db") {
-		// Ask user if they want DB
+	// Handle DB selection if no flag picked one (--single-binary brings SQLite)
+	if database == "" {
 		form := huh.NewForm(
 			huh.NewGroup(
-				huh.NewConfirm().
-					Title("Include Database?").
-					Description("PostgreSQL setup with pgx and goose").
-					Value(&includeDB),
+				huh.NewSelect[string]().
+					Title(i18n.T("prompt.db.title")).
+					Description(i18n.T("prompt.db.description")).
+					Options(
+						huh.NewOption(i18n.T("prompt.db.postgres"), DatabasePostgres),
+						huh.NewOption(i18n.T("prompt.db.mysql"), DatabaseMySQL),
+						huh.NewOption(i18n.T("prompt.db.sqlite"), DatabaseSQLite),
+						huh.NewOption(i18n.T("prompt.db.none"), DatabaseNone),
+					).
+					Value(&database),
 			),
 		)
-		if err := form.Run(); err != nil {
+		if err := runForm(form); err != nil {
 			return err
 		}
 	}
+	includeDB := database != DatabaseNone
 
 	// Handle deployment provider selection
 	if deployProviderFlag != "" {
@@ -246,22 +492,23 @@ func runNew(cmd *cobra.Command, args []string) error {
 		form := huh.NewForm(
 			huh.NewGroup(
 				huh.NewSelect[string]().
-					Title("Deployment Provider").
-					Description("Include deployment configuration (optional)").
+					Title(i18n.T("prompt.deploy.title")).
+					Description(i18n.T("prompt.deploy.description")).
 					Options(
-						huh.NewOption("None - Skip deployment files", DeployNone),
-						huh.NewOption("Hetzner + Caddy - VPS with reverse proxy", DeployHetznerCaddy),
+						huh.NewOption(i18n.T("prompt.deploy.none"), DeployNone),
+						huh.NewOption(i18n.T("prompt.deploy.hetzner_caddy"), DeployHetznerCaddy),
+						huh.NewOption(i18n.T("prompt.deploy.systemd"), DeploySystemd),
 					).
 					Value(&deployProvider),
 			),
 		)
-		if err := form.Run(); err != nil {
+		if err := runForm(form); err != nil {
 			return err
 		}
 	}
 
 	// Validate deployment provider choice
-	if deployProvider != DeployNone && deployProvider != DeployHetznerCaddy {
+	if deployProvider != DeployNone && deployProvider != DeployHetznerCaddy && deployProvider != DeploySystemd {
 		deployProvider = DeployNone // Default to no deployment
 	}
 
@@ -270,25 +517,181 @@ func runNew(cmd *cobra.Command, args []string) error {
 		form := huh.NewForm(
 			huh.NewGroup(
 				huh.NewConfirm().
-					Title("Include Git Hooks?").
-					Description("Pre-commit: templ fmt, gofumpt, golangci-lint").
+					Title(i18n.T("prompt.hooks.title")).
+					Description(i18n.T("prompt.hooks.description")).
 					Value(&includeHooks),
 			),
 		)
-		if err := form.Run(); err != nil {
+		if err := runForm(form); err != nil {
+			return err
+		}
+	}
+
+	// Validate search backend choice
+	search := searchFlag
+	if search != SearchPgTrgm && search != SearchMeilisearch && search != SearchBleve {
+		search = SearchNone // Default to no search
+	}
+	if litestreamFlag && database != DatabaseSQLite {
+		return errors.New(i18n.T("error.litestream_requires_sqlite"))
+	}
+	sqliteReplication := sqliteReplFlag
+	if sqliteReplication != SQLiteReplicationLiteFS && sqliteReplication != SQLiteReplicationTurso {
+		sqliteReplication = SQLiteReplicationNone // Default to one database file
+	}
+	if sqliteReplication != SQLiteReplicationNone && database != DatabaseSQLite {
+		return errors.New(i18n.T("error.sqlite_replication_requires_sqlite", sqliteReplication))
+	}
+	if sqliteReplication != SQLiteReplicationNone && litestreamFlag {
+		return errors.New(i18n.T("error.sqlite_replication_litestream", sqliteReplication))
+	}
+	if search == SearchPgTrgm && !includeDB {
+		return errors.New(i18n.T("error.search_requires_db", SearchPgTrgm))
+	}
+	if dbReplicasFlag && !includeDB {
+		return errors.New(i18n.T("error.replicas_requires_db"))
+	}
+	if postgisFlag && !includeDB {
+		return errors.New(i18n.T("error.postgis_requires_db"))
+	}
+	devDB := devDBFlag
+	if devDB != DevDBTestcontainers {
+		devDB = DevDBCompose // Default to the docker-compose database
+	}
+	if devDB == DevDBTestcontainers && database != DatabasePostgres {
+		return errors.New(i18n.T("error.dev_db_requires_postgres"))
+	}
+	if factoriesFlag && database != DatabasePostgres {
+		return errors.New(i18n.T("error.factories_requires_postgres"))
+	}
+	mocks := mocksFlag
+	if mocks != MocksMockery && mocks != MocksGomock {
+		mocks = MocksNone // Default to no mocks
+	}
+	if mocks != MocksNone && !includeDB {
+		return errors.New(i18n.T("error.mocks_requires_db"))
+	}
+	if autoMigrateFlag && !includeDB {
+		return errors.New(i18n.T("error.auto_migrate_requires_db"))
+	}
+	if autoMigrateFlag && database == DatabaseSQLite {
+		return errors.New(i18n.T("error.auto_migrate_sqlite"))
+	}
+	if autoMigrateFlag && database == DatabaseMySQL {
+		return errors.New(i18n.T("error.auto_migrate_mysql"))
+	}
+	if notificationsFlag && !includeDB {
+		return errors.New(i18n.T("error.notifications_requires_db"))
+	}
+	if activityFlag && !includeDB {
+		return errors.New(i18n.T("error.activity_requires_db"))
+	}
+	if onboardingFlag && !includeDB {
+		return errors.New(i18n.T("error.onboarding_requires_db"))
+	}
+
+	// Validate example resource choice
+	example := exampleFlag
+	if example != ExampleNote && example != ExampleTodo && example != ExampleNone {
+		example = ExampleUsers // Default to the users table migration
+		if leanFlag {
+			example = ExampleNone
+		}
+	}
+	if (example == ExampleNote || example == ExampleTodo) && !includeDB {
+		return errors.New(i18n.T("error.example_requires_db", example))
+	}
+	if datatableFlag && example != ExampleNote && example != ExampleTodo {
+		return errors.New(i18n.T("error.datatable_requires_example"))
+	}
+	if importExportFlag && example != ExampleNote && example != ExampleTodo {
+		return errors.New(i18n.T("error.import_export_requires_example"))
+	}
+	if ftsFlag && example != ExampleNote && example != ExampleTodo {
+		return errors.New(i18n.T("error.fts_requires_example"))
+	}
+	if settingsFlag && (!includeDB || example != ExampleUsers) {
+		return errors.New(i18n.T("error.settings_requires_users"))
+	}
+	if teamsFlag && (!includeDB || example != ExampleUsers) {
+		return errors.New(i18n.T("error.teams_requires_users"))
+	}
+	if billingFlag && (!includeDB || example != ExampleUsers) {
+		return errors.New(i18n.T("error.billing_requires_users"))
+	}
+
+	// Validate analytics provider choice
+	analyticsProvider := analyticsFlag
+	if analyticsProvider != AnalyticsPlausible && analyticsProvider != AnalyticsUmami {
+		analyticsProvider = AnalyticsNone // Default to no analytics
+	}
+
+	// Validate error reporting choice
+	errorReporting := errorsFlag
+	if errorReporting != ErrorsSentry && errorReporting != ErrorsGlitchTip {
+		errorReporting = ErrorsNone // Default to no error reporting
+	}
+
+	// Validate load testing tool choice
+	loadTest := loadTestFlag
+	if loadTest != LoadTestK6 && loadTest != LoadTestVegeta {
+		loadTest = LoadTestNone // Default to no load testing
+	}
+
+	// Validate reverse proxy choice
+	proxy := proxyFlag
+	if proxy != ProxyCaddy && proxy != ProxyNginx && proxy != ProxyTraefik {
+		proxy = ProxyNone // Default to no reverse proxy
+	}
+
+	// Validate lint preset choice
+	lintPreset := lintFlag
+	if lintPreset != LintStandard && lintPreset != LintMinimal {
+		lintPreset = LintStrict // Default to the strict preset
+	}
+
+	// Validate template channel choice
+	bundleVersion, err := generator.BundleVersion(channelFlag)
+	if err != nil {
+		return err
+	}
+
+	// Validate Go version choice; default to the local toolchain, then to
+	// the channel default
+	goVersion := generator.DetectGoVersion()
+	if goVersionFlag != "" {
+		if goVersion, err = generator.ParseGoVersion(goVersionFlag); err != nil {
 			return err
 		}
 	}
 
+	// Validate base path choice
+	basePath, err := generator.ParseBasePath(basePathFlag)
+	if err != nil {
+		return err
+	}
+
+	// Validate asset mirror choice
+	assetMirror, err := generator.ParseAssetMirror(assetMirrorFlag)
+	if err != nil {
+		return err
+	}
+
+	// Validate binaries choice
+	binaries, err := generator.ParseBinaries(binariesFlag)
+	if err != nil {
+		return err
+	}
+
 	// Get absolute path
 	absPath, err := filepath.Abs(projectName)
 	if err != nil {
-		return fmt.Errorf("failed to resolve path: %w", err)
+		return fmt.Errorf("%s: %w", i18n.T("error.resolve_path"), err)
 	}
 
 	// Check if directory exists
 	if _, err := os.Stat(absPath); !os.IsNotExist(err) {
-		return fmt.Errorf("directory '%s' already exists", projectName)
+		return errors.New(i18n.T("error.directory_exists", projectName))
 	}
 
 	frontendLabel := map[string]string{
@@ -304,80 +707,450 @@ func runNew(cmd *cobra.Command, args []string) error {
 		CSSFrameworkBasecoat: "Basecoat",
 	}[cssFramework]
 
-	dbLabel := "Yes (PostgreSQL)"
-	if !includeDB {
-		dbLabel = "No"
-	}
+	dbLabel := map[string]string{
+		DatabasePostgres: i18n.T("new.database_yes"),
+		DatabaseMySQL:    i18n.T("new.database_mysql"),
+		DatabaseSQLite:   i18n.T("new.database_sqlite"),
+		DatabaseNone:     i18n.T("label.no"),
+	}[database]
 
 	deployLabel := map[string]string{
-		DeployNone:         "None",
+		DeployNone:         i18n.T("label.none"),
 		DeployHetznerCaddy: "Hetzner + Caddy",
+		DeploySystemd:      "systemd (make deploy-ssh)",
 	}[deployProvider]
 
-	themeLabel := "None"
+	themeLabel := i18n.T("label.none")
 	if theme == ThemeCaffeine {
 		themeLabel = "Caffeine"
 	}
 
-	fmt.Printf("\n🚀 Creating project '%s' with module '%s'...\n", projectName, modulePath)
-	fmt.Printf("   Frontend: %s\n", frontendLabel)
-	fmt.Printf("   CSS Framework: %s\n", cssLabel)
+	fmt.Printf("\n%s\n", i18n.T("new.creating", projectName, modulePath))
+	printSummary("new.frontend", frontendLabel)
+	printSummary("new.css", cssLabel)
 	if cssFramework == CSSFrameworkBasecoat {
-		fmt.Printf("   Theme: %s\n", themeLabel)
+		printSummary("new.theme", themeLabel)
+	}
+	printSummary("new.database", dbLabel)
+	if dbReplicasFlag {
+		printSummary("new.pooling")
+	}
+	if postgisFlag {
+		printSummary("new.postgis")
+	}
+	if autoMigrateFlag {
+		printSummary("new.auto_migrate")
+	}
+	if devDB == DevDBTestcontainers {
+		printSummary("new.dev_db")
+	}
+	if factoriesFlag {
+		printSummary("new.factories")
 	}
-	fmt.Printf("   Database: %s\n", dbLabel)
-	fmt.Printf("   Deployment: %s\n", deployLabel)
+	if mocks != MocksNone {
+		printSummary("new.mocks", mocks)
+	}
+	if example == ExampleNote || example == ExampleTodo {
+		printSummary("new.example", example)
+	}
+	if datatableFlag {
+		printSummary("new.datatable", example)
+	}
+	if importExportFlag {
+		printSummary("new.import_export", example)
+	}
+	if ftsFlag {
+		printSummary("new.fts", example)
+	}
+	printSummary("new.deploy", deployLabel)
+	hooksLabel := i18n.T("label.no")
 	if includeHooks {
-		fmt.Printf("   Git Hooks: Yes (pre-commit)\n")
-	} else {
-		fmt.Printf("   Git Hooks: No\n")
+		hooksLabel = i18n.T("new.hooks_yes")
+	}
+	printSummary("new.hooks", hooksLabel)
+	if viteFlag {
+		printSummary("new.vite")
+	}
+	if typescriptFlag {
+		printSummary("new.typescript")
+	}
+	if previewFlag {
+		printSummary("new.preview")
+	}
+	if seoFlag {
+		printSummary("new.seo")
+	}
+	if staticExportFlag {
+		printSummary("new.static_export")
+	}
+	if contentFlag {
+		printSummary("new.content")
+	}
+	if search != SearchNone {
+		searchLabel := map[string]string{
+			SearchPgTrgm:      "PostgreSQL pg_trgm",
+			SearchMeilisearch: "Meilisearch",
+			SearchBleve:       i18n.T("new.search_bleve"),
+		}[search]
+		printSummary("new.search", searchLabel)
+	}
+	if geoipFlag {
+		printSummary("new.geoip")
+	}
+	if pdfFlag {
+		printSummary("new.pdf")
+	}
+	if imagesFlag {
+		printSummary("new.images")
+	}
+	if notificationsFlag {
+		printSummary("new.notifications")
+	}
+	if activityFlag {
+		printSummary("new.activity")
+	}
+	if settingsFlag {
+		printSummary("new.settings")
+	}
+	if onboardingFlag {
+		printSummary("new.onboarding")
+	}
+	if teamsFlag {
+		printSummary("new.teams")
+	}
+	if billingFlag {
+		printSummary("new.billing")
+	}
+	if demoFlag {
+		printSummary("new.demo")
+	}
+	if singleBinaryFlag {
+		printSummary("new.single_binary")
+	}
+	if litestreamFlag {
+		printSummary("new.litestream")
+	}
+	if sqliteReplication != SQLiteReplicationNone {
+		printSummary("new.sqlite_replication", sqliteReplication)
+	}
+	if analyticsProvider != AnalyticsNone {
+		analyticsLabel := map[string]string{
+			AnalyticsPlausible: "Plausible",
+			AnalyticsUmami:     "Umami",
+		}[analyticsProvider]
+		printSummary("new.analytics", analyticsLabel)
+	}
+	if gdprFlag {
+		printSummary("new.gdpr")
+	}
+	if errorReporting != ErrorsNone {
+		errorsLabel := map[string]string{
+			ErrorsSentry:    "Sentry",
+			ErrorsGlitchTip: "GlitchTip",
+		}[errorReporting]
+		printSummary("new.errors", errorsLabel)
+	}
+	if pprofFlag {
+		printSummary("new.pprof")
+	}
+	if statusPageFlag {
+		printSummary("new.status_page")
+	}
+	if loadTest != LoadTestNone {
+		printSummary("new.loadtest", loadTest)
+	}
+	if lintPreset != LintStrict {
+		printSummary("new.lint", lintPreset)
+	}
+	if e2eFlag {
+		printSummary("new.e2e")
+	}
+	if fuzzFlag {
+		printSummary("new.fuzz")
+	}
+	if snapshotsFlag {
+		printSummary("new.snapshots")
+	}
+	if openAPIFlag {
+		printSummary("new.openapi")
+	}
+	if jobsFlag {
+		printSummary("new.jobs")
+	}
+	if sbomFlag {
+		printSummary("new.sbom")
+	}
+	if signFlag {
+		printSummary("new.sign")
+	}
+	if httpsDevFlag {
+		printSummary("new.https_dev")
+	}
+	if embedAssetsFlag {
+		printSummary("new.embed_assets")
+	}
+	if proxy != ProxyNone {
+		printSummary("new.proxy", proxy)
+	}
+	if basePath != "" {
+		printSummary("new.base_path", basePath)
+	}
+	if assetMirror != "" {
+		printSummary("new.asset_mirror", assetMirror)
+	}
+	if binaries != generator.BinaryServer {
+		printSummary("new.binaries", binaries)
+	}
+	if channelFlag != generator.ChannelStable {
+		printSummary("new.channel", channelFlag, bundleVersion)
+	}
+	if atFlag != "" && atFlag != bundleVersion {
+		printSummary("new.at", atFlag, bundleVersion)
+	}
+	if goVersion != "" {
+		printSummary("new.go", goVersion)
+	}
+	if leanFlag {
+		printSummary("new.lean")
+	}
+	if len(vars) > 0 {
+		printSummary("new.vars", len(vars), varsFlag)
+	}
+	if langFlag != i18n.English {
+		printSummary("new.language", langFlag)
 	}
 	fmt.Println("")
 
 	// Generate the project with options
 	opts := generator.Options{
-		ProjectName:    projectName,
-		ModulePath:     modulePath,
-		Frontend:       frontend,
-		CSSFramework:   cssFramework,
-		Theme:          theme,
-		IncludeDB:      includeDB,
-		IncludeHooks:   includeHooks,
-		DeployProvider: deployProvider,
+		ProjectName:       projectName,
+		ModulePath:        modulePath,
+		Frontend:          frontend,
+		CSSFramework:      cssFramework,
+		Theme:             theme,
+		Database:          database,
+		IncludeDB:         includeDB,
+		DBReplicas:        dbReplicasFlag,
+		PostGIS:           postgisFlag,
+		AutoMigrate:       autoMigrateFlag,
+		DevDB:             devDB,
+		Factories:         factoriesFlag,
+		Mocks:             mocks,
+		Fuzz:              fuzzFlag,
+		Snapshots:         snapshotsFlag,
+		IncludeHooks:      includeHooks,
+		DeployProvider:    deployProvider,
+		Vite:              viteFlag,
+		TypeScript:        typescriptFlag,
+		Preview:           previewFlag,
+		SEO:               seoFlag,
+		StaticExport:      staticExportFlag,
+		Content:           contentFlag,
+		Search:            search,
+		GeoIP:             geoipFlag,
+		PDF:               pdfFlag,
+		Images:            imagesFlag,
+		Notifications:     notificationsFlag,
+		Activity:          activityFlag,
+		Settings:          settingsFlag,
+		Onboarding:        onboardingFlag,
+		Teams:             teamsFlag,
+		Billing:           billingFlag,
+		Demo:              demoFlag,
+		SingleBinary:      singleBinaryFlag,
+		Litestream:        litestreamFlag,
+		SQLiteReplication: sqliteReplication,
+		Analytics:         analyticsProvider,
+		GDPR:              gdprFlag,
+		Errors:            errorReporting,
+		Pprof:             pprofFlag,
+		StatusPage:        statusPageFlag,
+		LoadTest:          loadTest,
+		E2E:               e2eFlag,
+		OpenAPI:           openAPIFlag,
+		Jobs:              jobsFlag,
+		SBOM:              sbomFlag,
+		Sign:              signFlag,
+		HTTPSDev:          httpsDevFlag,
+		EmbedAssets:       embedAssetsFlag,
+		Proxy:             proxy,
+		ExampleResource:   example,
+		DataTable:         datatableFlag,
+		ImportExport:      importExportFlag,
+		FTS:               ftsFlag,
+		BasePath:          basePath,
+		AssetMirror:       assetMirror,
+		Binaries:          binaries,
+		Lint:              lintPreset,
+		GoVersion:         goVersion,
+		Channel:           channelFlag,
+		At:                atFlag,
+		Lean:              leanFlag,
+		Lang:              langFlag,
+		Vars:              vars,
+		Out:               io.MultiWriter(os.Stdout, runLog),
 	}
+	runLog.Record(opts)
 	if err := generator.GenerateWithOptions(opts); err != nil {
-		return fmt.Errorf("generation failed: %w", err)
+		return fmt.Errorf("%s: %w", i18n.T("error.generation_failed"), err)
+	}
+	recordUsage(opts)
+
+	report, err := generator.BuildReport(projectName)
+	if err != nil {
+		return fmt.Errorf("%s: %w", i18n.T("error.reading_project"), err)
 	}
 
 	// Success message
-	fmt.Println("\n✅ Project created successfully!")
+	fmt.Printf("\n%s\n", i18n.T("new.success"))
+	fmt.Println("─────────────────────────────────────────────────")
+	fmt.Print(report.Summary())
+	printSummary("new.full_report", filepath.Join(projectName, generator.ReportPath))
 	fmt.Println("─────────────────────────────────────────────────")
 	fmt.Printf("  cd %s\n", projectName)
-	fmt.Println("  make setup    # Install tools (Air, Templ, Goose, Tailwind)")
-	fmt.Println("  make dev      # Start development server with live reload")
-	fmt.Println("  make dev-templ # Start with Templ proxy (auto browser refresh)")
+	for _, step := range report.NextSteps {
+		fmt.Println("  " + step)
+	}
+	fmt.Println("─────────────────────────────────────────────────")
+	fmt.Printf("\n%s\n", i18n.T("new.see_readme"))
+
+	return nil
+}
+
+// runNewStack generates the services of a stack config; the optional
+// argument overrides the config's name (the root directory)
+func runNewStack(cmd *cobra.Command, args []string) error {
+	stack, err := generator.LoadStack(configFlag)
+	if err != nil {
+		return err
+	}
+	if len(args) > 0 {
+		stack.Name = args[0]
+	}
+	if err := validateProjectName(stack.Name); err != nil {
+		return fmt.Errorf("%s: %w (%s)", i18n.T("error.stack_name"), err, i18n.T("error.stack_name_hint", configFlag))
+	}
+	var ignored []string
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if f.Name != "config" {
+			ignored = append(ignored, "--"+f.Name)
+		}
+	})
+	if len(ignored) > 0 {
+		return errors.New(i18n.T("error.config_flags", strings.Join(ignored, ", "), configFlag))
+	}
+
+	var services []generator.Options
+	for i := range stack.Services {
+		stack.Services[i].Options.Out = io.MultiWriter(os.Stdout, runLog)
+		services = append(services, stack.Services[i].Options)
+	}
+	runLog.Record(services...)
+
+	fmt.Printf("\n%s\n", i18n.T("stack.creating", stack.Name, len(stack.Services)))
+	if err := generator.GenerateStack(stack); err != nil {
+		return fmt.Errorf("%s: %w", i18n.T("error.generation_failed"), err)
+	}
+	recordUsage(services...)
+
+	fmt.Printf("\n%s\n", i18n.T("stack.success"))
+	fmt.Println("─────────────────────────────────────────────────")
+	for i, svc := range stack.Services {
+		fmt.Printf("   %-16s %s/%s  http://localhost:%d\n", svc.Name, stack.Module, svc.Name, generator.StackPortBase+i)
+	}
 	fmt.Println("─────────────────────────────────────────────────")
-	fmt.Println("\n📚 See README.md for more commands and documentation.")
+	fmt.Printf("  cd %s\n", stack.Name)
+	fmt.Println("  docker compose up --build   # " + i18n.T("stack.run_all"))
+	fmt.Println("─────────────────────────────────────────────────")
+	fmt.Printf("\n%s\n", i18n.T("stack.see_readme"))
+	return nil
+}
+
+// runNewBatch generates the projects of a --batch file, several at a time,
+// and prints a table of the results in file order
+func runNewBatch(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		return errors.New(i18n.T("error.batch_args", batchFlag))
+	}
+	var ignored []string
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if f.Name != "batch" && f.Name != "parallel" && f.Name != "lang" {
+			ignored = append(ignored, "--"+f.Name)
+		}
+	})
+	if len(ignored) > 0 {
+		return errors.New(i18n.T("error.batch_flags", strings.Join(ignored, ", "), batchFlag))
+	}
+	projects, err := generator.LoadBatch(batchFlag)
+	if err != nil {
+		return err
+	}
+	for _, p := range projects {
+		if err := validateProjectName(filepath.Base(p.ProjectName)); err != nil {
+			return fmt.Errorf("%s: %w", p.ProjectName, err)
+		}
+	}
+
+	workers := parallelFlag
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+	fmt.Printf("\n%s\n\n", i18n.T("batch.creating", len(projects), min(workers, len(projects))))
+	runLog.Record(projects...)
+	results := generator.GenerateBatch(projects, workers)
+	var generated []generator.Options
+	for i, r := range results {
+		if r.Err == nil {
+			generated = append(generated, projects[i])
+		} else {
+			runLog.Fail(fmt.Errorf("%s: %w", r.Name, r.Err))
+		}
+	}
+	recordUsage(generated...)
 
+	failed := 0
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintf(w, "   %s\t%s\t%s\t%s\t%s\n", i18n.T("batch.name"), i18n.T("batch.module"), i18n.T("batch.files"), i18n.T("batch.time"), i18n.T("batch.status"))
+	for _, r := range results {
+		status, files := "✅", strconv.Itoa(r.Files)
+		if r.Err != nil {
+			status, files = "❌ "+r.Err.Error(), "-"
+			failed++
+		}
+		fmt.Fprintf(w, "   %s\t%s\t%s\t%s\t%s\n", r.Name, r.Module, files, r.Duration.Round(10*time.Millisecond), status)
+	}
+	w.Flush()
+
+	fmt.Printf("\n%s\n", i18n.T("batch.summary", len(results)-failed, failed))
+	if failed > 0 {
+		cmd.SilenceUsage = true // the table already says what failed
+		return errors.New(i18n.T("error.batch_failed", failed, len(results)))
+	}
 	return nil
 }
 
+// printSummary prints an indented line of the new command's summary, the
+// message id translated and formatted with args
+func printSummary(id string, args ...any) {
+	fmt.Println("   " + i18n.T(id, args...))
+}
+
 func validateProjectName(s string) error {
 	if s == "" {
-		return fmt.Errorf("project name is required")
+		return errors.New(i18n.T("validate.project_name_required"))
 	}
 	if strings.ContainsAny(s, " /\\") {
-		return fmt.Errorf("project name cannot contain spaces or slashes")
+		return errors.New(i18n.T("validate.project_name_chars"))
 	}
 	return nil
 }
 
 func validateModulePath(s string) error {
 	if s == "" {
-		return fmt.Errorf("module path is required")
+		return errors.New(i18n.T("validate.module_path_required"))
 	}
 	if !strings.Contains(s, "/") {
-		return fmt.Errorf("module path should contain at least one '/'")
+		return errors.New(i18n.T("validate.module_path_slash"))
 	}
 	return nil
 }
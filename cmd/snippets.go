@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/FACorreiaa/goforge/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var snippetsCmd = &cobra.Command{
+	Use:   "snippets [name]",
+	Short: "Print ready-to-paste HTMX patterns for the project",
+	Long: `Print an HTMX pattern as Templ components: infinite scroll, active search,
+inline edit or optimistic UI. The markup uses the classes of the project's
+CSS framework (DaisyUI and TemplUI, or Basecoat), Alpine.js state in
+htmx-alpine projects, and the project's --base-path, all read from
+.goforge.yaml. Outside a project it uses DaisyUI and plain htmx.
+
+Each pattern opens with a comment describing the routes it expects.
+Without a name, list the patterns.
+
+Example:
+  goforge snippets
+  goforge snippets active-search >> views/pages/search.templ
+  goforge snippets inline-edit --css basecoat`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSnippets,
+}
+
+var snippetsCSSFlag string
+
+func init() {
+	snippetsCmd.Flags().StringVar(&projectDirFlag, "dir", ".", "Project directory")
+	snippetsCmd.Flags().StringVar(&snippetsCSSFlag, "css", "", "CSS framework to adapt to: daisyui, templui, basecoat (default: the project's)")
+	rootCmd.AddCommand(snippetsCmd)
+}
+
+func runSnippets(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		for _, s := range generator.HTMXSnippets() {
+			fmt.Printf("  %-18s %s\n", s.Name, s.Description)
+		}
+		fmt.Println("\nRun 'goforge snippets <name>' to print one.")
+		return nil
+	}
+	opts, err := generator.HTMXSnippetOptionsFor(projectDirFlag)
+	if err != nil {
+		return err
+	}
+	if snippetsCSSFlag != "" {
+		opts.CSSFramework = snippetsCSSFlag
+	}
+	out, err := generator.RenderHTMXSnippet(args[0], opts)
+	if err != nil {
+		return err
+	}
+	fmt.Print(out)
+	return nil
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/FACorreiaa/goforge/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var telemetryCmd = &cobra.Command{
+	Use:   "telemetry [on|off]",
+	Short: "Turn anonymous usage statistics on or off",
+	Long: `Telemetry is off until you turn it on. When on, goforge new queues an
+anonymous count of the stack and features each project was generated with,
+and sends the queue now and then; goforge works the same offline. Without
+an argument the command shows the setting.
+
+Example:
+  goforge telemetry on
+  goforge telemetry data     # what is collected, and the queued events
+  goforge telemetry off      # also deletes the queue`,
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: []string{"on", "off"},
+	RunE:      runTelemetry,
+}
+
+var telemetryDataCmd = &cobra.Command{
+	Use:   "data",
+	Short: "Show what telemetry collects and the events waiting to be sent",
+	Args:  cobra.NoArgs,
+	RunE:  runTelemetryData,
+}
+
+var telemetryFlushCmd = &cobra.Command{
+	Use:   "flush",
+	Short: "Send the queued telemetry events now",
+	Args:  cobra.NoArgs,
+	RunE:  runTelemetryFlush,
+}
+
+func init() {
+	telemetryCmd.AddCommand(telemetryDataCmd, telemetryFlushCmd)
+	rootCmd.AddCommand(telemetryCmd)
+}
+
+func runTelemetry(cmd *cobra.Command, args []string) error {
+	t, err := generator.NewTelemetry()
+	if err != nil {
+		return err
+	}
+	if len(args) == 1 {
+		switch args[0] {
+		case "on", "off":
+			if err := t.SetEnabled(args[0] == "on"); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("want on or off, got %q", args[0])
+		}
+	}
+
+	on, err := t.Enabled()
+	if err != nil {
+		return err
+	}
+	events, err := t.Pending()
+	if err != nil {
+		return err
+	}
+	switch {
+	case on:
+		fmt.Printf("Telemetry is on: %d event(s) queued in %s\n", len(events), filepath.Join(t.Dir, generator.TelemetryQueueFile))
+		fmt.Println("Run 'goforge telemetry data' for what is collected, 'goforge telemetry off' to stop.")
+	case generator.TelemetryBlocked():
+		fmt.Println("Telemetry is off: DO_NOT_TRACK or GOFORGE_TELEMETRY=off is set.")
+	default:
+		fmt.Println("Telemetry is off. Run 'goforge telemetry data' for what turning it on would collect.")
+	}
+	return nil
+}
+
+func runTelemetryData(cmd *cobra.Command, args []string) error {
+	t, err := generator.NewTelemetry()
+	if err != nil {
+		return err
+	}
+	fmt.Println(generator.TelemetryStatement)
+	events, err := t.Pending()
+	if err != nil {
+		return err
+	}
+	if len(events) == 0 {
+		fmt.Println("\nNo events are queued.")
+		return nil
+	}
+	fmt.Printf("\nQueued events (%d), as they would be sent:\n", len(events))
+	for _, e := range events {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(line))
+	}
+	return nil
+}
+
+func runTelemetryFlush(cmd *cobra.Command, args []string) error {
+	t, err := generator.NewTelemetry()
+	if err != nil {
+		return err
+	}
+	if on, err := t.Enabled(); err != nil || !on {
+		if err == nil {
+			fmt.Println("Telemetry is off: nothing to send.")
+		}
+		return err
+	}
+	sent, err := t.Flush()
+	if err != nil {
+		cmd.SilenceUsage = true // a network failure, not a usage mistake
+		return fmt.Errorf("telemetry not sent, the events stay queued: %w", err)
+	}
+	fmt.Printf("✅ Sent %d event(s)\n", sent)
+	return nil
+}
+
+// recordUsage queues the telemetry events of generated projects and sends
+// the queue when due. Telemetry never fails or slows a generation beyond
+// the send timeout, so errors are dropped.
+func recordUsage(projects ...generator.Options) {
+	t, err := generator.NewTelemetry()
+	if err != nil || t.Record(projects...) != nil {
+		return
+	}
+	if t.Due() {
+		t.Flush()
+	}
+}
@@ -0,0 +1,291 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/FACorreiaa/goforge/internal/generator"
+	"github.com/FACorreiaa/goforge/internal/minisign"
+	"github.com/charmbracelet/huh"
+	"github.com/spf13/cobra"
+)
+
+var templatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "Author template bundles",
+	Long: `A template bundle is a directory of templates in goforge's syntax
+(conditional blocks, placeholders, fragments and variables) with a
+manifest.yaml naming the option sets it must render with.`,
+}
+
+var templatesNewCmd = &cobra.Command{
+	Use:   "new [dir]",
+	Short: "Scaffold a template bundle",
+	Long: `Scaffold a template bundle: manifest.yaml with example variables and a
+test matrix, example templates using each directive, and a README listing
+the conditions, placeholders and fragments this goforge provides. Prompts
+for the name and description unless given as flags.
+
+Example:
+  goforge templates new my-stack
+  goforge templates test my-stack`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTemplatesNew,
+}
+
+var templatesTestCmd = &cobra.Command{
+	Use:   "test [dir]",
+	Short: "Render a template bundle across its option matrix",
+	Long: `Render every template of a bundle once per matrix entry in its
+manifest.yaml, as goforge new would, without writing anything. Tags and
+placeholders left in the output, Go files that do not parse and templates
+that fail to render are reported; the command fails if any entry does.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTemplatesTest,
+}
+
+var templatesSearchCmd = &cobra.Command{
+	Use:   "search [query]",
+	Short: "Search the registry of community template bundles",
+	Long: `List the bundles in the template registry whose name or description
+contains the query, with their latest version. Without a query every bundle
+is listed.
+
+Example:
+  goforge templates search api`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTemplatesSearch,
+}
+
+var templatesAddCmd = &cobra.Command{
+	Use:   "add <name>[@version]",
+	Short: "Install a template bundle from the registry",
+	Long: `Download a bundle listed in the template registry, verify it against the
+SHA-256 checksum in the index and its signature against the trust policy,
+and install it as <name>@<version>. Without a version the latest is
+installed; give one to pin it. A bundle whose checksum or signature does not
+match, or that is not a valid bundle, is not installed; unsigned bundles are
+refused in CI unless the trust policy allows them.
+
+Example:
+  goforge templates add api-stack
+  goforge templates add api-stack@1.2.0`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTemplatesAdd,
+}
+
+var templatesVerifyCmd = &cobra.Command{
+	Use:   "verify <archive.tar.gz>",
+	Short: "Verify a template bundle's signature against the trust policy",
+	Long: `Check a bundle archive's minisign signature (<archive>.minisig unless
+--signature is given) against the keys in the trust policy, goforge/trust.yaml
+in the user config directory, or against --key alone. Fails unless the
+archive is signed by a trusted key.
+
+The trust policy:
+  keys:
+    alice: <public key line from the signer's minisign.pub>
+  unsigned: refuse   # or allow; default: refuse in CI, warn elsewhere
+
+Example:
+  minisign -Sm my-stack-1.0.0.tar.gz
+  goforge templates verify my-stack-1.0.0.tar.gz`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTemplatesVerify,
+}
+
+var (
+	signatureFlag string
+	keyFlag       string
+)
+
+var (
+	bundleNameFlag        string
+	bundleDescriptionFlag string
+	registryFlag          string
+	templatesDirFlag      string
+)
+
+func init() {
+	templatesNewCmd.Flags().StringVar(&bundleNameFlag, "name", "", "Bundle name (default: the directory name)")
+	templatesNewCmd.Flags().StringVar(&bundleDescriptionFlag, "description", "", "One-line description of the bundle")
+	templatesCmd.AddCommand(templatesNewCmd)
+	for _, c := range []*cobra.Command{templatesSearchCmd, templatesAddCmd} {
+		c.Flags().StringVar(&registryFlag, "registry", generator.DefaultRegistryURL, "URL of the registry index (https)")
+	}
+	templatesAddCmd.Flags().StringVar(&templatesDirFlag, "dir", "", "Install directory (default: goforge/templates in the user config directory)")
+	templatesCmd.AddCommand(templatesTestCmd)
+	templatesCmd.AddCommand(templatesSearchCmd)
+	templatesCmd.AddCommand(templatesAddCmd)
+	templatesVerifyCmd.Flags().StringVar(&signatureFlag, "signature", "", "Signature file (default: <archive>.minisig)")
+	templatesVerifyCmd.Flags().StringVar(&keyFlag, "key", "", "Trust only this minisign public key instead of the trust policy")
+	templatesCmd.AddCommand(templatesVerifyCmd)
+	rootCmd.AddCommand(templatesCmd)
+}
+
+func runTemplatesNew(cmd *cobra.Command, args []string) error {
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	name, description := bundleNameFlag, bundleDescriptionFlag
+	if name == "" || description == "" {
+		if name == "" {
+			if abs, err := filepath.Abs(dir); err == nil {
+				name = filepath.Base(abs)
+			}
+		}
+		form := huh.NewForm(
+			huh.NewGroup(
+				huh.NewInput().
+					Title("Bundle Name").
+					Description("Shown to people choosing a template bundle").
+					Value(&name).
+					Validate(validateProjectName),
+				huh.NewInput().
+					Title("Description").
+					Description("One line on the stack the bundle generates").
+					Value(&description),
+			),
+		)
+		if err := runForm(form); err != nil {
+			return err
+		}
+	}
+
+	files, err := generator.NewBundle(dir, name, description)
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		fmt.Printf("  ✓ %s\n", filepath.Join(dir, f))
+	}
+	fmt.Printf("\n✅ Template bundle %s created. Edit the templates, then run:\n", name)
+	fmt.Printf("  goforge templates test %s\n", dir)
+	return nil
+}
+
+func runTemplatesTest(cmd *cobra.Command, args []string) error {
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	results, err := generator.TestBundle(dir)
+	if err != nil {
+		return err
+	}
+	failed := 0
+	for _, r := range results {
+		if len(r.Problems) == 0 {
+			fmt.Printf("  ✓ %s (%d files)\n", r.Case, r.Files)
+			continue
+		}
+		failed++
+		fmt.Printf("  ✗ %s\n", r.Case)
+		for _, p := range r.Problems {
+			fmt.Printf("      %s\n", p)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d matrix entries failed", failed, len(results))
+	}
+	fmt.Printf("\n✅ All %d matrix entries render cleanly\n", len(results))
+	return nil
+}
+
+func runTemplatesSearch(cmd *cobra.Command, args []string) error {
+	query := ""
+	if len(args) > 0 {
+		query = args[0]
+	}
+	bundles, err := generator.NewRegistry(registryFlag).Search(query)
+	if err != nil {
+		return err
+	}
+	if len(bundles) == 0 {
+		fmt.Println("No template bundles found.")
+		return nil
+	}
+	for _, b := range bundles {
+		latest := "no releases"
+		if release, ok := b.Latest(); ok {
+			latest = release.Version
+		}
+		fmt.Printf("  %-24s %-10s %s\n", b.Name, latest, b.Description)
+	}
+	fmt.Println("\nInstall one with: goforge templates add <name>[@version]")
+	return nil
+}
+
+func runTemplatesAdd(cmd *cobra.Command, args []string) error {
+	root := templatesDirFlag
+	if root == "" {
+		var err error
+		if root, err = generator.TemplatesDir(); err != nil {
+			return err
+		}
+	}
+	policyPath, err := generator.TrustPolicyPath()
+	if err != nil {
+		return err
+	}
+	registry := generator.NewRegistry(registryFlag)
+	if registry.Trust, err = generator.LoadTrustPolicy(policyPath); err != nil {
+		return err
+	}
+	installed, err := registry.Add(args[0], root)
+	if err != nil {
+		return err
+	}
+	if v := installed.Verification; v.Signer != "" {
+		fmt.Printf("✅ Installed %s@%s (checksum verified, signed by %s)\n", installed.Name, installed.Version, v.Signer)
+	} else {
+		fmt.Printf("⚠️  %s; trust its signer in %s\n", v.Warning, policyPath)
+		fmt.Printf("✅ Installed %s@%s (checksum verified, not signature verified)\n", installed.Name, installed.Version)
+	}
+	fmt.Printf("   %s\n", installed.Path)
+	fmt.Printf("\nCheck it renders with: goforge templates test %s\n", installed.Path)
+	return nil
+}
+
+func runTemplatesVerify(cmd *cobra.Command, args []string) error {
+	archive, err := os.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+	sigPath := signatureFlag
+	if sigPath == "" {
+		sigPath = args[0] + ".minisig"
+	}
+	signature, err := os.ReadFile(sigPath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	var policy *generator.TrustPolicy
+	if keyFlag != "" {
+		key, err := minisign.ParsePublicKey(keyFlag)
+		if err != nil {
+			return fmt.Errorf("--key: %w", err)
+		}
+		policy = &generator.TrustPolicy{Keys: map[string]*minisign.PublicKey{"--key": key}}
+	} else {
+		path, err := generator.TrustPolicyPath()
+		if err != nil {
+			return err
+		}
+		if policy, err = generator.LoadTrustPolicy(path); err != nil {
+			return err
+		}
+	}
+	policy.Unsigned = generator.UnsignedRefuse
+
+	v, err := policy.Verify(archive, string(signature), true)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ %s: signed by %s (key %s)\n", args[0], v.Signer, v.KeyID)
+	fmt.Printf("   trusted comment: %s\n", v.TrustedComment)
+	return nil
+}
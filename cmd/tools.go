@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/FACorreiaa/goforge/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var toolsCmd = &cobra.Command{
+	Use:   "tools",
+	Short: "Manage a generated project's development tools",
+	Long:  `Manage the development tools of a GoForge project. Run it from the project root or pass --dir.`,
+}
+
+var toolsInstallCmd = &cobra.Command{
+	Use:   "install [tool...]",
+	Short: "Install pinned versions of templ, air, goose, tailwindcss and sqlc into ./bin",
+	Long: `Install the project's development tools into ./bin at the versions pinned in
+tools.lock. Go tools are built with go install; the Tailwind CSS standalone
+CLI is downloaded for this platform and checked against its SHA-256
+checksum. Tools not yet in tools.lock get goforge's pins (templ follows
+go.mod) and are added to it; commit tools.lock so everyone installs the same
+versions. Without arguments templ, air, tailwindcss, goose (with a database)
+and any other tool in tools.lock are installed.
+
+The generated Makefile puts ./bin first on PATH, and make setup runs this.
+Downloads honor HTTPS_PROXY; with --asset-mirror (or $GOFORGE_ASSET_MIRROR)
+https://host/path is fetched from <mirror>/host/path. Go tools come through
+GOPROXY.
+
+Example:
+  goforge tools install
+  goforge tools install sqlc`,
+	RunE: runToolsInstall,
+}
+
+var toolsMirrorFlag string
+
+func init() {
+	toolsCmd.PersistentFlags().StringVar(&projectDirFlag, "dir", ".", "Project directory")
+	toolsInstallCmd.Flags().StringVar(&toolsMirrorFlag, "asset-mirror", os.Getenv(generator.AssetMirrorEnv), "Mirror base URL for downloads (default $"+generator.AssetMirrorEnv+")")
+	toolsCmd.AddCommand(toolsInstallCmd)
+	rootCmd.AddCommand(toolsCmd)
+}
+
+func runToolsInstall(cmd *cobra.Command, args []string) error {
+	mirror, err := generator.ParseAssetMirror(toolsMirrorFlag)
+	if err != nil {
+		return err
+	}
+	tools := generator.NewTools(projectDirFlag)
+	tools.Mirror = mirror
+	installed, err := tools.Install(args)
+	for _, tool := range installed {
+		status := "installed"
+		if tool.Current {
+			status = "up to date"
+		}
+		fmt.Printf("  ✓ %-12s %-10s %s\n", tool.Name, tool.Version, status)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("\n✅ Tools in %s, pinned in %s\n", filepath.Join(projectDirFlag, generator.ToolsBinDir), generator.ToolsLockFile)
+	fmt.Println("   make puts ./bin on PATH; elsewhere, run them as ./bin/<tool>")
+	return nil
+}
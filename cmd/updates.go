@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/FACorreiaa/goforge/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var checkUpdatesCmd = &cobra.Command{
+	Use:   "check-updates",
+	Short: "Report newer versions of a generated project's pinned dependencies",
+	Long: `List the pinned dependencies of a GoForge project that have a newer
+release: the frontend libraries make setup downloads (htmx, Alpine.js,
+hyperscript, the DaisyUI plugin) and the Tailwind CSS standalone CLI, looked
+up on the npm registry; the Go modules go.mod requires directly (go list -m
+-u); and the template bundle, against the one this goforge ships.
+
+--apply bumps the pins: the download URLs in the Makefile and Dockerfile,
+the checksums in assets.sha256 and the Dockerfile (the new files are
+downloaded to compute them), tools.lock, and go.mod through go get and go
+mod tidy. Then run make setup and the tests. Template bundle updates are
+applied with goforge upgrade. package.json ranges are left to npm outdated.
+
+Example:
+  goforge check-updates
+  goforge check-updates --apply`,
+	Args: cobra.NoArgs,
+	RunE: runCheckUpdates,
+}
+
+var (
+	checkUpdatesApplyFlag  bool
+	checkUpdatesMirrorFlag string
+)
+
+func init() {
+	checkUpdatesCmd.Flags().StringVar(&projectDirFlag, "dir", ".", "Project directory")
+	checkUpdatesCmd.Flags().BoolVar(&checkUpdatesApplyFlag, "apply", false, "Bump the pins to the latest versions")
+	checkUpdatesCmd.Flags().StringVar(&checkUpdatesMirrorFlag, "asset-mirror", os.Getenv(generator.AssetMirrorEnv), "Mirror base URL for downloads (default $"+generator.AssetMirrorEnv+")")
+	rootCmd.AddCommand(checkUpdatesCmd)
+}
+
+// updateKindLabels head each group of the check-updates output
+var updateKindLabels = map[string]string{
+	generator.UpdateFrontend:  "Frontend libraries",
+	generator.UpdateTool:      "Tools",
+	generator.UpdateGo:        "Go modules",
+	generator.UpdateTemplates: "Template bundle",
+}
+
+func runCheckUpdates(cmd *cobra.Command, args []string) error {
+	mirror, err := generator.ParseAssetMirror(checkUpdatesMirrorFlag)
+	if err != nil {
+		return err
+	}
+	checker := generator.NewUpdateChecker(projectDirFlag)
+	checker.Mirror = mirror
+	report, err := checker.Check(checkUpdatesApplyFlag)
+	if err != nil {
+		return err
+	}
+
+	for _, kind := range generator.UpdateKinds() {
+		var updates []generator.DependencyUpdate
+		for _, u := range report.Updates {
+			if u.Kind == kind {
+				updates = append(updates, u)
+			}
+		}
+		if len(updates) == 0 {
+			continue
+		}
+		fmt.Println(updateKindLabels[kind])
+		for _, u := range updates {
+			fmt.Printf("  %-40s %s → %s\n", u.Name, u.Current, u.Latest)
+		}
+	}
+
+	var failed []string
+	for _, kind := range generator.UpdateKinds() {
+		if err, ok := report.Failed[kind]; ok {
+			fmt.Printf("⚠️  %s not checked: %v\n", updateKindLabels[kind], err)
+			failed = append(failed, strings.ToLower(updateKindLabels[kind]))
+		}
+	}
+
+	switch {
+	case len(report.Updates) == 0 && len(failed) == 0:
+		fmt.Println("✅ Everything is up to date")
+	case checkUpdatesApplyFlag && len(report.Changed) > 0:
+		fmt.Printf("\n✅ Updated %s\n", strings.Join(report.Changed, ", "))
+		fmt.Println("   Run make setup to download the new versions, then the tests.")
+	case !checkUpdatesApplyFlag && len(report.Updates) > 0:
+		fmt.Println("\nRun 'goforge check-updates --apply' to bump the pins.")
+	}
+	for _, u := range report.Updates {
+		if u.Kind == generator.UpdateTemplates {
+			fmt.Println("Apply the template bundle with 'goforge upgrade'.")
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("could not check %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/FACorreiaa/goforge/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var upgradeCmd = &cobra.Command{
+	Use:   "upgrade",
+	Short: "Apply template changes released since the project was generated",
+	Long: `Bring a generated project to the template bundle this goforge ships for its
+channel. The changesets between the bundle version in .goforge.yaml and the
+current one are applied in order: files you have not edited are updated,
+added or removed; files you edited are skipped and the new version is
+written next to them as <file>.goforge-new for you to merge.
+
+Example:
+  goforge upgrade --dry-run
+  goforge upgrade`,
+	Args: cobra.NoArgs,
+	RunE: runUpgrade,
+}
+
+var changesetCmd = &cobra.Command{
+	Use:    "changeset",
+	Short:  "Write the changeset from an older template tree to the embedded one",
+	Long:   `For goforge maintainers releasing a template bundle: compare an older templates directory with the embedded templates and print the changeset as JSON.`,
+	Args:   cobra.NoArgs,
+	Hidden: true,
+	RunE:   runChangeset,
+}
+
+var (
+	upgradeDryRunFlag    bool
+	changesetFromDirFlag string
+	changesetChannelFlag string
+	changesetFromFlag    string
+	changesetToFlag      string
+)
+
+func init() {
+	upgradeCmd.Flags().StringVar(&projectDirFlag, "dir", ".", "Project directory")
+	upgradeCmd.Flags().BoolVar(&upgradeDryRunFlag, "dry-run", false, "Report the changes without writing them")
+	rootCmd.AddCommand(upgradeCmd)
+
+	changesetCmd.Flags().StringVar(&changesetFromDirFlag, "from-dir", "", "Templates directory of the previous release")
+	changesetCmd.Flags().StringVar(&changesetChannelFlag, "channel", generator.ChannelStable, "Template channel")
+	changesetCmd.Flags().StringVar(&changesetFromFlag, "from", "", "Bundle version of the previous release")
+	changesetCmd.Flags().StringVar(&changesetToFlag, "to", "", "Bundle version being released")
+	rootCmd.AddCommand(changesetCmd)
+}
+
+func runUpgrade(cmd *cobra.Command, args []string) error {
+	report, err := generator.Upgrade(projectDirFlag, upgradeDryRunFlag)
+	if err != nil {
+		return err
+	}
+	if report.From == report.To {
+		fmt.Printf("✅ Templates are up to date (%s)\n", report.To)
+		return nil
+	}
+
+	fmt.Printf("Templates %s → %s\n", report.From, report.To)
+	for _, step := range report.Steps {
+		fmt.Printf("   %s\n", step)
+	}
+	for _, group := range []struct {
+		label string
+		files []string
+	}{{"updated", report.Updated}, {"added", report.Added}, {"removed", report.Removed}} {
+		for _, f := range group.files {
+			fmt.Printf("   %-8s %s\n", group.label+":", f)
+		}
+	}
+
+	skipped := make([]string, 0, len(report.Skipped))
+	for f := range report.Skipped {
+		skipped = append(skipped, f)
+	}
+	sort.Strings(skipped)
+	for _, f := range skipped {
+		fmt.Printf("   skipped: %s (%s)\n", f, report.Skipped[f])
+	}
+
+	if upgradeDryRunFlag {
+		fmt.Println("\nDry run: nothing was written.")
+		return nil
+	}
+	fmt.Println("\n✅ Upgraded. Review the changes with 'git diff' and run 'make templ && go build ./...'.")
+	return nil
+}
+
+func runChangeset(cmd *cobra.Command, args []string) error {
+	if changesetFromDirFlag == "" || changesetFromFlag == "" || changesetToFlag == "" {
+		return fmt.Errorf("--from-dir, --from and --to are required")
+	}
+	cs, err := generator.NewChangeset(os.DirFS(changesetFromDirFlag), generator.Templates(),
+		changesetChannelFlag, changesetFromFlag, changesetToFlag)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(cs)
+}
@@ -0,0 +1,201 @@
+package generator
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+//go:embed snippets
+var snippetFS embed.FS
+
+// Name is a user-supplied identifier (e.g. "send-welcome-email") split into
+// the spellings the add commands need
+type Name struct {
+	Words []string
+}
+
+// ParseName splits kebab-case, snake_case, camelCase or PascalCase input into
+// words. It rejects anything that cannot become a Go identifier.
+func ParseName(input string) (Name, error) {
+	if input == "" {
+		return Name{}, fmt.Errorf("name is required")
+	}
+	if !unicode.IsLetter(rune(input[0])) {
+		return Name{}, fmt.Errorf("invalid name %q: must start with a letter", input)
+	}
+
+	var words []string
+	var current []rune
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, strings.ToLower(string(current)))
+			current = nil
+		}
+	}
+	runes := []rune(input)
+	for i, r := range runes {
+		switch {
+		case r == '-' || r == '_':
+			flush()
+		case r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r)):
+			return Name{}, fmt.Errorf("invalid name %q: use letters, digits, '-' or '_'", input)
+		case unicode.IsUpper(r) && i > 0 && (unicode.IsLower(runes[i-1]) ||
+			(i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))):
+			flush()
+			current = append(current, r)
+		default:
+			current = append(current, r)
+		}
+	}
+	flush()
+
+	if len(words) == 0 {
+		return Name{}, fmt.Errorf("invalid name %q", input)
+	}
+	return Name{Words: words}, nil
+}
+
+// Pascal returns the exported Go spelling ("SendWelcomeEmail")
+func (n Name) Pascal() string {
+	var b strings.Builder
+	for _, w := range n.Words {
+		b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+	}
+	return b.String()
+}
+
+// Camel returns the unexported Go spelling ("sendWelcomeEmail")
+func (n Name) Camel() string {
+	p := n.Pascal()
+	return strings.ToLower(p[:1]) + p[1:]
+}
+
+// Snake returns the file and identifier spelling ("send_welcome_email")
+func (n Name) Snake() string {
+	return strings.Join(n.Words, "_")
+}
+
+// Kebab returns the URL and command spelling ("send-welcome-email")
+func (n Name) Kebab() string {
+	return strings.Join(n.Words, "-")
+}
+
+// projectModule reads the module path from the go.mod of a generated project
+func projectModule(projectDir string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(projectDir, "go.mod"))
+	if err != nil {
+		return "", fmt.Errorf("%s is not a Go project (no go.mod): %w", projectDir, err)
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if rest, ok := strings.CutPrefix(strings.TrimSpace(line), "module "); ok {
+			return strings.Trim(strings.TrimSpace(rest), `"`), nil
+		}
+	}
+	return "", fmt.Errorf("no module directive in %s", filepath.Join(projectDir, "go.mod"))
+}
+
+// renderSnippet executes snippets/<name> with data, formatting Go output
+func renderSnippet(name string, data any) ([]byte, error) {
+	tmpl, err := template.ParseFS(snippetFS, "snippets/"+name)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("render %s: %w", name, err)
+	}
+	if !strings.HasSuffix(name, ".go.tmpl") {
+		return buf.Bytes(), nil
+	}
+	out, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format %s: %w", name, err)
+	}
+	return out, nil
+}
+
+// writeNewFile writes a file that must not exist yet, so the add commands
+// never overwrite user code
+func writeNewFile(path string, content []byte) error {
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%s already exists", path)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0644)
+}
+
+// insertAtMarker inserts text above a marker comment, indenting every line
+// like the marker, and formats the result when it is Go source
+func insertAtMarker(path, marker, text string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	content := string(data)
+
+	idx := strings.Index(content, marker)
+	if idx < 0 {
+		return fmt.Errorf("marker %q not found in %s", marker, path)
+	}
+	lineStart := strings.LastIndex(content[:idx], "\n") + 1
+	updated := content[:lineStart] + indentLines(text, content[lineStart:idx]) + content[lineStart:]
+
+	out := []byte(updated)
+	if strings.HasSuffix(path, ".go") {
+		if out, err = format.Source(out); err != nil {
+			return fmt.Errorf("format %s: %w", path, err)
+		}
+	}
+	return os.WriteFile(path, out, 0644)
+}
+
+// addImport adds an import path to a Go file unless it is already imported,
+// as the last entry of its import block, and formats the result
+func addImport(path, importPath string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	content := string(data)
+	quoted := strconv.Quote(importPath)
+	if strings.Contains(content, quoted) {
+		return nil
+	}
+
+	start := strings.Index(content, "\nimport (\n")
+	if start < 0 {
+		return fmt.Errorf("%s has no import block", path)
+	}
+	end := strings.Index(content[start:], "\n)\n")
+	if end < 0 {
+		return fmt.Errorf("%s has an unterminated import block", path)
+	}
+	at := start + end + 1
+	out, err := format.Source([]byte(content[:at] + "\t" + quoted + "\n" + content[at:]))
+	if err != nil {
+		return fmt.Errorf("format %s: %w", path, err)
+	}
+	return os.WriteFile(path, out, 0644)
+}
+
+// indentLines prefixes every non-empty line of text and ends it with a newline
+func indentLines(text, prefix string) string {
+	var b strings.Builder
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		if line != "" {
+			b.WriteString(prefix)
+		}
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,39 @@
+package generator
+
+import "testing"
+
+func TestParseName(t *testing.T) {
+	tests := []struct {
+		input  string
+		pascal string
+		snake  string
+	}{
+		{"send-welcome-email", "SendWelcomeEmail", "send_welcome_email"},
+		{"send_welcome_email", "SendWelcomeEmail", "send_welcome_email"},
+		{"sendWelcomeEmail", "SendWelcomeEmail", "send_welcome_email"},
+		{"SendWelcomeEmail", "SendWelcomeEmail", "send_welcome_email"},
+		{"HTTPCheck", "HttpCheck", "http_check"},
+		{"report2024", "Report2024", "report2024"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			n, err := ParseName(tt.input)
+			if err != nil {
+				t.Fatalf("ParseName(%q) error: %v", tt.input, err)
+			}
+			if got := n.Pascal(); got != tt.pascal {
+				t.Errorf("Pascal() = %q, want %q", got, tt.pascal)
+			}
+			if got := n.Snake(); got != tt.snake {
+				t.Errorf("Snake() = %q, want %q", got, tt.snake)
+			}
+		})
+	}
+
+	for _, bad := range []string{"", "1job", "send email", "send.email", "-"} {
+		if _, err := ParseName(bad); err == nil {
+			t.Errorf("ParseName(%q) succeeded, want an error", bad)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package generator
+
+import (
+	"bufio"
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// AssetChecksumsFile lists, in a generated project, the SHA-256 of each
+// frontend file make setup downloads; `goforge assets verify` checks them
+const AssetChecksumsFile = "assets.sha256"
+
+// assetSums is the checksum of every pinned asset URL goforge generates,
+// one "<sha256>  <url>" line each. Maintainers record it with the hidden
+// `goforge assets checksums` command whenever an asset version changes.
+//
+//go:embed assets.sum
+var assetSums string
+
+// frontendAsset is a third-party file make setup downloads into the project
+type frontendAsset struct {
+	Path string
+	URL  string
+}
+
+// floating reports whether the URL follows a moving version, so no checksum
+// can be pinned for it
+func (a frontendAsset) floating() bool {
+	return strings.Contains(a.URL, "@latest") || strings.Contains(a.URL, "@main")
+}
+
+// frontendAssets are the files make setup downloads for opts, in order
+func frontendAssets(opts Options) []frontendAsset {
+	assets := []frontendAsset{{"assets/js/htmx.min.js", "https://unpkg.com/htmx.org@2.0.4/dist/htmx.min.js"}}
+	switch opts.Frontend {
+	case FrontendHTMXHyperscript:
+		assets = append(assets, frontendAsset{"assets/js/hyperscript.min.js", "https://unpkg.com/hyperscript.org@0.9.14"})
+	case FrontendHTMXAlpine:
+		assets = append(assets, frontendAsset{"assets/js/alpinejs.min.js", "https://unpkg.com/alpinejs@3.14.8/dist/cdn.min.js"})
+	case FrontendHTMXSurreal:
+		assets = append(assets, frontendAsset{"assets/js/surreal.js", "https://cdn.jsdelivr.net/gh/gnat/surreal@main/surreal.js"})
+	}
+	if opts.CSSFramework == CSSFrameworkBasecoat {
+		assets = append(assets, frontendAsset{"assets/js/basecoat.min.js", "https://cdn.jsdelivr.net/npm/basecoat-css@latest/dist/basecoat.min.js"})
+	}
+	return assets
+}
+
+// daisyUIAssets are the DaisyUI plugin files make setup downloads
+func daisyUIAssets() []frontendAsset {
+	base := "https://github.com/saadeghi/daisyui/releases/download/v" + daisyUIVersion + "/"
+	return []frontendAsset{
+		{"assets/js/daisyui.mjs", base + "daisyui.mjs"},
+		{"assets/js/daisyui-theme.mjs", base + "daisyui-theme.mjs"},
+	}
+}
+
+// projectAssets are all the third-party files a project downloads: the
+// DaisyUI plugin (unless Basecoat replaces it) and the frontend libraries
+func projectAssets(opts Options) []frontendAsset {
+	assets := frontendAssets(opts)
+	if opts.CSSFramework != CSSFrameworkBasecoat {
+		assets = append(daisyUIAssets(), assets...)
+	}
+	return assets
+}
+
+// AssetURLs lists every asset URL goforge can generate a download for,
+// with whether a checksum can be pinned for it
+func AssetURLs() map[string]bool {
+	urls := map[string]bool{}
+	for _, frontend := range []string{FrontendHTMX, FrontendHTMXHyperscript, FrontendHTMXAlpine, FrontendHTMXSurreal} {
+		for _, a := range frontendAssets(Options{Frontend: frontend, CSSFramework: CSSFrameworkBasecoat}) {
+			urls[a.URL] = !a.floating()
+		}
+	}
+	for _, a := range daisyUIAssets() {
+		urls[a.URL] = true
+	}
+	return urls
+}
+
+// assetChecksums parses assetSums
+func assetChecksums() map[string]string {
+	sums := map[string]string{}
+	for _, line := range strings.Split(assetSums, "\n") {
+		if fields := strings.Fields(line); len(fields) == 2 && !strings.HasPrefix(line, "#") {
+			sums[fields[1]] = fields[0]
+		}
+	}
+	return sums
+}
+
+// assetChecksumLines is the body of AssetChecksumsFile for the assets a
+// project downloads: "<sha256>  <path>" for each one with a pinned checksum
+func assetChecksumLines(assets []frontendAsset) string {
+	sums := assetChecksums()
+	var b strings.Builder
+	for _, a := range assets {
+		if sum, ok := sums[a.URL]; ok {
+			fmt.Fprintf(&b, "%s  %s\n", sum, a.Path)
+		}
+	}
+	return b.String()
+}
+
+// assetDownloads renders curl commands for assets, through $ASSET_MIRROR when
+// set: Makefile recipe lines, or a Dockerfile RUN that also checks each
+// pinned checksum with sha256sum
+func assetDownloads(assets []frontendAsset, docker bool) string {
+	sums := assetChecksums()
+	var cmds []string
+	for _, a := range assets {
+		if !docker {
+			cmds = append(cmds, "curl -sfL -o "+a.Path+" "+makeAssetURL(a.URL))
+			continue
+		}
+		cmd := "curl -sfL -o " + a.Path + " " + dockerAssetURL(a.URL)
+		if sum, ok := sums[a.URL]; ok {
+			cmd += ` && \
+    echo "` + sum + "  " + a.Path + `" | sha256sum -c -`
+		}
+		cmds = append(cmds, cmd)
+	}
+	if docker {
+		return "RUN " + strings.Join(cmds, " && \\\n    ")
+	}
+	return "\n\t@" + strings.Join(cmds, "\n\t@")
+}
+
+// RecordAssetChecksums downloads every pinnable asset URL and returns the
+// assets.sum content for them
+func RecordAssetChecksums(client *http.Client) (string, error) {
+	var urls []string
+	for url, pinned := range AssetURLs() {
+		if pinned {
+			urls = append(urls, url)
+		}
+	}
+	sort.Strings(urls)
+	var b strings.Builder
+	b.WriteString("# SHA-256 of the frontend assets goforge pins, recorded by `goforge assets checksums`\n")
+	for _, url := range urls {
+		resp, err := client.Get(url)
+		if err != nil {
+			return "", err
+		}
+		h := sha256.New()
+		_, err = io.Copy(h, resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return "", err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return "", fmt.Errorf("%s: %s", url, resp.Status)
+		}
+		fmt.Fprintf(&b, "%s  %s\n", hex.EncodeToString(h.Sum(nil)), url)
+	}
+	return b.String(), nil
+}
+
+// VerifyAssets checks the project's downloaded frontend files against
+// AssetChecksumsFile and returns the paths verified. A missing or modified
+// file is an error.
+func VerifyAssets(projectDir string) ([]string, error) {
+	f, err := os.Open(filepath.Join(projectDir, AssetChecksumsFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var verified, problems []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		want, rel, ok := strings.Cut(line, "  ")
+		if !ok {
+			return nil, fmt.Errorf("%s: malformed line %q", AssetChecksumsFile, line)
+		}
+		data, err := os.ReadFile(filepath.Join(projectDir, filepath.FromSlash(rel)))
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", rel, err))
+			continue
+		}
+		sum := sha256.Sum256(data)
+		if got := hex.EncodeToString(sum[:]); !strings.EqualFold(got, want) {
+			problems = append(problems, fmt.Sprintf("%s: checksum mismatch (expected %s, got %s)", rel, want, got))
+			continue
+		}
+		verified = append(verified, rel)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(problems) > 0 {
+		return verified, fmt.Errorf("frontend assets do not match %s; delete them and run make setup again, or find out who changed them upstream:\n  %s",
+			AssetChecksumsFile, strings.Join(problems, "\n  "))
+	}
+	return verified, nil
+}
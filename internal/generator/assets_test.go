@@ -0,0 +1,114 @@
+package generator
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// roundTripFunc serves every request from a function
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestAssetChecksums(t *testing.T) {
+	htmx := []byte("htmx")
+	saved := assetSums
+	defer func() { assetSums = saved }()
+	assetSums = "# comment\n" + sha256Hex(htmx) + "  https://unpkg.com/htmx.org@2.0.4/dist/htmx.min.js\n"
+
+	projectDir := generateProject(t, Options{Frontend: FrontendHTMXAlpine})
+	sums := readProjectFile(t, projectDir, AssetChecksumsFile)
+	if !strings.Contains(sums, sha256Hex(htmx)+"  assets/js/htmx.min.js\n") || strings.Contains(sums, "alpinejs") {
+		t.Errorf("%s lists only pinned checksums by path:\n%s", AssetChecksumsFile, sums)
+	}
+	makefile := readProjectFile(t, projectDir, "Makefile")
+	for _, want := range []string{"curl -sfL -o assets/js/alpinejs.min.js", "$(GOFORGE) assets verify"} {
+		if !strings.Contains(makefile, want) {
+			t.Errorf("make setup missing %q", want)
+		}
+	}
+	dockerfile := readProjectFile(t, projectDir, "Dockerfile")
+	if !strings.Contains(dockerfile, `echo "`+sha256Hex(htmx)+`  assets/js/htmx.min.js" | sha256sum -c -`) {
+		t.Error("Dockerfile does not check the htmx checksum")
+	}
+
+	if _, err := VerifyAssets(projectDir); err == nil || !strings.Contains(err.Error(), "htmx.min.js") {
+		t.Errorf("VerifyAssets() without the download: %v", err)
+	}
+	os.MkdirAll(filepath.Join(projectDir, "assets", "js"), 0755)
+	path := filepath.Join(projectDir, "assets", "js", "htmx.min.js")
+	os.WriteFile(path, htmx, 0644)
+	if verified, err := VerifyAssets(projectDir); err != nil || len(verified) != 1 {
+		t.Errorf("VerifyAssets() = %v, %v", verified, err)
+	}
+	os.WriteFile(path, []byte("compromised"), 0644)
+	if _, err := VerifyAssets(projectDir); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("VerifyAssets() with a changed file: %v", err)
+	}
+}
+
+func TestRecordAssetChecksums(t *testing.T) {
+	client := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(r.URL.String()))}, nil
+	})}
+	sums, err := RecordAssetChecksums(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	url := "https://unpkg.com/alpinejs@3.14.8/dist/cdn.min.js"
+	if !strings.Contains(sums, sha256Hex([]byte(url))+"  "+url+"\n") {
+		t.Errorf("RecordAssetChecksums() missing %s:\n%s", url, sums)
+	}
+	if strings.Contains(sums, "@latest") || strings.Contains(sums, "@main") {
+		t.Errorf("RecordAssetChecksums() pinned a moving version:\n%s", sums)
+	}
+}
+
+func TestAssetMirror(t *testing.T) {
+	for in, want := range map[string]string{
+		"":                                   "",
+		"https://artifacts.example.com/cdn/": "https://artifacts.example.com/cdn",
+		"http://10.0.0.5:8081/repository":    "http://10.0.0.5:8081/repository",
+	} {
+		if got, err := ParseAssetMirror(in); err != nil || got != want {
+			t.Errorf("ParseAssetMirror(%q) = %q, %v, want %q", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"artifacts.example.com", "ftp://mirror", "https://mirror?x=1"} {
+		if _, err := ParseAssetMirror(bad); err == nil {
+			t.Errorf("ParseAssetMirror(%q) succeeded", bad)
+		}
+	}
+
+	projectDir := generateProject(t, Options{AssetMirror: "https://artifacts.example.com/cdn/"})
+	makefile := readProjectFile(t, projectDir, "Makefile")
+	for _, want := range []string{
+		"ASSET_MIRROR := $(or $(ASSET_MIRROR),https://artifacts.example.com/cdn)",
+		"curl -sfL -o assets/js/htmx.min.js $(or $(ASSET_MIRROR),https:/)/unpkg.com/htmx.org@2.0.4/dist/htmx.min.js",
+		`tools install --asset-mirror "$(ASSET_MIRROR)"`,
+	} {
+		if !strings.Contains(makefile, want) {
+			t.Errorf("Makefile missing %q", want)
+		}
+	}
+	dockerfile := readProjectFile(t, projectDir, "Dockerfile")
+	for _, want := range []string{
+		"ARG ASSET_MIRROR=https://artifacts.example.com/cdn",
+		`"${ASSET_MIRROR:-https:/}/unpkg.com/htmx.org@2.0.4/dist/htmx.min.js"`,
+		`"${ASSET_MIRROR:-https:/}/github.com/tailwindlabs/tailwindcss/releases/download/v4.1.4/tailwindcss-linux-${arch}-musl"`,
+	} {
+		if !strings.Contains(dockerfile, want) {
+			t.Errorf("Dockerfile missing %q", want)
+		}
+	}
+	if strings.Contains(dockerfile, "daisyui.com/fast") {
+		t.Error("Dockerfile still pipes the DaisyUI installer to bash")
+	}
+	if m := readProjectFile(t, projectDir, ".goforge.yaml"); !strings.Contains(m, "asset-mirror: https://artifacts.example.com/cdn") {
+		t.Errorf("manifest does not record the mirror:\n%s", m)
+	}
+}
@@ -0,0 +1,29 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var basePathRe = regexp.MustCompile(`^(/[A-Za-z0-9._~-]+)+$`)
+
+// ParseBasePath checks a subpath for --base-path and returns it with a
+// leading and no trailing slash: "app/" gives "/app". "/" and "" mean the
+// site root and give "".
+func ParseBasePath(p string) (string, error) {
+	p = strings.TrimRight(strings.TrimSpace(p), "/")
+	if p == "" {
+		return "", nil
+	}
+	if !strings.HasPrefix(p, "/") {
+		p = "/" + p
+	}
+	if !basePathRe.MatchString(p) || strings.Contains(p+"/", "/./") || strings.Contains(p+"/", "/../") {
+		return "", fmt.Errorf("invalid base path %q (want a path like /app, made of letters, digits and . _ ~ -)", p)
+	}
+	if p == "/health" || p == "/ready" {
+		return "", fmt.Errorf("base path %q collides with the health checks served at the root", p)
+	}
+	return p, nil
+}
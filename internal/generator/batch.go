@@ -0,0 +1,141 @@
+package generator
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/FACorreiaa/goforge/internal/manifest"
+)
+
+// BatchResult is how generating one project of a batch went
+type BatchResult struct {
+	Name, Module string
+	// Files is the number of files written
+	Files    int
+	Duration time.Duration
+	Err      error
+}
+
+// LoadBatch reads a batch file of projects to generate, one CSV row each:
+//
+//	name,module,example-resource,lean
+//	billing,github.com/acme/billing,none,true
+//	web,github.com/acme/web,note,
+//
+// The header names the columns: name (the project directory) and module,
+// then options by the flag names of `goforge new`.
+// An empty cell keeps the option's default.
+func LoadBatch(path string) ([]Options, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	projects, err := ParseBatch(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return projects, nil
+}
+
+// ParseBatch decodes a batch file (see LoadBatch)
+func ParseBatch(r io.Reader) ([]Options, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.TrimLeadingSpace = true
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) < 2 {
+		return nil, fmt.Errorf("want a header row and at least one project")
+	}
+	header := rows[0]
+	for i := range header {
+		header[i] = strings.TrimSpace(header[i])
+	}
+	if len(header) < 2 || header[0] != "name" || header[1] != "module" {
+		return nil, fmt.Errorf("the header must start with name,module")
+	}
+
+	var projects []Options
+	names := map[string]int{}
+	for i, row := range rows[1:] {
+		line := i + 2
+		opts := Options{
+			ProjectName:    strings.TrimSpace(row[0]),
+			ModulePath:     strings.TrimSpace(row[1]),
+			Frontend:       FrontendHTMX,
+			CSSFramework:   CSSFrameworkDaisyUI,
+			DeployProvider: DeployNone,
+			IncludeDB:      true,
+		}
+		if opts.ProjectName == "" || opts.ModulePath == "" {
+			return nil, fmt.Errorf("line %d: name and module are required", line)
+		}
+		dir := filepath.Clean(opts.ProjectName)
+		if prev, ok := names[dir]; ok {
+			return nil, fmt.Errorf("line %d: %s is also generated on line %d", line, opts.ProjectName, prev)
+		}
+		names[dir] = line
+
+		values := map[string]string{}
+		for j, v := range row[2:] {
+			if v = strings.TrimSpace(v); v != "" {
+				values[header[j+2]] = v
+			}
+		}
+		if errs := setOptions(&opts, values, ""); len(errs) > 0 {
+			return nil, fmt.Errorf("line %d: %w", line, errs[0])
+		}
+		projects = append(projects, opts)
+	}
+	return projects, nil
+}
+
+// GenerateBatch generates projects, up to workers at a time, in one process.
+// Their file listings are discarded; the results come back in the order of
+// projects whatever order they finish in.
+func GenerateBatch(projects []Options, workers int) []BatchResult {
+	if workers < 1 {
+		workers = 1
+	}
+	results := make([]BatchResult, len(projects))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range min(workers, len(projects)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				results[i] = generateBatchProject(projects[i])
+			}
+		}()
+	}
+	for i := range projects {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	return results
+}
+
+func generateBatchProject(opts Options) BatchResult {
+	result := BatchResult{Name: opts.ProjectName, Module: opts.ModulePath}
+	start := time.Now()
+	opts.Out = io.Discard
+	result.Err = GenerateWithOptions(opts)
+	result.Duration = time.Since(start)
+	if result.Err == nil {
+		if m, err := manifest.Load(opts.ProjectName); err == nil {
+			result.Files = len(m.Files)
+		}
+	}
+	return result
+}
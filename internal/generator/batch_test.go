@@ -0,0 +1,102 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseBatch(t *testing.T) {
+	projects, err := ParseBatch(strings.NewReader(`# platform services
+name,module,example-resource,lean,db
+billing,github.com/acme/billing,none,true,
+web, github.com/acme/web,note,,
+tools,github.com/acme/tools,,,false
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(projects) != 3 {
+		t.Fatalf("got %d projects, want 3", len(projects))
+	}
+	if p := projects[0]; p.ProjectName != "billing" || p.ModulePath != "github.com/acme/billing" || p.ExampleResource != ExampleNone || !p.Lean || !p.IncludeDB {
+		t.Errorf("billing = %+v", p)
+	}
+	if p := projects[1]; p.ModulePath != "github.com/acme/web" || p.ExampleResource != ExampleNote || p.Lean {
+		t.Errorf("web = %+v", p)
+	}
+	if p := projects[2]; p.IncludeDB || p.Frontend != FrontendHTMX {
+		t.Errorf("tools = %+v", p)
+	}
+
+	for src, want := range map[string]string{
+		"module,name\na,b\n": "the header must start with name,module",
+		"name,module\n":      "want a header row and at least one project",
+		"name,module\na,\n":  "line 2: name and module are required",
+		"name,module\na,example.com/a\na,example.com/b\n": "line 3: a is also generated on line 2",
+		"name,module,colour\na,example.com/a,red\n":       "line 2: colour is not a goforge option",
+		"name,module,search\na,example.com/a,solr\n":      `line 2: search: unknown value "solr"`,
+	} {
+		if _, err := ParseBatch(strings.NewReader(src)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ParseBatch(%q) error = %v, want %q", src, err, want)
+		}
+	}
+}
+
+func TestGenerateBatch(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "taken"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "taken", "notes.txt"), []byte("ours\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var projects []Options
+	for _, p := range []struct {
+		name string
+		opts Options
+	}{
+		{"api", Options{IncludeDB: true, Lean: true}},
+		{"web", Options{IncludeDB: true, ExampleResource: ExampleNote}},
+		{"taken", Options{}},
+		{"broken", Options{DataTable: true}},
+		{"site", Options{}},
+	} {
+		opts := p.opts
+		opts.ProjectName = filepath.Join(root, p.name)
+		opts.ModulePath = "github.com/acme/" + p.name
+		opts.Frontend, opts.CSSFramework, opts.DeployProvider = FrontendHTMX, CSSFrameworkDaisyUI, DeployNone
+		projects = append(projects, opts)
+	}
+
+	results := GenerateBatch(projects, 3)
+	if len(results) != len(projects) {
+		t.Fatalf("got %d results, want %d", len(results), len(projects))
+	}
+	for i, r := range results {
+		if r.Name != projects[i].ProjectName {
+			t.Errorf("result %d is %s, want %s: results must keep the batch order", i, r.Name, projects[i].ProjectName)
+		}
+		switch filepath.Base(r.Name) {
+		case "taken":
+			if r.Err == nil || !strings.Contains(r.Err.Error(), "already exists") {
+				t.Errorf("taken: error = %v, want already exists", r.Err)
+			}
+		case "broken":
+			if r.Err == nil {
+				t.Error("broken: generated an invalid option combination")
+			}
+		default:
+			if r.Err != nil {
+				t.Errorf("%s: %v", r.Name, r.Err)
+			} else if r.Files == 0 {
+				t.Errorf("%s: no files counted", r.Name)
+			}
+		}
+	}
+	assertFilesExist(t, root, "api/.goforge.yaml", "web/internal/server/routes.go", "site/go.mod")
+	if entries, _ := os.ReadDir(filepath.Join(root, "taken")); len(entries) != 1 {
+		t.Error("generated into the existing taken/ directory")
+	}
+}
@@ -0,0 +1,45 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Binaries a project can ship, in the order they are listed
+const (
+	BinaryServer = "server"
+	BinaryWorker = "worker"
+	BinaryCLI    = "cli"
+)
+
+var binaryNames = []string{BinaryServer, BinaryWorker, BinaryCLI}
+
+// ParseBinaries checks a comma-separated --binaries list and returns it
+// deduplicated in canonical order: "cli, worker" gives "server,worker,cli".
+// The server is always built, so "" gives "server".
+func ParseBinaries(list string) (string, error) {
+	selected := map[string]bool{BinaryServer: true}
+	for _, name := range strings.Split(list, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if !containsString(binaryNames, name) {
+			return "", fmt.Errorf("unknown binary %q (want %s)", name, strings.Join(binaryNames, ", "))
+		}
+		selected[name] = true
+	}
+	var out []string
+	for _, name := range binaryNames {
+		if selected[name] {
+			out = append(out, name)
+		}
+	}
+	return strings.Join(out, ","), nil
+}
+
+// hasBinary reports whether opts.Binaries, as returned by ParseBinaries,
+// includes name
+func hasBinary(opts Options, name string) bool {
+	return containsString(strings.Split(opts.Binaries, ","), name)
+}
@@ -0,0 +1,269 @@
+package generator
+
+import (
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/FACorreiaa/goforge/internal/manifest"
+)
+
+// BundleManifestFile describes a template bundle: its name, version, example
+// variables and the option matrix it is tested against
+const BundleManifestFile = "manifest.yaml"
+
+// bundleTestModule is the module path bundles are rendered with when tested
+const bundleTestModule = "example.com/bundle-test"
+
+// Bundle is a template bundle written outside goforge, as scaffolded by
+// `goforge templates new`
+type Bundle struct {
+	Name, Version, Description string
+	// Vars are example values for the {{ var "name" }} directives
+	Vars map[string]string
+	// Matrix lists the option sets the bundle must render with
+	Matrix []BundleCase
+}
+
+// BundleCase is one entry of a bundle's test matrix
+type BundleCase struct {
+	Name    string
+	Options Options
+}
+
+// BundleTestResult is the outcome of rendering a bundle for one matrix entry
+type BundleTestResult struct {
+	Case  string
+	Files int
+	// Problems are "file: problem" findings; none means the entry passed
+	Problems []string
+}
+
+// LoadBundle reads the manifest of the bundle in dir
+func LoadBundle(dir string) (*Bundle, error) {
+	data, err := os.ReadFile(filepath.Join(dir, BundleManifestFile))
+	if err != nil {
+		return nil, err
+	}
+	bundle, err := parseBundle(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", BundleManifestFile, err)
+	}
+	return bundle, nil
+}
+
+func parseBundle(src string) (*Bundle, error) {
+	doc, err := manifest.ParseDocument(src)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range doc.Keys() {
+		if !containsString([]string{"name", "version", "description", "vars", "matrix"}, key) {
+			return nil, fmt.Errorf("unknown key %q", key)
+		}
+	}
+	bundle := &Bundle{}
+	for key, field := range map[string]*string{"name": &bundle.Name, "version": &bundle.Version, "description": &bundle.Description} {
+		if *field, err = doc.String(key); err != nil {
+			return nil, err
+		}
+	}
+	if bundle.Name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	if bundle.Vars, err = doc.Strings("vars"); err != nil {
+		return nil, err
+	}
+	matrix, err := doc.Document("matrix")
+	if err != nil {
+		return nil, err
+	}
+	if len(matrix.Keys()) == 0 {
+		return nil, fmt.Errorf("matrix must list at least one option set")
+	}
+	for _, name := range matrix.Keys() {
+		values, err := matrix.Strings(name)
+		if err != nil {
+			return nil, err
+		}
+		opts := Options{
+			ProjectName:    "bundle-test",
+			ModulePath:     bundleTestModule,
+			Frontend:       FrontendHTMX,
+			CSSFramework:   CSSFrameworkDaisyUI,
+			DeployProvider: DeployNone,
+			IncludeDB:      true,
+			Vars:           bundle.Vars,
+		}
+		if errs := setOptions(&opts, values, "matrix."+name+"."); len(errs) > 0 {
+			return nil, errs[0]
+		}
+		bundle.Matrix = append(bundle.Matrix, BundleCase{Name: name, Options: opts})
+	}
+	return bundle, nil
+}
+
+// NewBundle scaffolds a template bundle in dir: a manifest with a test
+// matrix, example templates using each kind of directive, and a README
+// listing the conditions, placeholders and fragments templates can use. It
+// returns the files written, relative to dir.
+func NewBundle(dir, name, description string) ([]string, error) {
+	if _, err := os.Stat(filepath.Join(dir, BundleManifestFile)); err == nil {
+		return nil, fmt.Errorf("%s already has a %s", dir, BundleManifestFile)
+	}
+	if description == "" {
+		description = "A goforge template bundle."
+	}
+	data, err := bundleDocs()
+	if err != nil {
+		return nil, err
+	}
+	data.Name, data.Description, data.Module = name, description, placeholderModule
+
+	files := []struct{ rel, snippet string }{
+		{BundleManifestFile, "bundle_manifest.yaml.tmpl"},
+		{"README.md", "bundle_readme.md.tmpl"},
+		{"templates/README.md.tmpl", "bundle_example_readme.md.tmpl"},
+		{"templates/go.mod.tmpl", "bundle_example_gomod.tmpl"},
+		{"templates/cmd/hello/main.go.tmpl", "bundle_example_main.tmpl"},
+	}
+	var written []string
+	for _, f := range files {
+		content, err := renderSnippet(f.snippet, data)
+		if err != nil {
+			return nil, err
+		}
+		if err := writeNewFile(filepath.Join(dir, filepath.FromSlash(f.rel)), content); err != nil {
+			return nil, err
+		}
+		written = append(written, f.rel)
+	}
+	return written, nil
+}
+
+// bundleDocsData is what the bundle README documents
+type bundleDocsData struct {
+	Name, Description, Module string
+	Conditions, Placeholders  []string
+	Fragments                 []string
+}
+
+// bundleDocs lists the conditions, placeholders and fragments of this goforge
+func bundleDocs() (*bundleDocsData, error) {
+	opts, err := prepareOptions(Options{ProjectName: "app", ModulePath: bundleTestModule, IncludeDB: true})
+	if err != nil {
+		return nil, err
+	}
+	data := &bundleDocsData{}
+	for name := range conditions(opts) {
+		data.Conditions = append(data.Conditions, name)
+	}
+	for placeholder := range getReplacements(opts) {
+		data.Placeholders = append(data.Placeholders, placeholder)
+	}
+	sort.Strings(data.Conditions)
+	sort.Strings(data.Placeholders)
+
+	err = fs.WalkDir(fragmentFS, "fragments", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || p == "fragments" {
+			return err
+		}
+		if d.IsDir() && !isVariantDir(p) {
+			return nil
+		}
+		data.Fragments = append(data.Fragments, strings.TrimPrefix(p, "fragments/"))
+		if d.IsDir() {
+			return fs.SkipDir
+		}
+		return nil
+	})
+	return data, err
+}
+
+// variantNameRe matches the condition names fragment variants are named after
+var variantNameRe = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+// isVariantDir reports whether the fragments directory p is one fragment
+// with variants rather than a group of fragments
+func isVariantDir(p string) bool {
+	entries, err := fs.ReadDir(fragmentFS, p)
+	if err != nil || len(entries) == 0 {
+		return false
+	}
+	for _, e := range entries {
+		if e.IsDir() || (e.Name() != fragmentDefault && !variantNameRe.MatchString(e.Name())) {
+			return false
+		}
+	}
+	return true
+}
+
+// TestBundle renders the bundle in dir once per matrix entry, as goforge new
+// would, and reports the tags and placeholders left in the output, Go files
+// that do not parse and templates that fail to render. Nothing is written.
+func TestBundle(dir string) ([]BundleTestResult, error) {
+	bundle, err := LoadBundle(dir)
+	if err != nil {
+		return nil, err
+	}
+	templates := os.DirFS(filepath.Join(dir, "templates"))
+	if _, err := fs.Stat(templates, "."); err != nil {
+		return nil, fmt.Errorf("%s has no templates directory", dir)
+	}
+
+	var results []BundleTestResult
+	for _, c := range bundle.Matrix {
+		result := BundleTestResult{Case: c.Name}
+		opts, err := prepareOptions(c.Options)
+		if err != nil {
+			result.Problems = append(result.Problems, err.Error())
+			results = append(results, result)
+			continue
+		}
+		replacements := getReplacements(opts)
+		err = fs.WalkDir(templates, ".", func(p string, d fs.DirEntry, err error) error {
+			if err != nil || p == "." {
+				return err
+			}
+			if skipPath(p, opts) {
+				if d.IsDir() {
+					return fs.SkipDir
+				}
+				return nil
+			}
+			if d.IsDir() || isBinaryFile(p) {
+				return nil
+			}
+			data, err := fs.ReadFile(templates, p)
+			if err != nil {
+				return err
+			}
+			file := strings.TrimSuffix(p, ".tmpl")
+			result.Files++
+			content, err := renderFile(p, string(data), opts, replacements)
+			if err != nil {
+				result.Problems = append(result.Problems, file+": "+err.Error())
+				return nil
+			}
+			for _, tag := range placeholderRe.FindAllString(content, -1) {
+				result.Problems = append(result.Problems, file+": "+tag+" left in output")
+			}
+			if strings.HasSuffix(file, ".go") {
+				if _, err := format.Source([]byte(content)); err != nil {
+					result.Problems = append(result.Problems, file+": does not parse: "+err.Error())
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
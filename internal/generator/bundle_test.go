@@ -0,0 +1,94 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewBundleRendersCleanly(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "my-stack")
+	files, err := NewBundle(dir, "my-stack", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 || files[0] != BundleManifestFile {
+		t.Fatalf("NewBundle() wrote %v", files)
+	}
+	readme := readProjectFile(t, dir, "README.md")
+	for _, want := range []string{"- `DB`", "- `<!-- BASE_PATH -->`", "- `db/health-check`", "- `ci/postgres-service`"} {
+		if !strings.Contains(readme, want) {
+			t.Errorf("bundle README missing %q", want)
+		}
+	}
+
+	results, err := TestBundle(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("TestBundle() ran %d matrix entries, want 3", len(results))
+	}
+	for _, r := range results {
+		if len(r.Problems) > 0 || r.Files != 3 {
+			t.Errorf("matrix entry %s: %d files, problems %v", r.Case, r.Files, r.Problems)
+		}
+	}
+
+	if _, err := NewBundle(dir, "my-stack", ""); err == nil {
+		t.Error("NewBundle() overwrote an existing bundle")
+	}
+}
+
+func TestTestBundleReportsProblems(t *testing.T) {
+	dir := t.TempDir()
+	write := func(rel, content string) {
+		path := filepath.Join(dir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(BundleManifestFile, "name: broken\nmatrix:\n  default: {}\n  no-db:\n    db: false\n")
+	write("templates/notes.md.tmpl", "<!-- IF NOT DB -->no database <!-- TYPO -->\n<!-- /IF NOT DB -->")
+	write("templates/main.go.tmpl", "package main\n\nfunc main() {\n<!-- IF DB -->}\n<!-- /IF DB -->")
+	write("templates/owner.txt", `{{ var "owner" }}`)
+
+	results, err := TestBundle(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	problems := map[string]string{}
+	for _, r := range results {
+		problems[r.Case] = strings.Join(r.Problems, "\n")
+	}
+	for c, want := range map[string][]string{
+		"default": {`owner.txt: template uses var "owner"`},
+		"no-db":   {"notes.md: <!-- TYPO --> left in output", "main.go: does not parse"},
+	} {
+		for _, w := range want {
+			if !strings.Contains(problems[c], w) {
+				t.Errorf("%s problems missing %q:\n%s", c, w, problems[c])
+			}
+		}
+	}
+	if strings.Contains(problems["default"], "main.go") {
+		t.Errorf("default entry flags main.go, which renders with DB:\n%s", problems["default"])
+	}
+}
+
+func TestParseBundle(t *testing.T) {
+	for _, tc := range []struct{ src, want string }{
+		{"matrix:\n  default: {}\n", "name is required"},
+		{"name: x\n", "at least one option set"},
+		{"name: x\nmatrix:\n  a:\n    search: solr\n", "matrix.a.search"},
+		{"name: x\nauthor: me\nmatrix:\n  a: {}\n", `unknown key "author"`},
+	} {
+		if _, err := parseBundle(tc.src); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("parseBundle(%q) error = %v, want %q", tc.src, err, tc.want)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package generator
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+)
+
+//go:embed changesets
+var changesetFS embed.FS
+
+// Change operations
+const (
+	ChangeAdd    = "add"
+	ChangeRemove = "remove"
+	ChangeModify = "modify"
+)
+
+// Changeset lists the template changes between two bundle versions of a
+// channel. Changesets are stored as changesets/<channel>-<to>.json and
+// chained by From/To; `goforge upgrade` applies them in order.
+type Changeset struct {
+	Channel string   `json:"channel"`
+	From    string   `json:"from"`
+	To      string   `json:"to"`
+	Changes []Change `json:"changes"`
+}
+
+// Change is one template file added, removed or modified. Patch is a unified
+// diff of the template (adds diff from empty, removes to empty), so older
+// templates can be rebuilt by undoing the patches of later changesets.
+type Change struct {
+	Path  string `json:"path"`
+	Op    string `json:"op"`
+	Patch string `json:"patch"`
+}
+
+// loadChangesets reads every changeset in fsys
+func loadChangesets(fsys fs.FS) ([]Changeset, error) {
+	var changesets []Changeset
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || path.Ext(p) != ".json" {
+			return err
+		}
+		data, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return err
+		}
+		var cs Changeset
+		if err := json.Unmarshal(data, &cs); err != nil {
+			return fmt.Errorf("changeset %s: %w", p, err)
+		}
+		changesets = append(changesets, cs)
+		return nil
+	})
+	return changesets, err
+}
+
+// changesetChain returns the changesets leading from one bundle version of a
+// channel to another, oldest first
+func changesetChain(all []Changeset, channel, from, to string) ([]Changeset, error) {
+	next := map[string]Changeset{}
+	for _, cs := range all {
+		if cs.Channel == channel {
+			next[cs.From] = cs
+		}
+	}
+
+	var chain []Changeset
+	for version := from; version != to; {
+		cs, ok := next[version]
+		if !ok {
+			return nil, fmt.Errorf("no %s changeset from template bundle %s towards %s", channel, version, to)
+		}
+		chain = append(chain, cs)
+		version = cs.To
+		if len(chain) > len(all) {
+			return nil, fmt.Errorf("%s changesets loop at %s", channel, version)
+		}
+	}
+	return chain, nil
+}
+
+// NewChangeset compares two template trees (the templates directory of two
+// goforge revisions) and returns the changes from oldTemplates to newTemplates.
+// Maintainers store the result in changesets/ when bumping a bundle version.
+func NewChangeset(oldTemplates, newTemplates fs.FS, channel, from, to string) (*Changeset, error) {
+	oldFiles, err := templateFiles(oldTemplates)
+	if err != nil {
+		return nil, err
+	}
+	newFiles, err := templateFiles(newTemplates)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := map[string]bool{}
+	for p := range oldFiles {
+		paths[p] = true
+	}
+	for p := range newFiles {
+		paths[p] = true
+	}
+	sorted := make([]string, 0, len(paths))
+	for p := range paths {
+		sorted = append(sorted, p)
+	}
+	sort.Strings(sorted)
+
+	cs := &Changeset{Channel: channel, From: from, To: to}
+	for _, p := range sorted {
+		oldContent, inOld := oldFiles[p]
+		newContent, inNew := newFiles[p]
+		if inOld && inNew && oldContent == newContent {
+			continue
+		}
+		if isBinaryFile(p) {
+			return nil, fmt.Errorf("%s: binary templates cannot be patched", p)
+		}
+		op := ChangeModify
+		switch {
+		case !inOld:
+			op = ChangeAdd
+		case !inNew:
+			op = ChangeRemove
+		}
+		cs.Changes = append(cs.Changes, Change{Path: p, Op: op, Patch: unifiedDiff(oldContent, newContent)})
+	}
+	return cs, nil
+}
+
+// templateFiles reads every file of a template tree by slash-separated path
+func templateFiles(fsys fs.FS) (map[string]string, error) {
+	files := map[string]string{}
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return err
+		}
+		files[p] = string(data)
+		return nil
+	})
+	return files, err
+}
+
+// templatesAt rebuilds the templates a chain of changesets touches as they
+// were before the chain, by undoing its patches on the current templates.
+// present reports whether each template existed then.
+func templatesAt(current fs.FS, chain []Changeset) (map[string]string, map[string]bool, error) {
+	content := map[string]string{}
+	present := map[string]bool{}
+	for _, cs := range chain {
+		for _, c := range cs.Changes {
+			if _, seen := present[c.Path]; seen {
+				continue
+			}
+			data, err := fs.ReadFile(current, c.Path)
+			present[c.Path] = err == nil
+			content[c.Path] = string(data)
+		}
+	}
+
+	for i := len(chain) - 1; i >= 0; i-- {
+		for _, c := range chain[i].Changes {
+			old, err := applyPatch(content[c.Path], c.Patch, true)
+			if err != nil {
+				return nil, nil, fmt.Errorf("changeset %s→%s: undo %s: %w", chain[i].From, chain[i].To, c.Path, err)
+			}
+			content[c.Path] = old
+			present[c.Path] = c.Op != ChangeAdd
+		}
+	}
+	return content, present, nil
+}
+
+// renderedPath is the project file a template renders to
+func renderedPath(templatePath string) string {
+	return strings.TrimSuffix(templatePath, ".tmpl")
+}
@@ -0,0 +1,43 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Template channels. Every goforge release ships one template bundle per
+// channel: stable is what `goforge new` generates by default, edge carries
+// changes (newer Go, newer APIs) before they are promoted to stable. Edge
+// differences live in the same templates as <!-- IF CHANNEL_EDGE --> blocks.
+const (
+	ChannelStable = "stable"
+	ChannelEdge   = "edge"
+)
+
+// channels lists the template channels, stable first
+var channels = []string{ChannelStable, ChannelEdge}
+
+// bundleVersions are the template bundle versions this goforge ships. Bump a
+// channel's version whenever its templates change, so upgrades can tell
+// which changesets a project is missing.
+var bundleVersions = map[string]string{
+	ChannelStable: "1.0.0",
+	ChannelEdge:   "1.1.0-edge.1",
+}
+
+// Channels returns the template channels, stable first
+func Channels() []string {
+	return append([]string{}, channels...)
+}
+
+// BundleVersion returns the template bundle version a channel generates
+func BundleVersion(channel string) (string, error) {
+	if channel == "" {
+		channel = ChannelStable
+	}
+	v, ok := bundleVersions[channel]
+	if !ok {
+		return "", fmt.Errorf("unknown template channel %q (valid: %s)", channel, strings.Join(channels, ", "))
+	}
+	return v, nil
+}
@@ -0,0 +1,160 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/FACorreiaa/goforge/internal/manifest"
+)
+
+const (
+	// commandsMarker is where `add command` registers new subcommands
+	commandsMarker = "// goforge:commands"
+
+	cobraModule  = "github.com/spf13/cobra"
+	cobraVersion = "v1.8.1"
+)
+
+// AddCommand adds a cobra subcommand to the project's maintenance CLI in
+// cmd/cli, creating the CLI on first use. Commands load the project config
+// and, when the project has a database, a connection pool.
+// It returns the created and modified files, relative to projectDir.
+func AddCommand(projectDir, name string) ([]string, error) {
+	n, err := ParseName(name)
+	if err != nil {
+		return nil, err
+	}
+	module, err := projectModule(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	mf, err := requireManifest(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(filepath.Join(projectDir, "internal", "config", "config.go")); err != nil {
+		return nil, fmt.Errorf("%s has no internal/config package: is it a GoForge project?", projectDir)
+	}
+	_, dbErr := os.Stat(filepath.Join(projectDir, "internal", "database", "database.go"))
+	_, txErr := os.Stat(filepath.Join(projectDir, "internal", "database", "tx.go"))
+
+	vars := struct {
+		Module  string
+		Project string
+		Type    string
+		Use     string
+		HasDB   bool
+		HasTx   bool
+	}{
+		Module:  module,
+		Project: filepath.Base(module),
+		Type:    n.Pascal(),
+		Use:     n.Kebab(),
+		HasDB:   dbErr == nil,
+		HasTx:   txErr == nil,
+	}
+
+	mainRel := filepath.Join("cmd", "cli", "main.go")
+	cmdRel := filepath.Join("cmd", "cli", n.Snake()+".go")
+	if _, err := os.Stat(filepath.Join(projectDir, cmdRel)); err == nil {
+		return nil, fmt.Errorf("command %q already exists (%s)", n.Kebab(), cmdRel)
+	}
+
+	var changed []string
+	mainPath := filepath.Join(projectDir, mainRel)
+	if _, err := os.Stat(mainPath); os.IsNotExist(err) {
+		content, err := renderSnippet("cli_main.go.tmpl", vars)
+		if err != nil {
+			return nil, err
+		}
+		if err := writeNewFile(mainPath, content); err != nil {
+			return nil, err
+		}
+	}
+
+	content, err := renderSnippet("command.go.tmpl", vars)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeNewFile(filepath.Join(projectDir, cmdRel), content); err != nil {
+		return nil, err
+	}
+	changed = append(changed, cmdRel)
+
+	line := fmt.Sprintf("rootCmd.AddCommand(new%sCmd())", n.Pascal())
+	if err := insertAtMarker(mainPath, commandsMarker, line); err != nil {
+		return nil, err
+	}
+	changed = append(changed, mainRel)
+
+	added, err := ensureRequire(projectDir, cobraModule, cobraVersion)
+	if err != nil {
+		return nil, err
+	}
+	if added {
+		changed = append(changed, "go.mod")
+	}
+	if err := recordChanges(projectDir, mf, []string{cmdRel}); err != nil {
+		return nil, err
+	}
+	return changed, nil
+}
+
+// ensureRequire adds a requirement to the project's go.mod unless the module
+// is already required. It reports whether go.mod changed; go.sum still needs
+// `go mod tidy`.
+func ensureRequire(projectDir, module, version string) (bool, error) {
+	path := filepath.Join(projectDir, "go.mod")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	content := string(data)
+
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(strings.TrimPrefix(strings.TrimSpace(line), "require"))
+		if len(fields) > 0 && fields[0] == module {
+			return false, nil
+		}
+	}
+
+	requirement := module + " " + version
+	if idx := strings.Index(content, "require (\n"); idx >= 0 {
+		at := idx + len("require (\n")
+		content = content[:at] + "\t" + requirement + "\n" + content[at:]
+	} else {
+		content = strings.TrimRight(content, "\n") + "\n\nrequire " + requirement + "\n"
+	}
+	return true, os.WriteFile(path, []byte(content), 0644)
+}
+
+// writeCLIMain renders cmd/cli/main.go for --binaries cli with the snippet
+// `add command` starts the CLI from, so later commands register at its
+// marker. Its checksum is added to files.
+func writeCLIMain(opts Options, files map[string]string) error {
+	if !hasBinary(opts, BinaryCLI) {
+		return nil
+	}
+	vars := struct {
+		Module  string
+		Project string
+		HasDB   bool
+	}{
+		Module:  opts.ModulePath,
+		Project: filepath.Base(opts.ModulePath),
+		HasDB:   opts.IncludeDB,
+	}
+	content, err := renderSnippet("cli_main.go.tmpl", vars)
+	if err != nil {
+		return err
+	}
+	rel := filepath.Join("cmd", "cli", "main.go")
+	if err := writeNewFile(filepath.Join(opts.ProjectName, rel), content); err != nil {
+		return err
+	}
+	files[filepath.ToSlash(rel)] = manifest.Checksum(content)
+	fmt.Fprintf(opts.Out, "  ✓ %s\n", filepath.ToSlash(rel))
+	return nil
+}
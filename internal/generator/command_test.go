@@ -0,0 +1,85 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAddCommand(t *testing.T) {
+	projectDir := generateProject(t, Options{IncludeDB: true})
+
+	if _, err := AddCommand(projectDir, "backfill-slugs"); err != nil {
+		t.Fatalf("AddCommand() error: %v", err)
+	}
+	if _, err := AddCommand(projectDir, "PruneSessions"); err != nil {
+		t.Fatalf("second AddCommand() error: %v", err)
+	}
+
+	assertFilesExist(t, projectDir,
+		"cmd/cli/main.go",
+		"cmd/cli/backfill_slugs.go",
+		"cmd/cli/prune_sessions.go",
+	)
+
+	main := readProjectFile(t, projectDir, "cmd/cli/main.go")
+	want := "rootCmd.AddCommand(newBackfillSlugsCmd())\n\trootCmd.AddCommand(newPruneSessionsCmd())\n\t" + commandsMarker
+	if !strings.Contains(main, want) {
+		t.Errorf("commands not registered in order above the marker:\n%s", main)
+	}
+	if !strings.Contains(main, "github.com/test/app/internal/config") {
+		t.Error("cli main.go does not import the project config")
+	}
+
+	command := readProjectFile(t, projectDir, "cmd/cli/backfill_slugs.go")
+	for _, want := range []string{`Use:   "backfill-slugs"`, "database.New()", "db.GetPool()", "database.WithTx(ctx, pool,", "database.ErrRollback"} {
+		if !strings.Contains(command, want) {
+			t.Errorf("command missing %s", want)
+		}
+	}
+
+	goMod := readProjectFile(t, projectDir, "go.mod")
+	if strings.Count(goMod, cobraModule+" ") != 1 {
+		t.Errorf("go.mod should require cobra exactly once:\n%s", goMod)
+	}
+
+	if _, err := AddCommand(projectDir, "backfill_slugs"); err == nil {
+		t.Error("AddCommand() overwrote an existing command")
+	}
+}
+
+func TestAddCommandWithoutDB(t *testing.T) {
+	projectDir := generateProject(t, Options{})
+
+	if _, err := AddCommand(projectDir, "rebuild-cache"); err != nil {
+		t.Fatalf("AddCommand() error: %v", err)
+	}
+	command := readProjectFile(t, projectDir, "cmd/cli/rebuild_cache.go")
+	if strings.Contains(command, "database") || strings.Contains(command, "pgxpool") {
+		t.Error("command references the database in a project without one")
+	}
+}
+
+func TestEnsureRequire(t *testing.T) {
+	dir := t.TempDir()
+	goMod := filepath.Join(dir, "go.mod")
+	if err := os.WriteFile(goMod, []byte("module example.com/x\n\ngo 1.23\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, wantAdded := range []bool{true, false} {
+		added, err := ensureRequire(dir, cobraModule, cobraVersion)
+		if err != nil {
+			t.Fatalf("ensureRequire() error: %v", err)
+		}
+		if added != wantAdded {
+			t.Errorf("call %d: added = %v, want %v", i+1, added, wantAdded)
+		}
+	}
+
+	data, _ := os.ReadFile(goMod)
+	if !strings.Contains(string(data), "require "+cobraModule+" "+cobraVersion) {
+		t.Errorf("go.mod without a require block not updated:\n%s", data)
+	}
+}
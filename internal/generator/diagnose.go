@@ -0,0 +1,332 @@
+package generator
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RunLogFile is the record of the last goforge run, in goforge's user cache
+// directory; `goforge diagnose` packages it for bug reports
+const RunLogFile = "last-run.json"
+
+// maxRunOutput caps the output a run log keeps. The end of a long run, where
+// it failed, is what is kept.
+const maxRunOutput = 1 << 20
+
+// runEnv are the environment variables a diagnosis shows, besides GOFORGE_*
+var runEnv = []string{"GOPROXY", "GOFLAGS", "GOTOOLCHAIN", "GOPRIVATE", "CGO_ENABLED", "CI", "TERM", "LANG", "NO_COLOR", "DO_NOT_TRACK"}
+
+var (
+	// urlCredentialsRe matches the user and password of a URL
+	urlCredentialsRe = regexp.MustCompile(`([a-zA-Z][a-zA-Z0-9+.-]*://)[^/\s@]+@`)
+	// secretAssignRe matches the value of a token=..., password: ... pair
+	secretAssignRe = regexp.MustCompile(`(?i)((?:token|secret|password|passwd|api[_-]?key|auth)[a-z_-]*\s*[=:]\s*)[^\s&"',]+`)
+	// tokenRe matches well-known token formats: GitHub, Stripe, Slack
+	tokenRe = regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{20,}|github_pat_\w{20,}|[sr]k_(?:live|test)_\w{10,}|xox[abpr]-[\w-]{10,})`)
+)
+
+// RunLog records one goforge run: the command line, the options of the
+// projects it generated, what they printed and how the run ended. Private
+// values (project names, module paths, template variables, the home
+// directory, credentials) are redacted when it is saved.
+type RunLog struct {
+	Goforge  string              `json:"goforge"`
+	Args     []string            `json:"args"`
+	Started  time.Time           `json:"started"`
+	Duration string              `json:"duration"`
+	Projects []map[string]string `json:"projects,omitempty"`
+	Errors   []string            `json:"errors,omitempty"`
+	// Template is the template the run failed to render, by its path in the
+	// template tree
+	Template string `json:"template,omitempty"`
+	Panic    string `json:"panic,omitempty"`
+	Stack    string `json:"stack,omitempty"`
+	Output   string `json:"output,omitempty"`
+
+	mu  sync.Mutex
+	out []byte
+	// private maps the values to redact to their placeholder
+	private map[string]string
+}
+
+// NewRunLog starts the log of a run with the given command line arguments
+func NewRunLog(args []string) *RunLog {
+	return &RunLog{
+		Goforge: Version,
+		Args:    slices.Clone(args),
+		Started: time.Now().UTC().Truncate(time.Second),
+		private: map[string]string{},
+	}
+}
+
+// RunLogDir is goforge's user cache directory, where the run log is kept
+func RunLogDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "goforge"), nil
+}
+
+// Record adds the projects the run generates
+func (r *RunLog) Record(projects ...Options) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, opts := range projects {
+		suffix := ""
+		if n := len(r.Projects); n > 0 {
+			suffix = fmt.Sprintf("-%d", n+1)
+		}
+		r.hide(opts.ModulePath, "<module"+suffix+">")
+		r.hide(opts.ProjectName, "<project"+suffix+">")
+		r.hide(filepath.Base(opts.ProjectName), "<project"+suffix+">")
+		for name, v := range opts.Vars {
+			r.hide(v, "<var "+name+">")
+		}
+
+		values := optionsToManifest(opts)
+		if opts.Channel != "" {
+			values["channel"] = opts.Channel
+		}
+		if opts.At != "" {
+			values["at"] = opts.At
+		}
+		r.Projects = append(r.Projects, values)
+	}
+}
+
+// hide redacts value wherever it appears. Values too short to be told from
+// ordinary words are left alone.
+func (r *RunLog) hide(value, placeholder string) {
+	if len(value) >= 3 {
+		if _, ok := r.private[value]; !ok {
+			r.private[value] = placeholder
+		}
+	}
+}
+
+// Write keeps what the run prints
+func (r *RunLog) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.out = append(r.out, p...)
+	if len(r.out) > maxRunOutput {
+		r.out = r.out[len(r.out)-maxRunOutput:]
+	}
+	return len(p), nil
+}
+
+// Fail records an error the run ended with, and the template it failed to
+// render if any
+func (r *RunLog) Fail(err error) {
+	if err == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Errors = append(r.Errors, err.Error())
+	var tmplErr *TemplateError
+	if r.Template == "" && errors.As(err, &tmplErr) {
+		r.Template = tmplErr.Path
+	}
+}
+
+// Crash records a panic and the stack it was raised on
+func (r *RunLog) Crash(v any, stack []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Panic = fmt.Sprint(v)
+	r.Stack = string(stack)
+}
+
+// Save writes the redacted log to dir, replacing the previous run's
+func (r *RunLog) Save(dir string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	redact := func(s string) string { return redactText(s, r.private) }
+	saved := &RunLog{
+		Goforge:  r.Goforge,
+		Started:  r.Started,
+		Duration: time.Since(r.Started).Round(time.Millisecond).String(),
+		Template: r.Template,
+		Panic:    redact(r.Panic),
+		Stack:    redact(r.Stack),
+		Output:   redact(string(r.out)),
+	}
+	for _, arg := range r.Args {
+		saved.Args = append(saved.Args, redact(arg))
+	}
+	for _, values := range r.Projects {
+		project := map[string]string{}
+		for k, v := range values {
+			project[k] = redact(v)
+		}
+		saved.Projects = append(saved.Projects, project)
+	}
+	for _, e := range r.Errors {
+		saved.Errors = append(saved.Errors, redact(e))
+	}
+
+	data, err := marshalRunLog(saved)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	path := filepath.Join(dir, RunLogFile)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// marshalRunLog is the JSON of a run log, keeping the redaction
+// placeholders readable
+func marshalRunLog(r *RunLog) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	err := enc.Encode(r)
+	return buf.Bytes(), err
+}
+
+// LoadRunLog reads the log of the last run saved in dir
+func LoadRunLog(dir string) (*RunLog, error) {
+	path := filepath.Join(dir, RunLogFile)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var r RunLog
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &r, nil
+}
+
+// redactText replaces the private values, longest first, the home
+// directory and anything that looks like a credential
+func redactText(s string, private map[string]string) string {
+	values := make([]string, 0, len(private))
+	for v := range private {
+		values = append(values, v)
+	}
+	sort.Slice(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+	for _, v := range values {
+		s = strings.ReplaceAll(s, v, private[v])
+	}
+	if home, err := os.UserHomeDir(); err == nil && len(home) > 1 {
+		s = strings.ReplaceAll(s, home, "~")
+	}
+	s = urlCredentialsRe.ReplaceAllString(s, "${1}<redacted>@")
+	s = secretAssignRe.ReplaceAllString(s, "${1}<redacted>")
+	return tokenRe.ReplaceAllString(s, "<redacted>")
+}
+
+// WriteDiagnosis writes a zip for a bug report: the run log, its output, the
+// environment and, with withTemplate, the source of the template the run
+// failed to render. It returns the names of the files in the zip.
+func WriteDiagnosis(w io.Writer, r *RunLog, withTemplate bool) ([]string, error) {
+	zw := zip.NewWriter(w)
+	var names []string
+	add := func(name string, data []byte) error {
+		f, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: r.Started})
+		if err != nil {
+			return err
+		}
+		names = append(names, name)
+		_, err = f.Write(data)
+		return err
+	}
+
+	output := r.Output
+	r.Output = ""
+	run, err := marshalRunLog(r)
+	r.Output = output
+	if err != nil {
+		return nil, err
+	}
+	if err := add("run.json", run); err != nil {
+		return nil, err
+	}
+	if output != "" {
+		if err := add("output.log", []byte(output)); err != nil {
+			return nil, err
+		}
+	}
+	if err := add("environment.txt", []byte(diagnosisEnvironment())); err != nil {
+		return nil, err
+	}
+	if withTemplate && r.Template != "" {
+		source, err := runTemplate(r)
+		if err != nil {
+			return nil, fmt.Errorf("template %s: %w", r.Template, err)
+		}
+		if err := add("template/"+r.Template, source); err != nil {
+			return nil, err
+		}
+	}
+	return names, zw.Close()
+}
+
+// diagnosisEnvironment describes the machine goforge runs on, redacted
+func diagnosisEnvironment() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "goforge %s, built with %s, %s/%s\n", Version, runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	if out, err := exec.Command("go", "env", "GOVERSION").Output(); err == nil {
+		fmt.Fprintf(&b, "go on PATH: %s\n", strings.TrimSpace(string(out)))
+	} else {
+		b.WriteString("go on PATH: not found\n")
+	}
+	var names []string
+	for _, kv := range os.Environ() {
+		name, _, _ := strings.Cut(kv, "=")
+		if strings.HasPrefix(name, "GOFORGE_") || slices.Contains(runEnv, name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(&b, "%s=%s\n", name, os.Getenv(name))
+	}
+	return redactText(b.String(), nil)
+}
+
+// runTemplate reads the template a run failed to render from the bundle its
+// first project was generated with
+func runTemplate(r *RunLog) ([]byte, error) {
+	opts := Options{Channel: ChannelStable}
+	if len(r.Projects) > 0 {
+		if ch := r.Projects[0]["channel"]; ch != "" {
+			opts.Channel = ch
+		}
+		opts.At = r.Projects[0]["at"]
+	}
+	changesets, err := loadChangesets(changesetFS)
+	if err != nil {
+		return nil, err
+	}
+	templates, _, _, err := templatesFor(opts, changesets, goforgeReleases)
+	if err != nil {
+		return nil, err
+	}
+	return fs.ReadFile(templates, r.Template)
+}
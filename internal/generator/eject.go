@@ -0,0 +1,112 @@
+package generator
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/FACorreiaa/goforge/internal/manifest"
+)
+
+var (
+	// markerLineRe matches a line holding only a goforge marker, such as
+	// "// goforge:endpoints" or "# goforge:paths"
+	markerLineRe = regexp.MustCompile(`^[ \t]*(?://|#) goforge:[a-z-]+[ \t]*$`)
+	// commandRefRe matches prose that points at a goforge command
+	commandRefRe = regexp.MustCompile(`\bgoforge (?:add|generate|upgrade|regen|manifest)\b`)
+)
+
+// EjectReport describes what `goforge eject` removed and what it left for
+// the team to tidy, relative to the project directory
+type EjectReport struct {
+	// Removed lists the goforge bookkeeping deleted: the manifest and report
+	Removed []string
+	// Markers maps each file to the number of marker lines stripped from it
+	Markers map[string]int
+	// Leftovers are .goforge-new and .goforge-bak files still to be merged
+	Leftovers []string
+	// References are "file:line" mentions of goforge commands, in comments
+	// and docs, that no longer apply
+	References []string
+}
+
+// Eject ends goforge's management of a project: the manifest with its
+// checksums and the generation report are deleted and the markers the add
+// commands insert at are stripped. Nothing else changes, so the project
+// builds as before. With dryRun nothing is written.
+func Eject(projectDir string, dryRun bool) (*EjectReport, error) {
+	if _, err := requireManifest(projectDir); err != nil {
+		return nil, err
+	}
+	report := &EjectReport{Markers: map[string]int{}}
+
+	err := filepath.WalkDir(projectDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(projectDir, path)
+		if d.IsDir() {
+			if path != projectDir && (renameSkipDirs[d.Name()] || rel == filepath.Dir(ReportPath)) {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(path, newFileSuffix) || strings.HasSuffix(path, backupSuffix) {
+			report.Leftovers = append(report.Leftovers, rel)
+			return nil
+		}
+		if !d.Type().IsRegular() || isBinaryFile(path) || path == manifest.Path(projectDir) {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if !strings.Contains(string(data), "goforge") {
+			return nil
+		}
+		lines := strings.SplitAfter(string(data), "\n")
+		kept := lines[:0]
+		for _, line := range lines {
+			if markerLineRe.MatchString(strings.TrimRight(line, "\r\n")) {
+				report.Markers[rel]++
+				continue
+			}
+			if commandRefRe.MatchString(line) {
+				report.References = append(report.References, fmt.Sprintf("%s:%d", rel, len(kept)+1))
+			}
+			kept = append(kept, line)
+		}
+		if report.Markers[rel] == 0 || dryRun {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(path, []byte(strings.Join(kept, "")), info.Mode().Perm())
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(report.Leftovers)
+
+	for _, rel := range []string{manifest.FileName, filepath.Dir(ReportPath)} {
+		path := filepath.Join(projectDir, rel)
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		report.Removed = append(report.Removed, rel)
+		if !dryRun {
+			if err := os.RemoveAll(path); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,60 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/FACorreiaa/goforge/internal/manifest"
+)
+
+func TestEject(t *testing.T) {
+	projectDir := generateProject(t, Options{Jobs: true})
+	routesPath := filepath.Join(projectDir, "internal", "server", "routes.go")
+	os.WriteFile(filepath.Join(projectDir, "Makefile"+backupSuffix), []byte("old\n"), 0644)
+
+	report, err := Eject(projectDir, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(manifest.Path(projectDir)); err != nil {
+		t.Error("dry run removed the manifest")
+	}
+	if data, _ := os.ReadFile(routesPath); !strings.Contains(string(data), modulesMarker) {
+		t.Error("dry run stripped markers")
+	}
+
+	report, err = Eject(projectDir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, rel := range []string{manifest.FileName, ".goforge"} {
+		if !containsString(report.Removed, rel) {
+			t.Errorf("Removed = %v, missing %s", report.Removed, rel)
+		}
+		if _, err := os.Stat(filepath.Join(projectDir, rel)); !os.IsNotExist(err) {
+			t.Errorf("%s still exists", rel)
+		}
+	}
+	if report.Markers["internal/server/routes.go"] != 2 || report.Markers["internal/jobs/registry.go"] != 1 {
+		t.Errorf("Markers = %v", report.Markers)
+	}
+	data, _ := os.ReadFile(routesPath)
+	if strings.Contains(string(data), "goforge:") {
+		t.Error("routes.go still has goforge markers")
+	}
+	if !strings.Contains(string(data), "func (s *Server) Routes() []router.Route {") {
+		t.Error("eject changed more than the markers")
+	}
+	if len(report.Leftovers) != 1 || report.Leftovers[0] != "Makefile"+backupSuffix {
+		t.Errorf("Leftovers = %v", report.Leftovers)
+	}
+	if len(report.References) == 0 {
+		t.Error("no references to goforge commands reported")
+	}
+
+	if _, err := Eject(projectDir, false); err == nil {
+		t.Error("Eject() of an ejected project succeeded")
+	}
+}
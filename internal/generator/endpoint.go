@@ -0,0 +1,236 @@
+package generator
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// endpointsMarker is where `add endpoint` registers routes in the /api group
+	endpointsMarker = "// goforge:endpoints"
+	// openAPIPathsMarker and openAPISchemasMarker are where it documents them
+	openAPIPathsMarker   = "# goforge:paths"
+	openAPISchemasMarker = "# goforge:schemas"
+)
+
+// endpointParam is a path parameter such as {id}
+type endpointParam struct {
+	Name  string // as written in the path
+	Field string // DTO field
+	Var   string // handler variable
+}
+
+// endpoint describes the route `add endpoint` generates
+type endpoint struct {
+	Op          string // Go name: handle<Op>, <Op>Request, <Op>Response
+	File        string // api_<op> in internal/server
+	OperationID string
+	RouteName   string // api.get-users-by-id, in the route registry
+	Method      string // GET
+	MethodLower string // get
+	ChiMethod   string // Get
+	Path        string // /api/users/{id}
+	RoutePath   string // /users/{id}, relative to the /api group
+	SamplePath  string // /api/users/1
+	Params      []endpointParam
+	HasBody     bool
+	NoContent   bool
+	Status      string // http.StatusCreated
+	StatusCode  int
+	StatusText  string
+}
+
+// parseEndpoint validates the method and path given to `add endpoint`.
+// Paths are relative to /api, with or without the prefix.
+func parseEndpoint(method, path string) (endpoint, error) {
+	e := endpoint{Method: strings.ToUpper(method)}
+	switch e.Method {
+	case http.MethodGet, http.MethodDelete:
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		e.HasBody = true
+	default:
+		return e, fmt.Errorf("unsupported method %q: use GET, POST, PUT, PATCH or DELETE", method)
+	}
+	e.MethodLower = strings.ToLower(e.Method)
+	e.ChiMethod = e.Method[:1] + e.MethodLower[1:]
+
+	rel := strings.Trim(strings.TrimPrefix(strings.TrimPrefix(path, "/"), "api/"), "/")
+	if rel == "" || rel == "api" {
+		return e, fmt.Errorf("invalid path %q: expected something like /api/users/{id}", path)
+	}
+
+	words := []string{e.MethodLower}
+	var samples []string
+	for _, segment := range strings.Split(rel, "/") {
+		if name, ok := strings.CutPrefix(segment, "{"); ok && strings.HasSuffix(name, "}") {
+			name = strings.TrimSuffix(name, "}")
+			n, err := ParseName(name)
+			if err != nil {
+				return e, fmt.Errorf("invalid path parameter %q: %w", segment, err)
+			}
+			e.Params = append(e.Params, endpointParam{Name: name, Field: n.Pascal(), Var: n.Camel()})
+			words = append(words, "by")
+			words = append(words, n.Words...)
+			samples = append(samples, "1")
+			continue
+		}
+		n, err := ParseName(segment)
+		if err != nil {
+			return e, fmt.Errorf("invalid path segment %q: %w", segment, err)
+		}
+		words = append(words, n.Words...)
+		samples = append(samples, segment)
+	}
+
+	op := Name{Words: words}
+	e.Op = op.Pascal()
+	e.OperationID = op.Camel()
+	e.RouteName = "api." + op.Kebab()
+	e.File = "api_" + op.Snake()
+	e.RoutePath = "/" + rel
+	e.Path = "/api" + e.RoutePath
+	e.SamplePath = "/api/" + strings.Join(samples, "/")
+
+	switch e.Method {
+	case http.MethodPost:
+		e.Status, e.StatusCode = "http.StatusCreated", http.StatusCreated
+	case http.MethodDelete:
+		e.Status, e.StatusCode, e.NoContent = "http.StatusNoContent", http.StatusNoContent, true
+	default:
+		e.Status, e.StatusCode = "http.StatusOK", http.StatusOK
+	}
+	e.StatusText = http.StatusText(e.StatusCode)
+	return e, nil
+}
+
+// AddEndpoint adds a JSON API route to the /api group of a generated project:
+// handler, request/response DTOs with validation, route registration and a
+// table-driven test. When the project has api/openapi.yaml (--openapi) the
+// operation and its schemas are added too, so the spec drift test keeps
+// passing. It returns the created and modified files, relative to projectDir.
+func AddEndpoint(projectDir, method, path string) ([]string, error) {
+	e, err := parseEndpoint(method, path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := projectModule(projectDir); err != nil {
+		return nil, err
+	}
+	mf, err := requireManifest(projectDir)
+	if err != nil {
+		return nil, err
+	}
+
+	routesRel := filepath.Join("internal", "server", "routes.go")
+	routes, err := os.ReadFile(filepath.Join(projectDir, routesRel))
+	if err != nil {
+		return nil, fmt.Errorf("%s has no %s: is it a GoForge project?", projectDir, routesRel)
+	}
+	if !strings.Contains(string(routes), endpointsMarker) {
+		return nil, fmt.Errorf("%s has no %q marker: add it to the route registry in Server.Routes", routesRel, endpointsMarker)
+	}
+	if strings.Contains(string(routes), "s.handle"+e.Op+"}") || strings.Contains(string(routes), "s.handle"+e.Op+")") {
+		return nil, fmt.Errorf("endpoint %s %s already exists (handle%s)", e.Method, e.Path, e.Op)
+	}
+
+	files := []struct{ path, snippet string }{
+		{filepath.Join("internal", "server", e.File+".go"), "endpoint.go.tmpl"},
+		{filepath.Join("internal", "server", e.File+"_test.go"), "endpoint_test.go.tmpl"},
+	}
+	for _, f := range files {
+		if _, err := os.Stat(filepath.Join(projectDir, f.path)); err == nil {
+			return nil, fmt.Errorf("%s already exists", f.path)
+		}
+	}
+
+	var changed []string
+	helpersRel := filepath.Join("internal", "server", "apijson.go")
+	if _, err := os.Stat(filepath.Join(projectDir, helpersRel)); os.IsNotExist(err) {
+		files = append(files, struct{ path, snippet string }{helpersRel, "apijson.go.tmpl"})
+	}
+	for _, f := range files {
+		content, err := renderSnippet(f.snippet, e)
+		if err != nil {
+			return nil, err
+		}
+		if err := writeNewFile(filepath.Join(projectDir, f.path), content); err != nil {
+			return nil, err
+		}
+		changed = append(changed, f.path)
+	}
+	generated := changed
+
+	route := fmt.Sprintf("{Name: %q, Method: http.Method%s, Path: %q, Handler: s.handle%s},", e.RouteName, e.ChiMethod, e.Path, e.Op)
+	if !strings.Contains(string(routes), "[]router.Route{") {
+		// Projects generated before the route registry register in the /api group
+		route = fmt.Sprintf("r.%s(%q, s.handle%s)", e.ChiMethod, e.RoutePath, e.Op)
+	}
+	if err := insertAtMarker(filepath.Join(projectDir, routesRel), endpointsMarker, route); err != nil {
+		return nil, err
+	}
+	changed = append(changed, routesRel)
+
+	specRel := filepath.Join("api", "openapi.yaml")
+	if _, err := os.Stat(filepath.Join(projectDir, specRel)); err == nil {
+		if err := documentEndpoint(filepath.Join(projectDir, specRel), e); err != nil {
+			return nil, err
+		}
+		changed = append(changed, specRel)
+	}
+	if err := recordChanges(projectDir, mf, generated); err != nil {
+		return nil, err
+	}
+	return changed, nil
+}
+
+// documentEndpoint adds the operation and its schemas to an OpenAPI spec,
+// under the existing path item when another method already uses the path
+func documentEndpoint(specPath string, e endpoint) error {
+	data, err := os.ReadFile(specPath)
+	if err != nil {
+		return err
+	}
+	spec := string(data)
+	for _, marker := range []string{openAPIPathsMarker, openAPISchemasMarker} {
+		if !strings.Contains(spec, marker) {
+			return fmt.Errorf("%s has no %q marker", specPath, marker)
+		}
+	}
+
+	operation, err := renderSnippet("openapi_operation.yaml.tmpl", e)
+	if err != nil {
+		return err
+	}
+	pathKey := "\n  " + e.Path + ":\n"
+	if idx := strings.Index(spec, pathKey); idx >= 0 {
+		at := idx + len(pathKey)
+		spec = spec[:at] + indentLines(strings.TrimSpace(string(operation)), "    ") + spec[at:]
+		if err := os.WriteFile(specPath, []byte(spec), 0644); err != nil {
+			return err
+		}
+	} else if err := insertAtMarker(specPath, openAPIPathsMarker, e.Path+":\n"+indentLines(strings.TrimSpace(string(operation)), "  ")); err != nil {
+		return err
+	}
+
+	snippets := []string{"openapi_schemas.yaml.tmpl"}
+	if e.HasBody && !strings.Contains(spec, "\n    ValidationError:\n") {
+		snippets = append(snippets, "openapi_errors.yaml.tmpl")
+	}
+	var schemas []string
+	for _, name := range snippets {
+		out, err := renderSnippet(name, e)
+		if err != nil {
+			return err
+		}
+		if text := strings.TrimSpace(string(out)); text != "" {
+			schemas = append(schemas, text)
+		}
+	}
+	if len(schemas) == 0 {
+		return nil
+	}
+	return insertAtMarker(specPath, openAPISchemasMarker, strings.Join(schemas, "\n"))
+}
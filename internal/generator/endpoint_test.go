@@ -0,0 +1,100 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseEndpoint(t *testing.T) {
+	tests := []struct {
+		method, path string
+		op, route    string
+		status       string
+		hasBody      bool
+	}{
+		{"POST", "/api/users", "PostUsers", "/users", "http.StatusCreated", true},
+		{"get", "/users/{id}", "GetUsersById", "/users/{id}", "http.StatusOK", false},
+		{"DELETE", "api/users/{userId}/", "DeleteUsersByUserId", "/users/{userId}", "http.StatusNoContent", false},
+		{"PATCH", "/api/team-members/{id}", "PatchTeamMembersById", "/team-members/{id}", "http.StatusOK", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			e, err := parseEndpoint(tt.method, tt.path)
+			if err != nil {
+				t.Fatalf("parseEndpoint() error: %v", err)
+			}
+			if e.Op != tt.op || e.RoutePath != tt.route || e.Status != tt.status || e.HasBody != tt.hasBody {
+				t.Errorf("parseEndpoint() = %s %s %s body=%v, want %s %s %s body=%v",
+					e.Op, e.RoutePath, e.Status, e.HasBody, tt.op, tt.route, tt.status, tt.hasBody)
+			}
+		})
+	}
+
+	for _, bad := range [][2]string{{"HEAD", "/api/x"}, {"GET", "/api"}, {"GET", "/api/users/{1d}"}, {"GET", "/api/a.b"}} {
+		if _, err := parseEndpoint(bad[0], bad[1]); err == nil {
+			t.Errorf("parseEndpoint(%q, %q) succeeded, want an error", bad[0], bad[1])
+		}
+	}
+}
+
+func TestAddEndpoint(t *testing.T) {
+	projectDir := generateProject(t, Options{OpenAPI: true})
+
+	if _, err := AddEndpoint(projectDir, "POST", "/api/users"); err != nil {
+		t.Fatalf("AddEndpoint(POST) error: %v", err)
+	}
+	if _, err := AddEndpoint(projectDir, "GET", "/api/users"); err != nil {
+		t.Fatalf("AddEndpoint(GET) error: %v", err)
+	}
+
+	assertFilesExist(t, projectDir,
+		"internal/server/apijson.go",
+		"internal/server/api_post_users.go",
+		"internal/server/api_post_users_test.go",
+		"internal/server/api_get_users.go",
+	)
+
+	handler := readProjectFile(t, projectDir, "internal/server/api_post_users.go")
+	for _, want := range []string{"type PostUsersRequest struct", "func (req PostUsersRequest) Validate()", "http.StatusUnprocessableEntity"} {
+		if !strings.Contains(handler, want) {
+			t.Errorf("handler missing %s", want)
+		}
+	}
+
+	routes := readProjectFile(t, projectDir, "internal/server/routes.go")
+	want := `{Name: "api.post-users", Method: http.MethodPost, Path: "/api/users", Handler: s.handlePostUsers},` + "\n\t\t" +
+		`{Name: "api.get-users", Method: http.MethodGet, Path: "/api/users", Handler: s.handleGetUsers},` + "\n\t\t" + endpointsMarker
+	if !strings.Contains(routes, want) {
+		t.Errorf("routes not added to the registry:\n%s", routes)
+	}
+
+	spec := readProjectFile(t, projectDir, "api/openapi.yaml")
+	if strings.Count(spec, "\n  /api/users:\n") != 1 {
+		t.Error("openapi.yaml should document /api/users once, with both methods")
+	}
+	for _, want := range []string{"operationId: postUsers", "operationId: getUsers", "    PostUsersRequest:", "    ValidationError:"} {
+		if !strings.Contains(spec, want) {
+			t.Errorf("openapi.yaml missing %s", want)
+		}
+	}
+
+	if _, err := AddEndpoint(projectDir, "POST", "/users"); err == nil {
+		t.Error("AddEndpoint() added a duplicate route")
+	}
+}
+
+func TestAddEndpointWithoutOpenAPI(t *testing.T) {
+	projectDir := generateProject(t, Options{})
+
+	files, err := AddEndpoint(projectDir, "DELETE", "/api/users/{id}")
+	if err != nil {
+		t.Fatalf("AddEndpoint() error: %v", err)
+	}
+	for _, f := range files {
+		if strings.HasPrefix(f, "api/") {
+			t.Errorf("AddEndpoint() touched %s in a project without --openapi", f)
+		}
+	}
+	assertFilesMissing(t, projectDir, "api")
+}
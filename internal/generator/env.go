@@ -0,0 +1,417 @@
+package generator
+
+import (
+	"bufio"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// externalEnv are variables env check leaves alone: the app reads them but
+// the platform it runs on sets them, or a tool the scaffold runs reads them
+var externalEnv = map[string]bool{
+	"LISTEN_FDS": true, "LISTEN_PID": true, // systemd socket activation
+	"FLY_APP_NAME": true,
+	"CI":           true,
+	// Litestream's S3 credentials
+	"LITESTREAM_ACCESS_KEY_ID": true, "LITESTREAM_SECRET_ACCESS_KEY": true,
+}
+
+var (
+	// envRefRe finds $NAME, ${NAME} and $(NAME) in the Makefile, Dockerfile,
+	// compose files and scripts
+	envRefRe = regexp.MustCompile(`\$[({]?([A-Z][A-Z0-9_]*)`)
+	// flyEnvRe is a KEY = "value" line in the [env] section of fly.toml
+	flyEnvRe = regexp.MustCompile(`^\s*([A-Z][A-Z0-9_]*)\s*=`)
+	// composeEnvRe is an entry of a compose environment block, as a list
+	// (- KEY=value) or a map (KEY: value)
+	composeEnvRe = regexp.MustCompile(`^\s+(?:-\s*)?([A-Z][A-Z0-9_]*)(?:=|:)`)
+)
+
+// envRefExts are the files other than Go code that may read a variable
+var envRefExts = map[string]bool{".yml": true, ".yaml": true, ".toml": true, ".sh": true, ".service": true, ".ini": true, ".conf": true}
+
+// EnvFinding is a variable `goforge env check` reports, with a suggested fix
+type EnvFinding struct {
+	Name string
+	// File is where the variable is read or set, relative to the project,
+	// with :line
+	File string
+	Fix  string
+}
+
+// EnvReport is what `goforge env check` found
+type EnvReport struct {
+	// Read is the number of variables the Go code reads
+	Read int
+	// Missing are read by the Go code but set by neither .env.example nor
+	// an APP_ENV profile in internal/config/env
+	Missing []EnvFinding
+	// Orphaned are set in .env.example, a profile or a deployment manifest
+	// (compose files, fly.toml, the systemd environment file) but read by
+	// nothing: not the Go code, nor the Makefile, Dockerfile, compose files
+	// or scripts
+	Orphaned []EnvFinding
+}
+
+// envRead is where the Go code first reads a variable
+type envRead struct {
+	name, file string
+	// fallback is the default a helper such as getEnv is given, when it
+	// is a literal
+	fallback    string
+	hasFallback bool
+}
+
+// CheckEnv cross-checks the environment variables a project's Go code reads,
+// through os.Getenv, os.LookupEnv or a helper that passes its argument on to
+// them (config.getEnv), with the ones .env.example, the APP_ENV profiles and
+// the deployment manifests set
+func CheckEnv(projectDir string) (*EnvReport, error) {
+	if _, err := projectModule(projectDir); err != nil {
+		return nil, err
+	}
+	reads, err := readEnvReads(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := readEnvSettings(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	referenced, err := readEnvRefs(projectDir)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &EnvReport{Read: len(reads)}
+	read := map[string]bool{}
+	for _, r := range reads {
+		read[r.name] = true
+	}
+	documented := map[string]bool{}
+	for _, s := range settings {
+		if s.documents {
+			documented[s.name] = true
+		}
+	}
+
+	for _, r := range reads {
+		if documented[r.name] || externalEnv[r.name] {
+			continue
+		}
+		line := r.name + "="
+		if r.hasFallback {
+			line = "# " + r.name + "=" + r.fallback
+		}
+		report.Missing = append(report.Missing, EnvFinding{
+			Name: r.name,
+			File: r.file,
+			Fix:  fmt.Sprintf("add %q to .env.example, under a comment saying what it sets", line),
+		})
+	}
+
+	for _, s := range settings {
+		if read[s.name] || referenced[s.name] || externalEnv[s.name] {
+			continue
+		}
+		fix := "remove it, or read it in the code"
+		if match := closestEnvName(s.name, read); match != "" {
+			fix = fmt.Sprintf("the code reads %s: fix the spelling", match)
+		}
+		report.Orphaned = append(report.Orphaned, EnvFinding{Name: s.name, File: s.file, Fix: fix})
+	}
+	return report, nil
+}
+
+// readEnvReads lists the variables the non-test Go code of a project reads
+// with a literal name, where each is first read, in file order
+func readEnvReads(projectDir string) ([]envRead, error) {
+	var files []goFile
+	err := filepath.WalkDir(projectDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != projectDir && renameSkipDirs[d.Name()] {
+			return filepath.SkipDir
+		}
+		parsed, err := parseGoFiles(path)
+		files = append(files, parsed...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The argument position of each function that reads a variable: os's,
+	// then, until no more turn up, helpers forwarding a parameter to one
+	readers := map[string]int{"os.Getenv": 0, "os.LookupEnv": 0}
+	for grown := true; grown; {
+		grown = false
+		for _, f := range files {
+			for _, decl := range f.file.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || fn.Recv != nil || fn.Body == nil {
+					continue
+				}
+				if _, known := readers[fn.Name.Name]; known {
+					continue
+				}
+				if param, ok := forwardedEnvParam(fn, readers); ok {
+					readers[fn.Name.Name] = param
+					grown = true
+				}
+			}
+		}
+	}
+
+	var reads []envRead
+	seen := map[string]bool{}
+	for _, f := range files {
+		ast.Inspect(f.file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			arg, ok := readers[callName(call)]
+			if !ok || arg >= len(call.Args) {
+				return true
+			}
+			name, ok := literalValue(call.Args[arg])
+			if !ok || seen[name] {
+				return true
+			}
+			seen[name] = true
+			rel, _ := filepath.Rel(projectDir, f.path)
+			r := envRead{name: name, file: fmt.Sprintf("%s:%d", filepath.ToSlash(rel), f.fset.Position(call.Pos()).Line)}
+			if arg+1 < len(call.Args) && !strings.HasPrefix(callName(call), "os.") {
+				r.fallback, r.hasFallback = literalValue(call.Args[arg+1])
+			}
+			reads = append(reads, r)
+			return true
+		})
+	}
+	return reads, nil
+}
+
+// literalValue is the value of a string or number literal
+func literalValue(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok {
+		return "", false
+	}
+	if lit.Kind == token.STRING {
+		value, err := strconv.Unquote(lit.Value)
+		return value, err == nil
+	}
+	return lit.Value, lit.Kind == token.INT || lit.Kind == token.FLOAT
+}
+
+// forwardedEnvParam reports which parameter of fn it passes to a reader
+func forwardedEnvParam(fn *ast.FuncDecl, readers map[string]int) (int, bool) {
+	params := map[string]int{}
+	i := 0
+	for _, field := range fn.Type.Params.List {
+		for _, name := range field.Names {
+			params[name.Name] = i
+			i++
+		}
+		if len(field.Names) == 0 {
+			i++
+		}
+	}
+	found, param := false, 0
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || found {
+			return !found
+		}
+		if arg, ok := readers[callName(call)]; ok && arg < len(call.Args) {
+			if id, ok := call.Args[arg].(*ast.Ident); ok {
+				param, found = params[id.Name]
+			}
+		}
+		return !found
+	})
+	return param, found
+}
+
+// callName names the function a call invokes: os.Getenv for the os
+// package, the bare name otherwise (getEnv, config.GetEnv)
+func callName(call *ast.CallExpr) string {
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		return fun.Name
+	case *ast.SelectorExpr:
+		if pkg, ok := fun.X.(*ast.Ident); ok && pkg.Name == "os" {
+			return "os." + fun.Sel.Name
+		}
+		return fun.Sel.Name
+	}
+	return ""
+}
+
+// envSetting is a variable a project file sets
+type envSetting struct {
+	name, file string
+	// documents is true for .env.example and the profiles, which count as
+	// documenting the variable for the code that reads it
+	documents bool
+}
+
+// readEnvSettings lists the variables .env.example, the APP_ENV profiles and
+// the deployment manifests set, in that order. Missing files set nothing.
+func readEnvSettings(projectDir string) ([]envSetting, error) {
+	var settings []envSetting
+	scan := func(rel string, documents bool, match func(line string) string) error {
+		f, err := os.Open(filepath.Join(projectDir, rel))
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		scanner := bufio.NewScanner(f)
+		for n := 1; scanner.Scan(); n++ {
+			if name := match(scanner.Text()); name != "" {
+				settings = append(settings, envSetting{name: name, file: fmt.Sprintf("%s:%d", filepath.ToSlash(rel), n), documents: documents})
+			}
+		}
+		return scanner.Err()
+	}
+	envFile := func(line string) string {
+		if m := envLineRe.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			return m[2]
+		}
+		return ""
+	}
+
+	profiles, _ := filepath.Glob(filepath.Join(projectDir, "internal", "config", "env", "*.env"))
+	files := []string{".env.example"}
+	for _, path := range profiles {
+		rel, _ := filepath.Rel(projectDir, path)
+		files = append(files, rel)
+	}
+	for _, rel := range files {
+		if err := scan(rel, true, envFile); err != nil {
+			return nil, err
+		}
+	}
+	if err := scan(filepath.Join("deploy", "systemd", "app.env.example"), false, envFile); err != nil {
+		return nil, err
+	}
+
+	// fly.toml: the [env] table
+	section := ""
+	if err := scan("fly.toml", false, func(line string) string {
+		if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, "[") {
+			section = trimmed
+		} else if m := flyEnvRe.FindStringSubmatch(line); m != nil && section == "[env]" {
+			return m[1]
+		}
+		return ""
+	}); err != nil {
+		return nil, err
+	}
+
+	// Compose files: the environment block of the app service
+	composeFiles, _ := filepath.Glob(filepath.Join(projectDir, "docker-compose*.yml"))
+	for _, path := range composeFiles {
+		rel, _ := filepath.Rel(projectDir, path)
+		service, inEnv := "", false
+		if err := scan(rel, false, func(line string) string {
+			trimmed := strings.TrimSpace(line)
+			indent := len(line) - len(strings.TrimLeft(line, " "))
+			switch {
+			case trimmed == "" || strings.HasPrefix(trimmed, "#"):
+			case indent == 2 && strings.HasSuffix(trimmed, ":"):
+				service, inEnv = strings.TrimSuffix(trimmed, ":"), false
+			case indent < 2:
+				service, inEnv = "", false
+			case indent == 4:
+				inEnv = trimmed == "environment:"
+			case inEnv && service == "app":
+				if m := composeEnvRe.FindStringSubmatch(line); m != nil {
+					return m[1]
+				}
+			}
+			return ""
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return settings, nil
+}
+
+// readEnvRefs collects the variables the Makefile, Dockerfiles, compose
+// files, configuration and scripts of a project expand
+func readEnvRefs(projectDir string) (map[string]bool, error) {
+	refs := map[string]bool{}
+	err := filepath.WalkDir(projectDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if path != projectDir && renameSkipDirs[name] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if name != "Makefile" && !strings.HasPrefix(name, "Dockerfile") && name != "Caddyfile" && !envRefExts[filepath.Ext(name)] {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, m := range envRefRe.FindAllStringSubmatch(string(data), -1) {
+			refs[m[1]] = true
+		}
+		return nil
+	})
+	return refs, err
+}
+
+// closestEnvName is the variable in names within two edits of name, the
+// likely intended spelling, or "" when there is none
+func closestEnvName(name string, names map[string]bool) string {
+	best, bestDistance := "", 3
+	for candidate := range names {
+		if d := editDistance(name, candidate); d < bestDistance || d == bestDistance && candidate < best {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
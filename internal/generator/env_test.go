@@ -0,0 +1,77 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckEnvClean(t *testing.T) {
+	for _, opts := range []Options{
+		{},
+		{IncludeDB: true, ExampleResource: ExampleNote, Search: SearchMeilisearch, GeoIP: true, Errors: ErrorsSentry, Pprof: true, Jobs: true, DBReplicas: true, Vite: true, DeployProvider: DeploySystemd},
+		{IncludeDB: true, SingleBinary: true, Litestream: true},
+		{IncludeDB: true, SingleBinary: true, SQLiteReplication: SQLiteReplicationLiteFS},
+		{IncludeDB: true, Factories: true},
+		{Database: DatabaseMySQL, Jobs: true, StatusPage: true},
+		{Database: DatabaseSQLite},
+	} {
+		projectDir := generateProject(t, opts)
+		assertFilesExist(t, projectDir, ".github/workflows/env.yml")
+		report, err := CheckEnv(projectDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if report.Read == 0 {
+			t.Errorf("%+v: no variables read", opts)
+		}
+		for _, f := range append(report.Missing, report.Orphaned...) {
+			t.Errorf("%+v: %s (%s): %s", opts, f.Name, f.File, f.Fix)
+		}
+	}
+}
+
+func TestCheckEnvFindings(t *testing.T) {
+	projectDir := generateProject(t, Options{IncludeDB: true})
+	write := func(rel, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(projectDir, rel), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("internal/config/mail.go", `package config
+
+import "os"
+
+// MailFrom is the sender of outgoing mail
+func MailFrom() string { return getEnv("MAIL_FROM", "app@example.com") }
+
+func smtpHost() string { return os.Getenv("SMTP_HOST") }
+`)
+	env := readProjectFile(t, projectDir, ".env.example")
+	write(".env.example", env+"\n# Outgoing mail\nSMTP_HOTS=localhost\nLEGACY_FLAG=true\n# Read by make\nMIGRATE_DIR=db\n")
+	makefile := readProjectFile(t, projectDir, "Makefile")
+	write("Makefile", makefile+"\nmigrate-dir:\n\t@echo $(MIGRATE_DIR)\n")
+
+	report, err := CheckEnv(projectDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, f := range report.Missing {
+		got = append(got, "missing "+f.Name+" "+f.File+": "+f.Fix)
+	}
+	for _, f := range report.Orphaned {
+		got = append(got, "orphaned "+f.Name+": "+f.Fix)
+	}
+	want := []string{
+		`missing MAIL_FROM internal/config/mail.go:6: add "# MAIL_FROM=app@example.com" to .env.example, under a comment saying what it sets`,
+		`missing SMTP_HOST internal/config/mail.go:8: add "SMTP_HOST=" to .env.example, under a comment saying what it sets`,
+		`orphaned SMTP_HOTS: the code reads SMTP_HOST: fix the spelling`,
+		`orphaned LEGACY_FLAG: remove it, or read it in the code`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("findings:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
@@ -0,0 +1,71 @@
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/FACorreiaa/goforge/internal/manifest"
+)
+
+// exampleSchemas are the tables behind the --example-resource CRUDs, in the
+// CREATE TABLE form `goforge generate model` reads
+var exampleSchemas = map[string]string{
+	ExampleNote: `CREATE TABLE IF NOT EXISTS notes (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    title TEXT NOT NULL,
+    body TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+`,
+	ExampleTodo: `CREATE TABLE IF NOT EXISTS todos (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    title TEXT NOT NULL,
+    done BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_todos_done ON todos (done);
+`,
+}
+
+// writeExampleResource renders the migration and repository of the example
+// CRUD with the model snippets, so they match what `generate model` writes
+// for the same table. Checksums are added to files.
+func writeExampleResource(opts Options, files map[string]string) error {
+	schema, ok := exampleSchemas[opts.ExampleResource]
+	if !ok {
+		return nil
+	}
+	m, err := parseModelSQL(schema)
+	if err != nil {
+		return fmt.Errorf("example resource %s: %w", opts.ExampleResource, err)
+	}
+	d := newModelData(m)
+	d.Module = opts.ModulePath
+	d.Tx = true
+	d.Routed = opts.DBReplicas
+	typ, _ := ParseName(m.Type)
+
+	snippets := []struct{ path, snippet string }{
+		{filepath.Join("internal", "database", "migrations", "00001_create_"+m.Table+".sql"), "model_migration.sql.tmpl"},
+		{filepath.Join("internal", "repository", "repository.go"), "repository_base.go.tmpl"},
+		{filepath.Join("internal", "repository", typ.Snake()+".go"), "repository.go.tmpl"},
+	}
+	if opts.Factories {
+		snippets = append(snippets, factorySnippets(typ)...)
+	}
+	for _, f := range snippets {
+		content, err := renderSnippet(f.snippet, d)
+		if err != nil {
+			return err
+		}
+		if err := writeNewFile(filepath.Join(opts.ProjectName, f.path), content); err != nil {
+			return err
+		}
+		files[filepath.ToSlash(f.path)] = manifest.Checksum(content)
+		fmt.Fprintf(opts.Out, "  ✓ %s\n", filepath.ToSlash(f.path))
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package generator
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Fragments are pieces of template text shared by several templates (a
+// health check, a CI service), so the Makefile, Dockerfile and workflows
+// spell them the same way. The add commands' files live in snippets.
+//
+//go:embed fragments
+var fragmentFS embed.FS
+
+const (
+	// fragmentDefault is the variant used when no condition-named variant applies
+	fragmentDefault = "default"
+	// maxSnippetDepth bounds fragments including fragments, so a cycle fails
+	maxSnippetDepth = 8
+)
+
+// snippetRe matches a {{ snippet "name" }} directive
+var snippetRe = regexp.MustCompile(`\{\{\s*snippet\s+"([^"]+)"\s*\}\}`)
+
+// expandSnippets replaces each {{ snippet "name" }} directive with the
+// fragment it names. A directive alone on its line indents every line of the
+// fragment like the directive, and the line is dropped if the fragment is
+// empty; elsewhere the fragment is inserted as is. Fragments are expanded
+// before conditional blocks and placeholders, so they can use both, and can
+// include other fragments.
+func expandSnippets(content string, conds map[string]bool) (string, error) {
+	return expandSnippetsDepth(content, conds, 0)
+}
+
+func expandSnippetsDepth(content string, conds map[string]bool, depth int) (string, error) {
+	if !strings.Contains(content, "snippet") {
+		return content, nil
+	}
+	var b strings.Builder
+	for {
+		loc := snippetRe.FindStringSubmatchIndex(content)
+		if loc == nil {
+			b.WriteString(content)
+			return b.String(), nil
+		}
+		name := content[loc[2]:loc[3]]
+		if depth == maxSnippetDepth {
+			return "", fmt.Errorf("snippet %q nests too deeply (does it include itself?)", name)
+		}
+		fragment, err := resolveFragment(name, conds)
+		if err != nil {
+			return "", err
+		}
+		if fragment, err = expandSnippetsDepth(fragment, conds, depth+1); err != nil {
+			return "", err
+		}
+		fragment = strings.TrimSuffix(fragment, "\n")
+
+		lineStart := strings.LastIndex(content[:loc[0]], "\n") + 1
+		indent := content[lineStart:loc[0]]
+		lineEnd := len(content)
+		if i := strings.IndexByte(content[loc[1]:], '\n'); i >= 0 {
+			lineEnd = loc[1] + i
+		}
+		midLine := lineStart == 0 && b.Len() > 0 && !strings.HasSuffix(b.String(), "\n")
+		alone := !midLine && strings.TrimSpace(indent) == "" && strings.TrimSpace(content[loc[1]:lineEnd]) == ""
+
+		switch {
+		case alone && fragment == "":
+			b.WriteString(content[:lineStart])
+			if lineEnd < len(content) {
+				lineEnd++ // drop the newline too
+			}
+			content = content[lineEnd:]
+			continue
+		case alone:
+			fragment = strings.ReplaceAll(fragment, "\n", "\n"+indent)
+		}
+		b.WriteString(content[:loc[0]])
+		b.WriteString(fragment)
+		content = content[loc[1]:]
+	}
+}
+
+// resolveFragment reads fragments/<name>. A file is used as is; a directory
+// holds variants named after conditions (DB, SEARCH_MEILI...), and the first
+// whose condition is selected wins, then "default". A directory without a
+// matching variant resolves to nothing.
+func resolveFragment(name string, conds map[string]bool) (string, error) {
+	p := path.Join("fragments", name)
+	info, err := fs.Stat(fragmentFS, p)
+	if err != nil {
+		return "", fmt.Errorf("unknown snippet %q", name)
+	}
+	if !info.IsDir() {
+		data, err := fragmentFS.ReadFile(p)
+		return string(data), err
+	}
+
+	entries, err := fragmentFS.ReadDir(p)
+	if err != nil {
+		return "", err
+	}
+	var variants []string
+	for _, e := range entries {
+		variants = append(variants, e.Name())
+	}
+	sort.Strings(variants)
+	for _, v := range variants {
+		if conds[v] {
+			data, err := fragmentFS.ReadFile(path.Join(p, v))
+			return string(data), err
+		}
+	}
+	if containsString(variants, fragmentDefault) {
+		data, err := fragmentFS.ReadFile(path.Join(p, fragmentDefault))
+		return string(data), err
+	}
+	return "", nil
+}
@@ -0,0 +1,41 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExpandSnippets(t *testing.T) {
+	db := map[string]bool{"DB": true}
+	tests := []struct {
+		name, content string
+		conds         map[string]bool
+		want          string
+	}{
+		{"no directive", "plain\n", db, "plain\n"},
+		{"inline variant", `test: ["CMD-SHELL", "{{ snippet "db/health-check" }}"]` + "\n", db, `test: ["CMD-SHELL", "pg_isready -U postgres"]` + "\n"},
+		{"no matching variant drops the line", "a\n  {{ snippet \"db/health-check\" }}\nb\n", map[string]bool{}, "a\nb\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandSnippets(tt.content, tt.conds)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("expandSnippets() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	got, _ := expandSnippets("services:\n  {{ snippet \"ci/postgres-service\" }}\nenv:\n", db)
+	for _, want := range []string{"\n  postgres:\n    image: postgres:16-alpine\n", "\n      --health-cmd \"pg_isready -U postgres\"\n", "--health-retries 5\nenv:\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("indented fragment missing %q:\n%s", want, got)
+		}
+	}
+
+	if _, err := expandSnippets(`{{ snippet "no/such-fragment" }}`, db); err == nil {
+		t.Error("expandSnippets() accepted an unknown snippet")
+	}
+}
@@ -1,16 +1,19 @@
 package generator
 
 import (
-	"embed"
+	"errors"
 	"fmt"
+	"go/format"
+	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
-)
+	"time"
 
-//go:embed all:templates
-var templateFS embed.FS
+	"github.com/FACorreiaa/goforge/internal/i18n"
+	"github.com/FACorreiaa/goforge/internal/manifest"
+)
 
 const (
 	// Placeholder module used in the golden templates
@@ -20,16 +23,25 @@ const (
 	placeholderFrontendScripts = "<!-- FRONTEND_SCRIPTS -->"
 	placeholderSetupCommand    = "<!-- SETUP_COMMAND -->"
 	placeholderCiSetupCommand  = "<!-- CI_SETUP_COMMAND -->"
-	placeholderDevCommand      = "<!-- DEV_COMMAND -->"
-	placeholderCssWatchCmd     = "<!-- CSS_WATCH_COMMAND -->"
 	placeholderCssBuildCmd     = "<!-- CSS_BUILD_COMMAND -->"
 	placeholderAirBuildCmd     = "<!-- AIR_BUILD_CMD -->"
-	placeholderTailwindPlugin  = "<!-- TAILWIND_PLUGIN -->"
-	placeholderDaisyuiConfig   = "<!-- DAISYUI_CONFIG -->"
 	placeholderDockerSetupRun  = "<!-- DOCKER_SETUP_RUN -->"
 	placeholderDockerBuildCss  = "<!-- DOCKER_BUILD_CSS -->"
+	placeholderDockerInputCSS  = "<!-- DOCKER_INPUT_CSS -->"
+	placeholderTsconfigInclude = "<!-- TSCONFIG_INCLUDE -->"
+	placeholderProjectName     = "<!-- PROJECT_NAME -->"
+	placeholderGeneratedDate   = "<!-- GENERATED_DATE -->"
+	placeholderGoDirective     = "<!-- GO_DIRECTIVE -->"
+	placeholderGoVersion       = "<!-- GO_VERSION -->"
+	placeholderGoLangVersion   = "<!-- GO_LANG_VERSION -->"
+	placeholderBasePath        = "<!-- BASE_PATH -->"
+	placeholderAssetChecksums  = "<!-- ASSET_CHECKSUMS -->"
+	placeholderAssetMirror     = "<!-- ASSET_MIRROR -->"
 )
 
+// daisyUIVersion is the DaisyUI plugin release make setup downloads
+const daisyUIVersion = "5.0.43"
+
 // Frontend options
 const (
 	FrontendHTMX            = "htmx"
@@ -55,18 +67,246 @@ const (
 const (
 	DeployNone         = "none"
 	DeployHetznerCaddy = "hetzner-caddy"
+	DeploySystemd      = "systemd"
+)
+
+// Search backend options
+const (
+	SearchNone        = "none"
+	SearchPgTrgm      = "pg_trgm"
+	SearchMeilisearch = "meilisearch"
+	SearchBleve       = "bleve"
+)
+
+// Analytics provider options
+const (
+	AnalyticsNone      = "none"
+	AnalyticsPlausible = "plausible"
+	AnalyticsUmami     = "umami"
+)
+
+// Error reporting options
+const (
+	ErrorsNone      = "none"
+	ErrorsSentry    = "sentry"
+	ErrorsGlitchTip = "glitchtip"
+)
+
+// Load testing tool options
+const (
+	LoadTestNone   = "none"
+	LoadTestK6     = "k6"
+	LoadTestVegeta = "vegeta"
+)
+
+// Mock generator options
+const (
+	MocksNone    = "none"
+	MocksMockery = "mockery"
+	MocksGomock  = "gomock"
+)
+
+// Reverse proxy options
+const (
+	ProxyNone    = "none"
+	ProxyCaddy   = "caddy"
+	ProxyNginx   = "nginx"
+	ProxyTraefik = "traefik"
+)
+
+// Database backends: Postgres through pgx, MySQL or SQLite through
+// database/sql, or no database
+const (
+	DatabasePostgres = "postgres"
+	DatabaseMySQL    = "mysql"
+	DatabaseSQLite   = "sqlite"
+	DatabaseNone     = "none"
+)
+
+// SQLite replication options
+const (
+	SQLiteReplicationNone   = "none"
+	SQLiteReplicationLiteFS = "litefs"
+	SQLiteReplicationTurso  = "turso"
+)
+
+// Development database options: docker-compose, or a disposable Postgres
+// that make dev starts with testcontainers-go
+const (
+	DevDBCompose        = "compose"
+	DevDBTestcontainers = "testcontainers"
+)
+
+// Example resource options: the table, repository, handlers and pages the
+// scaffold ships as a starting point
+const (
+	ExampleUsers = "users"
+	ExampleNote  = "note"
+	ExampleTodo  = "todo"
+	ExampleNone  = "none"
+)
+
+// golangci-lint presets
+const (
+	LintStrict   = "strict"
+	LintStandard = "standard"
+	LintMinimal  = "minimal"
 )
 
 // Options for project generation
 type Options struct {
-	ProjectName    string
-	ModulePath     string
-	Frontend       string
-	CSSFramework   string
-	Theme          string
+	ProjectName  string
+	ModulePath   string
+	Frontend     string
+	CSSFramework string
+	Theme        string
+	// Database is the database backend. When empty it follows IncludeDB:
+	// Postgres (SQLite with SingleBinary), or none. prepareOptions sets it
+	// and sets IncludeDB to whether there is a database.
+	Database       string
 	IncludeDB      bool
+	DBReplicas     bool
 	IncludeHooks   bool
 	DeployProvider string
+	Vite           bool
+	TypeScript     bool
+	Preview        bool
+	SEO            bool
+	StaticExport   bool
+	Content        bool
+	Search         string
+	GeoIP          bool
+	Analytics      string
+	GDPR           bool
+	Errors         string
+	Pprof          bool
+	LoadTest       string
+	E2E            bool
+	OpenAPI        bool
+	Jobs           bool
+	SBOM           bool
+	Sign           bool
+	HTTPSDev       bool
+	Proxy          string
+	// EmbedAssets serves the embedded assets with precompressed gzip and
+	// brotli variants and ETags, and ships only the binary in the image
+	EmbedAssets bool
+	// ExampleResource is the sample domain the scaffold ships: the users
+	// migration (when empty), a note or todo CRUD, or none
+	ExampleResource string
+	// DataTable adds a data table page for the note or todo example, with
+	// server-side sorting, filtering, pagination and CSV export
+	DataTable bool
+	// ImportExport adds CSV import with per-row validation errors and
+	// streamed CSV/XLSX export for the note or todo example, with background
+	// exports that report their progress
+	ImportExport bool
+	// PDF adds server-side PDF generation with headless Chromium (chromedp),
+	// an example invoice document and a chromedp/headless-shell service in
+	// docker-compose
+	PDF bool
+	// Images adds image uploads resized to WebP and JPEG variants, served
+	// through signed URLs, with a Templ <picture> component
+	Images bool
+	// Notifications adds in-app notifications: a table and migration, a
+	// navbar bell updated over server-sent events, mark-as-read endpoints
+	// and hooks other features send through
+	Notifications bool
+	// Activity adds an activity stream: a polymorphic events table, a
+	// recording helper and a Templ timeline with infinite scroll
+	Activity bool
+	// Settings adds an account settings page (profile, password, theme,
+	// API tokens) on the users table, for the app's own sign-in to plug into
+	Settings bool
+	// Onboarding adds a multi-step HTMX wizard with a progress indicator,
+	// per-step validation and progress saved in the database
+	Onboarding bool
+	// Teams adds organizations, memberships with roles and invitations on
+	// the users table
+	Teams bool
+	// Billing adds Stripe subscriptions: a plans table, subscription
+	// lifecycle webhooks, feature gating by plan and a pricing page
+	Billing bool
+	// Demo adds a read-only demo mode with a banner, demo content for the
+	// example tables and a `make screenshots` target (rod)
+	Demo bool
+	// SingleBinary is the zero-services profile: SQLite (pure Go) instead
+	// of Postgres, migrations embedded and applied on start, and embedded
+	// assets, so go build yields the one artifact to deploy
+	SingleBinary bool
+	// Litestream adds a litestream.yml streaming the SQLite database to
+	// object storage, with make targets to run and restore it
+	Litestream bool
+	// SQLiteReplication distributes the SQLite database:
+	// LiteFS on Fly.io (with writes forwarded to the primary) or Turso
+	// through the libsql driver (none when empty)
+	SQLiteReplication string
+	// PostGIS runs Postgres with the PostGIS extension and adds a places
+	// table with a geography point column, a nearby query (ST_DWithin), a
+	// GeoJSON endpoint and a Leaflet map page
+	PostGIS bool
+	// FTS adds Postgres full-text search to the note or todo example: a
+	// tsvector column kept up to date by a trigger, a ranked and
+	// highlighted repository search and an HTMX live-search page
+	FTS bool
+	// AutoMigrate embeds the Goose migrations into the server, which
+	// applies the pending ones on start under a Postgres advisory lock
+	// (AUTO_MIGRATE=false leaves them to make db-up)
+	AutoMigrate bool
+	// DevDB is where make dev finds Postgres: docker-compose (the default)
+	// or a container cmd/devstack starts with testcontainers-go and removes
+	// on exit
+	DevDB string
+	// Factories adds pkg/factory, a test data builder per model (the
+	// example resource and every `generate model` output), and dbtest,
+	// which runs each test in a transaction rolled back at its end
+	Factories bool
+	// Mocks generates mocks of the interfaces at the server's boundaries
+	// (the database service and the example resource's store) with mockery
+	// or gomock, for the example handler tests and your own
+	Mocks string
+	// Fuzz adds fuzz tests for the input handling code (validation
+	// helpers, and the onboarding forms, CSV imports and blog posts when
+	// selected), make fuzz and a CI workflow running them briefly
+	Fuzz bool
+	// Snapshots adds golden-file tests of the Templ components and pages:
+	// internal/snapshot renders and normalizes them, make snapshots-update
+	// accepts a change
+	Snapshots bool
+	// StatusPage adds an internal /status page (build, uptime, database
+	// pool, job queue and the last migrations applied) that refreshes
+	// itself over HTMX polling
+	StatusPage bool
+	// Binaries are the cmd/ entrypoints to build, as returned by
+	// ParseBinaries (only the server when empty)
+	Binaries string
+	// BasePath is the subpath the app is served under, as /app (the site
+	// root when empty)
+	BasePath string
+	// AssetMirror is the default base URL frontend and Tailwind downloads go
+	// through, as returned by ParseAssetMirror (the upstream hosts when empty)
+	AssetMirror string
+	// Lint is the golangci-lint preset (strict when empty)
+	Lint string
+	// GoVersion is the Go release to target, 1.N or 1.N.P (the channel
+	// default when empty)
+	GoVersion string
+	// Channel is the template channel (stable when empty)
+	Channel string
+	// At is an older template bundle version of the channel to generate
+	// with, to reproduce a project's baseline (the current one when empty)
+	At string
+	// Lang is the language of the README quick start, one of
+	// i18n.Languages (English when empty)
+	Lang string
+	// Lean drops what a microservice does not need: the PWA assets, the
+	// landing page demo sections, README boilerplate and, unless an example
+	// resource is chosen, the sample migration
+	Lean bool
+	// Vars are the values of {{ var "name" }} directives, from --vars
+	Vars map[string]string
+	// Out receives the list of files written (stdout when nil)
+	Out io.Writer
 }
 
 // Generate creates a new project from the embedded templates (backward compatible)
@@ -83,56 +323,51 @@ func Generate(projectName string, newModule string) error {
 
 // GenerateWithOptions creates a new project with custom options
 func GenerateWithOptions(opts Options) error {
-	// Create the project directory
+	opts, err := prepareOptions(opts)
+	if err != nil {
+		return err
+	}
+	changesets, err := loadChangesets(changesetFS)
+	if err != nil {
+		return err
+	}
+	templates, bundleVersion, source, err := templatesFor(opts, changesets, goforgeReleases)
+	if err != nil {
+		return err
+	}
+	return generate(opts, templates, bundleVersion, source)
+}
+
+// generate writes the project opts describes from a template tree, recording
+// the tree's bundle version and source in the manifest
+func generate(opts Options, templates fs.FS, bundleVersion, source string) error {
+	// Create the project directory, or take an empty one
 	if err := os.MkdirAll(opts.ProjectName, 0755); err != nil {
 		return fmt.Errorf("failed to create project directory: %w", err)
 	}
+	if err := claimProjectDir(opts.ProjectName); err != nil {
+		return err
+	}
 
 	// Prepare replacements
 	replacements := getReplacements(opts)
 
-	// Walk through the embedded templates
-	return fs.WalkDir(templateFS, "templates", func(path string, d fs.DirEntry, err error) error {
+	// Checksums of the written files, recorded in the manifest
+	files := map[string]string{}
+	audit := newPlaceholderAudit(replacements)
+
+	// Walk through the templates
+	err := fs.WalkDir(templates, ".", func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
-
-		// Calculate the relative path (remove "templates/" prefix)
-		relPath := strings.TrimPrefix(path, "templates/")
-		if relPath == "" || relPath == "templates" {
+		relPath := path
+		if relPath == "." {
 			return nil // Skip replace root
 		}
 
-		// Skip database directory if IncludeDB is false
-		if !opts.IncludeDB && (strings.HasPrefix(relPath, "internal/database") ||
-			strings.HasPrefix(relPath, "docker-compose.yml") && false) {
-			// Check logic for docker-compose: if DB is optional, do we skip file?
-			// docker-compose usually provides DB. If no DB, we might want it for other things?
-			// Current plan says "Wrap db service in <!-- IF DB -->". So don't skip file.
-			// Only skip internal/database folder.
-			if d.IsDir() {
-				return fs.SkipDir
-			}
-			return nil
-		}
-
-		if !opts.IncludeDB && strings.HasPrefix(relPath, "internal/database") {
-			if d.IsDir() {
-				return fs.SkipDir
-			}
-			return nil
-		}
-
-		// Skip deploy directory if DeployProvider is none
-		if opts.DeployProvider != DeployHetznerCaddy && strings.HasPrefix(relPath, "deploy") {
-			if d.IsDir() {
-				return fs.SkipDir
-			}
-			return nil
-		}
-
-		// Skip .githooks directory if IncludeHooks is false
-		if !opts.IncludeHooks && strings.HasPrefix(relPath, ".githooks") {
+		// Skip files and directories belonging to features that are not selected
+		if skipPath(relPath, opts) {
 			if d.IsDir() {
 				return fs.SkipDir
 			}
@@ -148,25 +383,18 @@ func GenerateWithOptions(opts Options) error {
 		}
 
 		// Handle Files
-		data, err := templateFS.ReadFile(path)
+		data, err := fs.ReadFile(templates, path)
 		if err != nil {
 			return fmt.Errorf("failed to read template file %s: %w", path, err)
 		}
 
 		// Perform content replacement for text files
 		content := string(data)
-
 		if !isBinaryFile(path) {
-			// Process conditional blocks first
-			content = processConditionalBlocks(content, opts)
-
-			// Replace module path
-			content = strings.ReplaceAll(content, placeholderModule, opts.ModulePath)
-
-			// Replace all other placeholders
-			for k, v := range replacements {
-				content = strings.ReplaceAll(content, k, v)
+			if content, err = renderFile(path, content, opts, replacements); err != nil {
+				return &TemplateError{Path: path, Err: err}
 			}
+			audit.record(strings.TrimSuffix(relPath, ".tmpl"), string(data), content)
 		}
 
 		// Handle .tmpl extension (strip it from the target)
@@ -176,44 +404,759 @@ func GenerateWithOptions(opts Options) error {
 		if err := os.WriteFile(targetPath, []byte(content), 0644); err != nil {
 			return fmt.Errorf("failed to write file %s: %w", targetPath, err)
 		}
+		files[strings.TrimSuffix(relPath, ".tmpl")] = manifest.Checksum([]byte(content))
 
-		fmt.Printf("  ✓ %s\n", strings.TrimPrefix(targetPath, opts.ProjectName+"/"))
+		fmt.Fprintf(opts.Out, "  ✓ %s\n", strings.TrimPrefix(targetPath, opts.ProjectName+"/"))
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+	if err := audit.err(); err != nil {
+		return err
+	}
+	if unused := unusedVars(opts.Vars); len(unused) > 0 {
+		fmt.Fprintf(opts.Out, "  ⚠️  vars not used by any template: %s\n", strings.Join(unused, ", "))
+	}
+	if err := writeExampleResource(opts, files); err != nil {
+		return err
+	}
+	if err := writeCLIMain(opts, files); err != nil {
+		return err
+	}
+
+	if err := newManifest(opts, source, bundleVersion, files).Save(opts.ProjectName); err != nil {
+		return fmt.Errorf("failed to write %s: %w", manifest.FileName, err)
+	}
+	fmt.Fprintf(opts.Out, "  ✓ %s\n", manifest.FileName)
+
+	if len(opts.Vars) > 0 {
+		if err := writeProjectVars(opts.ProjectName, opts.Vars); err != nil {
+			return fmt.Errorf("failed to write %s: %w", VarsPath, err)
+		}
+		fmt.Fprintf(opts.Out, "  ✓ %s\n", filepath.ToSlash(VarsPath))
+	}
+
+	if err := writeReport(opts.ProjectName); err != nil {
+		return fmt.Errorf("failed to write %s: %w", ReportPath, err)
+	}
+	fmt.Fprintf(opts.Out, "  ✓ %s\n", filepath.ToSlash(ReportPath))
+	return nil
+}
+
+// TemplateError is a template that failed to render, by its path in the
+// template tree
+type TemplateError struct {
+	Path string
+	Err  error
+}
+
+func (e *TemplateError) Error() string {
+	return fmt.Sprintf("failed to render template %s: %v", e.Path, e.Err)
+}
+
+func (e *TemplateError) Unwrap() error { return e.Err }
+
+// claimProjectDir makes sure no other goforge run generates into dir: it
+// must be empty, and creating the manifest first, exclusively, fails for
+// every run but one when several start at once. The manifest is rewritten
+// once generation is done.
+func claimProjectDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	if len(entries) > 0 {
+		return fmt.Errorf("%s already exists and is not empty", dir)
+	}
+	f, err := os.OpenFile(manifest.Path(dir), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if errors.Is(err, fs.ErrExist) {
+		return fmt.Errorf("%s is being generated by another goforge run", dir)
+	}
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// prepareOptions rejects option combinations that cannot be generated and
+// fills in the defaults
+func prepareOptions(opts Options) (Options, error) {
+	if opts.SingleBinary && opts.Database != "" && opts.Database != DatabaseSQLite {
+		return opts, fmt.Errorf("the single binary runs on SQLite, not %s", opts.Database)
+	}
+	opts.Database = databaseOf(opts)
+	if !containsString([]string{DatabasePostgres, DatabaseMySQL, DatabaseSQLite, DatabaseNone}, opts.Database) {
+		return opts, fmt.Errorf("unknown database %q (use postgres, mysql, sqlite or none)", opts.Database)
+	}
+	opts.IncludeDB = opts.Database != DatabaseNone
+	if opts.SingleBinary {
+		opts.EmbedAssets = true
+	}
+	if opts.Litestream && !usesSQLite(opts) {
+		return opts, fmt.Errorf("litestream replicates a SQLite database")
+	}
+	if hasSQLiteReplication(opts) && !usesSQLite(opts) {
+		return opts, fmt.Errorf("SQLite replication %q needs the SQLite database", opts.SQLiteReplication)
+	}
+	if hasSQLiteReplication(opts) && opts.Litestream {
+		return opts, fmt.Errorf("SQLite replication %q keeps its own copies; drop litestream", opts.SQLiteReplication)
+	}
+	if opts.Search == SearchPgTrgm && !opts.IncludeDB {
+		return opts, fmt.Errorf("search backend %q requires the database", SearchPgTrgm)
+	}
+	if opts.DBReplicas && !opts.IncludeDB {
+		return opts, fmt.Errorf("read replicas and PgBouncer require the database")
+	}
+	if opts.PostGIS && !opts.IncludeDB {
+		return opts, fmt.Errorf("PostGIS requires the database")
+	}
+	if opts.DevDB == DevDBTestcontainers && !opts.IncludeDB {
+		return opts, fmt.Errorf("dev database %q requires the database", DevDBTestcontainers)
+	}
+	if opts.Factories && !opts.IncludeDB {
+		return opts, fmt.Errorf("test factories require the database")
+	}
+	if hasMocks(opts) && !opts.IncludeDB {
+		return opts, fmt.Errorf("mocks %q require the database", opts.Mocks)
+	}
+	if opts.AutoMigrate && !opts.IncludeDB {
+		return opts, fmt.Errorf("auto-migrate requires the database")
+	}
+	if opts.AutoMigrate && usesSQLite(opts) {
+		return opts, fmt.Errorf("SQLite projects already apply their migrations on start; drop auto-migrate")
+	}
+	if opts.AutoMigrate && opts.Database == DatabaseMySQL {
+		return opts, fmt.Errorf("auto-migrate takes a Postgres advisory lock; run make db-up with MySQL")
+	}
+	if hasExampleCRUD(opts) && !opts.IncludeDB {
+		return opts, fmt.Errorf("example resource %q requires the database", opts.ExampleResource)
+	}
+	if opts.DataTable && !hasExampleCRUD(opts) {
+		return opts, fmt.Errorf("the data table needs the note or todo example resource")
+	}
+	if opts.ImportExport && !hasExampleCRUD(opts) {
+		return opts, fmt.Errorf("import and export need the note or todo example resource")
+	}
+	if opts.FTS && !hasExampleCRUD(opts) {
+		return opts, fmt.Errorf("full-text search needs the note or todo example resource")
+	}
+	if opts.Notifications && !opts.IncludeDB {
+		return opts, fmt.Errorf("notifications require the database")
+	}
+	if opts.Activity && !opts.IncludeDB {
+		return opts, fmt.Errorf("the activity feed requires the database")
+	}
+	if opts.Onboarding && !opts.IncludeDB {
+		return opts, fmt.Errorf("the onboarding wizard requires the database")
+	}
+	if opts.ExampleResource == "" && opts.Lean {
+		opts.ExampleResource = ExampleNone
+	}
+	if opts.ExampleResource == "" {
+		opts.ExampleResource = ExampleUsers
+	}
+	if opts.Settings && (!opts.IncludeDB || opts.ExampleResource != ExampleUsers) {
+		return opts, fmt.Errorf("settings need the users table (the database with the users example resource)")
+	}
+	if opts.Teams && (!opts.IncludeDB || opts.ExampleResource != ExampleUsers) {
+		return opts, fmt.Errorf("teams need the users table (the database with the users example resource)")
+	}
+	if opts.Billing && (!opts.IncludeDB || opts.ExampleResource != ExampleUsers) {
+		return opts, fmt.Errorf("billing needs the users table (the database with the users example resource)")
+	}
+	if opts.IncludeDB && !usesPostgres(opts) {
+		if feature := postgresOnly(opts); feature != "" {
+			return opts, fmt.Errorf("%s needs Postgres, not %s", feature, databaseName(opts.Database))
+		}
+	}
+	if opts.Out == nil {
+		opts.Out = os.Stdout
+	}
+	if opts.Channel == "" {
+		opts.Channel = ChannelStable
+	}
+	if opts.Lint == "" {
+		opts.Lint = LintStrict
+	}
+	if opts.Lang == "" {
+		opts.Lang = i18n.English
+	}
+	if !containsString(i18n.Languages, opts.Lang) {
+		return opts, fmt.Errorf("unsupported language %q (use %s)", opts.Lang, strings.Join(i18n.Languages, ", "))
+	}
+	if opts.GoVersion != "" {
+		v, err := ParseGoVersion(opts.GoVersion)
+		if err != nil {
+			return opts, err
+		}
+		opts.GoVersion = v
+	}
+	opts.GoVersion = goVersion(opts)
+	basePath, err := ParseBasePath(opts.BasePath)
+	if err != nil {
+		return opts, err
+	}
+	opts.BasePath = basePath
+	if opts.AssetMirror, err = ParseAssetMirror(opts.AssetMirror); err != nil {
+		return opts, err
+	}
+	binaries, err := ParseBinaries(opts.Binaries)
+	if err != nil {
+		return opts, err
+	}
+	opts.Binaries = binaries
+	if err := checkGroups(opts); err != nil {
+		return opts, err
+	}
+	return opts, nil
+}
+
+// renderTemplate turns a text template into project content: snippets
+// first, then variables, conditional blocks, the module path and the other placeholders
+func renderTemplate(content string, opts Options, replacements map[string]string) (string, error) {
+	content, err := expandSnippets(content, conditions(opts))
+	if err != nil {
+		return "", err
+	}
+	if content, err = expandVars(content, opts.Vars); err != nil {
+		return "", err
+	}
+	content = processConditionalBlocks(content, opts)
+	content = replaceModulePath(content, placeholderModule, opts.ModulePath)
+	for k, v := range replacements {
+		content = strings.ReplaceAll(content, k, v)
+	}
+	return content, nil
+}
+
+// renderFile renders the template at templatePath. Go files are gofmt'ed:
+// the blocks an option set keeps change how struct fields and keys align.
+// Output that does not parse is returned as rendered, for bundle lint and
+// the compiler to report.
+func renderFile(templatePath, content string, opts Options, replacements map[string]string) (string, error) {
+	content, err := renderTemplate(content, opts, replacements)
+	if err != nil || !strings.HasSuffix(strings.TrimSuffix(templatePath, ".tmpl"), ".go") {
+		return content, err
+	}
+	if formatted, err := format.Source([]byte(content)); err == nil {
+		content = string(formatted)
+	}
+	return content, nil
+}
+
+// featurePaths maps template path prefixes to whether the feature that owns
+// them is selected. A path is skipped if any matching prefix is disabled.
+func featurePaths(opts Options) map[string]bool {
+	return map[string]bool{
+		"internal/database": hasDatabase(opts),
+		"deploy":            opts.DeployProvider == DeployHetznerCaddy || opts.DeployProvider == DeploySystemd || hasProxy(opts),
+		".githooks":         opts.IncludeHooks,
+
+		// PgBouncer and read/write pool routing
+		"pgbouncer":                     opts.DBReplicas,
+		"internal/database/replicas.go": opts.DBReplicas,
+
+		// Postgres (pgx), MySQL, or SQLite with embedded migrations, backed
+		// up by Litestream
+		"internal/database/database.go":          usesPostgres(opts),
+		"internal/database/tx.go":                usesPostgres(opts),
+		"internal/database/mysql.go":             opts.Database == DatabaseMySQL,
+		"internal/database/sqlite.go":            usesSQLite(opts),
+		"internal/database/migrations/README.md": usesSQLite(opts) || opts.AutoMigrate, // keeps the embedded directory from being empty
+		"internal/database/migrate.go":           opts.AutoMigrate,
+		"internal/database/dbtest":               opts.Factories,
+		"internal/database/retry.go":             usesPostgres(opts) || opts.Database == DatabaseMySQL || opts.SQLiteReplication == SQLiteReplicationTurso, // waits for a database over the network
+		"litestream.yml":                         opts.Litestream,
+
+		// Distributed SQLite: LiteFS (with write forwarding) or Turso, on Fly.io
+		"fly.toml":                      hasSQLiteReplication(opts),
+		"litefs.yml":                    opts.SQLiteReplication == SQLiteReplicationLiteFS,
+		"internal/database/litefs.go":   opts.SQLiteReplication == SQLiteReplicationLiteFS,
+		"internal/middleware/litefs.go": opts.SQLiteReplication == SQLiteReplicationLiteFS,
+
+		// Example resource (the note and todo tables and repositories are
+		// rendered from exampleSchemas)
+		"internal/database/migrations/00001_init.sql": opts.ExampleResource == ExampleUsers,
+		"internal/server/notes.go":                    opts.ExampleResource == ExampleNote,
+		"views/pages/notes.templ":                     opts.ExampleResource == ExampleNote,
+		"internal/server/todos.go":                    opts.ExampleResource == ExampleTodo,
+		"views/pages/todos.templ":                     opts.ExampleResource == ExampleTodo,
+		"internal/repository":                         opts.DataTable || opts.ImportExport || opts.FTS, // the CRUD repositories come from the model snippets
+		"internal/repository/datatable.go":            opts.DataTable,
+		"internal/server/datatable.go":                opts.DataTable,
+		"views/pages/datatable.templ":                 opts.DataTable,
+		"internal/repository/importexport.go":         opts.ImportExport,
+		"internal/importexport":                       opts.ImportExport,
+		"internal/server/importexport.go":             opts.ImportExport,
+		"views/pages/importexport.templ":              opts.ImportExport,
+		"internal/repository/fts.go":                  opts.FTS,
+		"internal/server/fts.go":                      opts.FTS,
+		"views/pages/fts.templ":                       opts.FTS,
+		"internal/database/migrations/00010_fts":      opts.FTS,
+
+		// PDF generation
+		"internal/pdf":               opts.PDF,
+		"internal/server/invoice.go": opts.PDF,
+		"views/documents":            opts.PDF,
+
+		// Image uploads
+		"internal/images":                opts.Images,
+		"internal/server/images.go":      opts.Images,
+		"views/components/picture.templ": opts.Images,
+		"views/pages/images.templ":       opts.Images,
+
+		// In-app notifications
+		"internal/notify":                                  opts.Notifications,
+		"internal/notify/jobs.go":                          opts.Jobs,
+		"internal/server/notifications.go":                 opts.Notifications,
+		"views/components/notifications.templ":             opts.Notifications,
+		"views/pages/notifications.templ":                  opts.Notifications,
+		"internal/database/migrations/00003_notifications": opts.Notifications,
+
+		// Activity feed (notifies event recipients when notifications are on)
+		"internal/activity":                           opts.Activity,
+		"internal/activity/notify.go":                 opts.Notifications,
+		"internal/server/activity.go":                 opts.Activity,
+		"views/components/timeline.templ":             opts.Activity,
+		"views/pages/activity.templ":                  opts.Activity,
+		"internal/database/migrations/00004_activity": opts.Activity,
+
+		// The signed-in user, for the features working on accounts
+		"internal/server/users.go": opts.Settings || opts.Teams || opts.Billing,
+
+		// Account settings
+		"internal/settings":                           opts.Settings,
+		"internal/server/settings.go":                 opts.Settings,
+		"views/pages/settings.templ":                  opts.Settings,
+		"internal/database/migrations/00005_settings": opts.Settings,
+
+		// Onboarding wizard
+		"internal/onboarding":                           opts.Onboarding,
+		"internal/server/onboarding.go":                 opts.Onboarding,
+		"views/components/progress.templ":               opts.Onboarding,
+		"views/pages/onboarding.templ":                  opts.Onboarding,
+		"internal/database/migrations/00006_onboarding": opts.Onboarding,
+
+		// Organizations, memberships and invitations
+		"internal/teams":                           opts.Teams,
+		"internal/server/teams.go":                 opts.Teams,
+		"views/pages/teams.templ":                  opts.Teams,
+		"internal/database/migrations/00007_teams": opts.Teams,
+
+		// Stripe subscriptions
+		"internal/billing":                           opts.Billing,
+		"internal/server/billing.go":                 opts.Billing,
+		"views/pages/pricing.templ":                  opts.Billing,
+		"internal/database/migrations/00008_billing": opts.Billing,
+
+		// PostGIS places with a map
+		"internal/places":                           opts.PostGIS,
+		"internal/server/places.go":                 opts.PostGIS,
+		"views/pages/places.templ":                  opts.PostGIS,
+		"internal/database/migrations/00009_places": opts.PostGIS,
+
+		// Read-only demo, demo content and screenshots
+		"internal/demo":               opts.Demo,
+		"internal/demo/seed.go":       opts.Demo && usesPostgres(opts),
+		"cmd/demo":                    opts.Demo,
+		"views/components/demo.templ": opts.Demo,
+
+		// Deployment: Hetzner + Caddy files at the top of deploy/, systemd
+		// over SSH in deploy/systemd, the compose rollout behind a proxy
+		"deploy/Caddyfile":       opts.DeployProvider == DeployHetznerCaddy,
+		"deploy/app.service":     opts.DeployProvider == DeployHetznerCaddy,
+		"deploy/deploy.sh":       opts.DeployProvider == DeployHetznerCaddy,
+		"deploy/setup-server.sh": opts.DeployProvider == DeployHetznerCaddy,
+		"deploy/systemd":         opts.DeployProvider == DeploySystemd,
+		"deploy/rollout.sh":      hasProxy(opts),
+
+		// PWA (dropped by --lean)
+		"assets/static": !opts.Lean,
+
+		// Vite (TypeScript islands)
+		"vite.config.ts":              opts.Vite,
+		"package.json":                opts.Vite,
+		"frontend":                    opts.Vite,
+		"internal/vite":               opts.Vite,
+		"internal/middleware/vite.go": opts.Vite,
+		"views/components/vite.templ": opts.Vite,
+
+		// Embedded, precompressed assets
+		"internal/static":    opts.EmbedAssets,
+		"assets/compress.go": opts.EmbedAssets,
+
+		// TypeScript (esbuild)
+		"src/ts": opts.TypeScript,
+
+		// Component preview server
+		"cmd/preview":  opts.Preview,
+		"cmd/devstack": opts.DevDB == DevDBTestcontainers,
+		"pkg/factory":  opts.Factories,
+
+		// Mocks of the server's boundary interfaces, generated by make mocks
+		".mockery.yaml":                 opts.Mocks == MocksMockery,
+		"internal/server/stores.go":     hasMocks(opts) && hasExampleCRUD(opts),
+		"internal/server/mocks_test.go": hasMocks(opts),
+
+		// Extra binaries (cmd/cli is rendered from the add command snippet)
+		"cmd/worker": hasBinary(opts, BinaryWorker),
+
+		// SEO
+		"internal/seo":               opts.SEO,
+		"views/components/seo.templ": opts.SEO,
+
+		// Static site export
+		"cmd/export": opts.StaticExport,
+
+		// Blog/content
+		"content":                 opts.Content,
+		"internal/blog":           opts.Content,
+		"internal/server/blog.go": opts.Content,
+		"views/pages/blog.templ":  opts.Content,
+
+		// Search
+		"internal/search":                           hasSearch(opts),
+		"internal/search/pgtrgm.go":                 opts.Search == SearchPgTrgm,
+		"internal/search/meilisearch.go":            opts.Search == SearchMeilisearch,
+		"internal/search/bleve.go":                  opts.Search == SearchBleve,
+		"internal/database/migrations/00002_search": opts.Search == SearchPgTrgm,
+		"internal/server/search.go":                 hasSearch(opts),
+		"views/pages/search.templ":                  hasSearch(opts),
+
+		// GeoIP / request context enrichment
+		"internal/geoip":               opts.GeoIP,
+		"internal/middleware/geoip.go": opts.GeoIP,
+
+		// Analytics
+		"internal/analytics":               hasAnalytics(opts),
+		"views/components/analytics.templ": hasAnalytics(opts),
+
+		// Cookie consent and legal pages
+		"internal/consent":               opts.GDPR,
+		"internal/server/consent.go":     opts.GDPR,
+		"views/components/consent.templ": opts.GDPR,
+		"views/pages/legal.templ":        opts.GDPR,
+
+		// Error reporting
+		"internal/errorreport":           hasErrors(opts),
+		"internal/server/errorreport.go": hasErrors(opts),
+
+		// Profiling and runtime diagnostics
+		"internal/diagnostics": opts.Pprof,
+
+		// Status page for operators
+		"internal/server/status.go": opts.StatusPage,
+		"views/pages/status.templ":  opts.StatusPage,
+
+		// Load testing
+		"loadtest":                       hasLoadTest(opts),
+		"loadtest/k6":                    opts.LoadTest == LoadTestK6,
+		"loadtest/vegeta":                opts.LoadTest == LoadTestVegeta,
+		".github/workflows/loadtest.yml": hasLoadTest(opts),
+
+		// End-to-end tests (Playwright)
+		"e2e":                       opts.E2E,
+		"e2e/tests/search.spec.ts":  hasSearch(opts),
+		"e2e/tests/consent.spec.ts": opts.GDPR,
+		"e2e/tests/blog.spec.ts":    opts.Content,
+		".github/workflows/e2e.yml": opts.E2E,
+
+		// Fuzz tests of the input handling code
+		"pkg/helpers/validate_fuzz_test.go":               opts.Fuzz,
+		"internal/onboarding/onboarding_fuzz_test.go":     opts.Fuzz,
+		"internal/importexport/importexport_fuzz_test.go": opts.Fuzz,
+		"internal/blog/blog_fuzz_test.go":                 opts.Fuzz,
+		".github/workflows/fuzz.yml":                      opts.Fuzz,
+
+		// Snapshot tests of the Templ components and pages
+		"internal/snapshot":                            opts.Snapshots,
+		"views/components/components_snapshot_test.go": opts.Snapshots,
+		"views/pages/pages_snapshot_test.go":           opts.Snapshots,
+
+		// OpenAPI spec and contract tests
+		"api":                             opts.OpenAPI,
+		"internal/server/openapi_test.go": opts.OpenAPI,
+		".github/workflows/contract.yml":  opts.OpenAPI,
+
+		// Background jobs
+		"internal/jobs": opts.Jobs,
+
+		// Software bill of materials (Syft, CycloneDX)
+		".syft.yaml":                 opts.SBOM,
+		".github/workflows/sbom.yml": opts.SBOM,
+
+		// Local HTTPS (mkcert)
+		"internal/middleware/https.go": opts.HTTPSDev,
+		"internal/server/https.go":     opts.HTTPSDev,
+
+		// Reverse proxy in front of the app (docker compose service "proxy")
+		"proxy":         hasProxy(opts),
+		"proxy/caddy":   opts.Proxy == ProxyCaddy,
+		"proxy/nginx":   opts.Proxy == ProxyNginx,
+		"proxy/traefik": opts.Proxy == ProxyTraefik,
+
+		// Signed releases (cosign, SLSA provenance)
+		".github/workflows/release.yml": opts.Sign,
+
+		// Shared by Vite and TypeScript
+		"tsconfig.json": opts.Vite || opts.TypeScript,
+	}
+}
+
+// hasSearch reports whether a search backend is selected
+func hasSearch(opts Options) bool {
+	return opts.Search != "" && opts.Search != SearchNone
+}
+
+// hasAnalytics reports whether an analytics provider is selected
+func hasAnalytics(opts Options) bool {
+	return opts.Analytics == AnalyticsPlausible || opts.Analytics == AnalyticsUmami
+}
+
+// hasErrors reports whether an error reporting backend is selected
+func hasErrors(opts Options) bool {
+	return opts.Errors == ErrorsSentry || opts.Errors == ErrorsGlitchTip
+}
+
+// hasMocks reports whether a mock generator is selected
+func hasMocks(opts Options) bool {
+	return opts.Mocks == MocksMockery || opts.Mocks == MocksGomock
+}
+
+// hasLoadTest reports whether a load testing tool is selected
+func hasLoadTest(opts Options) bool {
+	return opts.LoadTest == LoadTestK6 || opts.LoadTest == LoadTestVegeta
+}
+
+// hasExampleCRUD reports whether the example resource is a note or todo
+// CRUD, which needs the database
+func hasExampleCRUD(opts Options) bool {
+	return opts.ExampleResource == ExampleNote || opts.ExampleResource == ExampleTodo
+}
+
+// hasSQLiteReplication reports whether a SQLite replication is selected
+func hasSQLiteReplication(opts Options) bool {
+	return opts.SQLiteReplication == SQLiteReplicationLiteFS || opts.SQLiteReplication == SQLiteReplicationTurso
+}
+
+// databaseOf is the database backend of opts, following IncludeDB and
+// SingleBinary when Database is not set
+func databaseOf(opts Options) string {
+	switch {
+	case opts.Database != "":
+		return opts.Database
+	case opts.SingleBinary:
+		return DatabaseSQLite
+	case opts.IncludeDB:
+		return DatabasePostgres
+	}
+	return DatabaseNone
+}
+
+// databaseName is how messages name a database backend
+func databaseName(database string) string {
+	switch database {
+	case DatabaseMySQL:
+		return "MySQL"
+	case DatabaseSQLite:
+		return "SQLite"
+	}
+	return "Postgres"
+}
+
+// hasDatabase reports whether there is a database, whatever its backend
+func hasDatabase(opts Options) bool {
+	return databaseOf(opts) != DatabaseNone
+}
+
+// usesPostgres reports whether the database is Postgres
+func usesPostgres(opts Options) bool {
+	return databaseOf(opts) == DatabasePostgres
+}
+
+// usesSQLite reports whether the database is SQLite, a file or Turso
+func usesSQLite(opts Options) bool {
+	return databaseOf(opts) == DatabaseSQLite
+}
+
+// postgresOnly names the first selected feature written for Postgres (pgx
+// queries, Postgres SQL), or returns "" when there is none
+func postgresOnly(opts Options) string {
+	switch {
+	case opts.DBReplicas:
+		return "read replicas"
+	case opts.Search == SearchPgTrgm:
+		return "pg_trgm search"
+	case hasExampleCRUD(opts):
+		return fmt.Sprintf("the %s example resource", opts.ExampleResource)
+	case opts.Notifications:
+		return "notifications"
+	case opts.Activity:
+		return "the activity feed"
+	case opts.Settings:
+		return "settings"
+	case opts.Onboarding:
+		return "the onboarding wizard"
+	case opts.Teams:
+		return "teams"
+	case opts.Billing:
+		return "billing"
+	case opts.PostGIS:
+		return "PostGIS"
+	case opts.DevDB == DevDBTestcontainers:
+		return "the testcontainers dev database"
+	case opts.Factories:
+		return "test factories"
+	}
+	return ""
+}
+
+// hasProxy reports whether a reverse proxy is selected
+func hasProxy(opts Options) bool {
+	return opts.Proxy == ProxyCaddy || opts.Proxy == ProxyNginx || opts.Proxy == ProxyTraefik
+}
+
+// skipPath reports whether a template path belongs to an unselected feature
+func skipPath(relPath string, opts Options) bool {
+	for prefix, enabled := range featurePaths(opts) {
+		if !enabled && strings.HasPrefix(relPath, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// conditions maps conditional block names to whether they are kept.
+// Every name X supports both <!-- IF X --> and <!-- IF NOT X --> blocks.
+func conditions(opts Options) map[string]bool {
+	conds := featureConditions(opts)
+
+	// Derived conditions
+	conds["EXAMPLE_CRUD"] = hasExampleCRUD(opts)
+	conds["ACCOUNTS"] = opts.Settings || opts.Teams || opts.Billing // features needing the signed-in user
+	conds["EXAMPLE_USERS"] = hasDatabase(opts) && opts.ExampleResource == ExampleUsers
+	conds["POSTGRES"] = usesPostgres(opts)
+	conds["MYSQL"] = databaseOf(opts) == DatabaseMySQL
+	conds["SQLITE"] = usesSQLite(opts)
+	conds["SQL_DB"] = conds["MYSQL"] || conds["SQLITE"]                                         // a database/sql handle rather than a pgx pool
+	conds["SQLITE_FILE"] = usesSQLite(opts) && opts.SQLiteReplication != SQLiteReplicationTurso // a database file next to the app
+	conds["EMBED_MIGRATIONS"] = usesSQLite(opts) || opts.AutoMigrate                            // goose runs inside the app
+	conds["COMPONENTS_IN_LAYOUT"] = opts.Vite || opts.SEO || hasAnalytics(opts) || opts.GDPR || opts.Demo
+	conds["BASE_URL"] = opts.SEO || opts.Content
+	conds["BASE_PATH"] = opts.BasePath != ""
+	conds["CSS_BASECOAT"] = opts.CSSFramework == CSSFrameworkBasecoat
+	conds["BINARIES"] = hasBinary(opts, BinaryWorker) || hasBinary(opts, BinaryCLI)
+	conds["DB_OR_JOBS"] = hasDatabase(opts) || opts.Jobs // the status page lists pool or queue stats
+	conds["LOG_IMPORT"] = opts.Content || hasSearch(opts) || opts.GeoIP || opts.PDF || opts.Images
+	conds["DATA_DIR"] = opts.Search == SearchBleve || opts.Images || conds["SQLITE_FILE"] // data/ holds local state
+	conds["DEPENDS_ON"] = usesPostgres(opts) || conds["MYSQL"] || opts.Search == SearchMeilisearch || opts.PDF
+	conds["CHANNEL_EDGE"] = opts.Channel == ChannelEdge
+	conds["GO_LOOP"] = goMinor(goVersion(opts)) >= 24 // testing.B.Loop
+	conds["LINT_STANDARD"] = opts.Lint == LintStandard
+	conds["LINT_MINIMAL"] = opts.Lint == LintMinimal
+	conds["LINT_STRICT"] = !conds["LINT_STANDARD"] && !conds["LINT_MINIMAL"]
+	conds["PWA"] = !opts.Lean
+	conds["STATIC_ASSETS"] = !opts.Lean || opts.Vite // Vite builds into assets/static/vite
+	for _, lang := range i18n.Languages[1:] {
+		conds["LANG_"+strings.ToUpper(lang)] = opts.Lang == lang
+	}
+	return conds
+}
+
+// featureConditions maps the user-facing features to whether they are
+// selected; the enabled ones are recorded in the project manifest
+func featureConditions(opts Options) map[string]bool {
+	return map[string]bool{
+		"DB":                  hasDatabase(opts),
+		"DB_REPLICAS":         opts.DBReplicas,
+		"DEPLOY_HETZNER":      opts.DeployProvider == DeployHetznerCaddy,
+		"DEPLOY_SYSTEMD":      opts.DeployProvider == DeploySystemd,
+		"HOOKS":               opts.IncludeHooks,
+		"VITE":                opts.Vite,
+		"LEAN":                opts.Lean,
+		"TYPESCRIPT":          opts.TypeScript,
+		"PREVIEW":             opts.Preview,
+		"SEO":                 opts.SEO,
+		"STATIC_EXPORT":       opts.StaticExport,
+		"CONTENT":             opts.Content,
+		"SEARCH":              hasSearch(opts),
+		"SEARCH_PGTRGM":       opts.Search == SearchPgTrgm,
+		"SEARCH_MEILI":        opts.Search == SearchMeilisearch,
+		"SEARCH_BLEVE":        opts.Search == SearchBleve,
+		"GEOIP":               opts.GeoIP,
+		"ANALYTICS":           hasAnalytics(opts),
+		"ANALYTICS_PLAUSIBLE": opts.Analytics == AnalyticsPlausible,
+		"ANALYTICS_UMAMI":     opts.Analytics == AnalyticsUmami,
+		"GDPR":                opts.GDPR,
+		"ERRORS":              hasErrors(opts),
+		"ERRORS_SENTRY":       opts.Errors == ErrorsSentry,
+		"ERRORS_GLITCHTIP":    opts.Errors == ErrorsGlitchTip,
+		"PPROF":               opts.Pprof,
+		"LOADTEST":            hasLoadTest(opts),
+		"LOADTEST_K6":         opts.LoadTest == LoadTestK6,
+		"LOADTEST_VEGETA":     opts.LoadTest == LoadTestVegeta,
+		"E2E":                 opts.E2E,
+		"OPENAPI":             opts.OpenAPI,
+		"JOBS":                opts.Jobs,
+		"SBOM":                opts.SBOM,
+		"SIGN":                opts.Sign,
+		"HTTPS_DEV":           opts.HTTPSDev,
+		"EMBED_ASSETS":        opts.EmbedAssets,
+		"EXAMPLE_NOTE":        opts.ExampleResource == ExampleNote,
+		"EXAMPLE_TODO":        opts.ExampleResource == ExampleTodo,
+		"DATATABLE":           opts.DataTable,
+		"IMPORT_EXPORT":       opts.ImportExport,
+		"PDF":                 opts.PDF,
+		"IMAGES":              opts.Images,
+		"NOTIFICATIONS":       opts.Notifications,
+		"ACTIVITY":            opts.Activity,
+		"SETTINGS":            opts.Settings,
+		"ONBOARDING":          opts.Onboarding,
+		"TEAMS":               opts.Teams,
+		"BILLING":             opts.Billing,
+		"DEMO":                opts.Demo,
+		"SINGLE_BINARY":       opts.SingleBinary,
+		"LITESTREAM":          opts.Litestream,
+		"SQLITE_REPLICATION":  hasSQLiteReplication(opts),
+		"SQLITE_LITEFS":       opts.SQLiteReplication == SQLiteReplicationLiteFS,
+		"SQLITE_TURSO":        opts.SQLiteReplication == SQLiteReplicationTurso,
+		"POSTGIS":             opts.PostGIS,
+		"FTS":                 opts.FTS,
+		"AUTO_MIGRATE":        opts.AutoMigrate,
+		"DEV_TESTCONTAINERS":  opts.DevDB == DevDBTestcontainers,
+		"FACTORIES":           opts.Factories,
+		"MOCKS":               hasMocks(opts),
+		"MOCKS_MOCKERY":       opts.Mocks == MocksMockery,
+		"MOCKS_GOMOCK":        opts.Mocks == MocksGomock,
+		"FUZZ":                opts.Fuzz,
+		"SNAPSHOTS":           opts.Snapshots,
+		"STATUS_PAGE":         opts.StatusPage,
+		"BINARY_WORKER":       hasBinary(opts, BinaryWorker),
+		"BINARY_CLI":          hasBinary(opts, BinaryCLI),
+		"PROXY":               hasProxy(opts),
+		"PROXY_CADDY":         opts.Proxy == ProxyCaddy,
+		"PROXY_NGINX":         opts.Proxy == ProxyNginx,
+		"PROXY_TRAEFIK":       opts.Proxy == ProxyTraefik,
+	}
 }
 
 // processConditionalBlocks removes content between <!-- IF X --> and <!-- /IF X --> if condition is false
-// Supports: <!-- IF DB -->, <!-- IF NOT DB -->, <!-- IF DEPLOY_HETZNER -->, <!-- IF HOOKS -->
+// and content between <!-- IF NOT X --> and <!-- /IF NOT X --> if condition is true
 func processConditionalBlocks(content string, opts Options) string {
-	// Process DB blocks
-	if opts.IncludeDB {
-		// Keep content, remove tags
-		content = removeTags(content, "<!-- IF DB -->", "<!-- /IF DB -->")
-		// Remove NOT DB content
-		content = removeBlock(content, "<!-- IF NOT DB -->", "<!-- /IF NOT DB -->")
-	} else {
-		// Remove DB content
-		content = removeBlock(content, "<!-- IF DB -->", "<!-- /IF DB -->")
-		// Keep NOT DB content, remove tags
-		content = removeTags(content, "<!-- IF NOT DB -->", "<!-- /IF NOT DB -->")
-	}
-
-	// Process DEPLOY_HETZNER blocks
-	if opts.DeployProvider == DeployHetznerCaddy {
-		// Keep content, remove tags
-		content = removeTags(content, "<!-- IF DEPLOY_HETZNER -->", "<!-- /IF DEPLOY_HETZNER -->")
-	} else {
-		// Remove DEPLOY_HETZNER content
-		content = removeBlock(content, "<!-- IF DEPLOY_HETZNER -->", "<!-- /IF DEPLOY_HETZNER -->")
-	}
-
-	// Process HOOKS blocks
-	if opts.IncludeHooks {
-		// Keep content, remove tags
-		content = removeTags(content, "<!-- IF HOOKS -->", "<!-- /IF HOOKS -->")
-	} else {
-		// Remove HOOKS content
-		content = removeBlock(content, "<!-- IF HOOKS -->", "<!-- /IF HOOKS -->")
+	for name, keep := range conditions(opts) {
+		ifStart, ifEnd := "<!-- IF "+name+" -->", "<!-- /IF "+name+" -->"
+		notStart, notEnd := "<!-- IF NOT "+name+" -->", "<!-- /IF NOT "+name+" -->"
+
+		if keep {
+			// Keep content, remove tags
+			content = removeTags(content, ifStart, ifEnd)
+			content = removeBlock(content, notStart, notEnd)
+		} else {
+			// Remove content, keep NOT content
+			content = removeBlock(content, ifStart, ifEnd)
+			content = removeTags(content, notStart, notEnd)
+		}
 	}
 
 	return content
@@ -252,77 +1195,48 @@ func removeTags(content, startTag, endTag string) string {
 func getReplacements(opts Options) map[string]string {
 	replacements := make(map[string]string)
 
-	// Frontend JS Downloads
+	// Frontend JS Downloads, checked against the pinned checksums
+	assets := frontendAssets(opts)
 	jsDownloads := `
-	@echo "📥 Downloading Frontend Libraries..."
-	@curl -sL -o assets/js/htmx.min.js https://unpkg.com/htmx.org@2.0.4/dist/htmx.min.js`
-
-	dockerJsDownloads := `RUN curl -sL -o assets/js/htmx.min.js https://unpkg.com/htmx.org@2.0.4/dist/htmx.min.js`
-
-	if opts.Frontend == FrontendHTMXHyperscript {
-		jsDownloads += `
-	@curl -sL -o assets/js/hyperscript.min.js https://unpkg.com/hyperscript.org@0.9.14`
-		dockerJsDownloads += ` && \
-    curl -sL -o assets/js/hyperscript.min.js https://unpkg.com/hyperscript.org@0.9.14`
-	} else if opts.Frontend == FrontendHTMXAlpine {
-		jsDownloads += `
-	@curl -sL -o assets/js/alpinejs.min.js https://unpkg.com/alpinejs@3.14.8/dist/cdn.min.js`
-		dockerJsDownloads += ` && \
-    curl -sL -o assets/js/alpinejs.min.js https://unpkg.com/alpinejs@3.14.8/dist/cdn.min.js`
-	} else if opts.Frontend == FrontendHTMXSurreal {
-		jsDownloads += `
-	@curl -sL -o assets/js/surreal.js https://cdn.jsdelivr.net/gh/gnat/surreal@main/surreal.js`
-		dockerJsDownloads += ` && \
-    curl -sL -o assets/js/surreal.js https://cdn.jsdelivr.net/gh/gnat/surreal@main/surreal.js`
-	}
+	@echo "📥 Downloading Frontend Libraries..."` + assetDownloads(assets, false) + `
+	@$(GOFORGE) assets verify`
+	dockerJsDownloads := assetDownloads(assets, true)
 
-	if opts.CSSFramework == CSSFrameworkBasecoat {
-		jsDownloads += `
-	@curl -sL -o assets/js/basecoat.min.js https://cdn.jsdelivr.net/npm/basecoat-css@latest/dist/basecoat.min.js`
-		dockerJsDownloads += ` && \
-    curl -sL -o assets/js/basecoat.min.js https://cdn.jsdelivr.net/npm/basecoat-css@latest/dist/basecoat.min.js`
-	}
+	replacements[placeholderAssetChecksums] = assetChecksumLines(projectAssets(opts))
 
 	// Frontend Scripts (Local Links)
 	replacements[placeholderFrontendScripts] = getFrontendScripts(opts)
 
-	// Default Setup (DaisyUI)
-	setupCmd := fmt.Sprintf(`@echo "📥 Installing Tailwind CSS + DaisyUI..."
-	@mkdir -p assets/css assets/js
-	@cd assets && curl -sL daisyui.com/fast | bash
-	@mv assets/input.css assets/css/input.css 2>/dev/null || true
-	@mv assets/output.css assets/css/output.css 2>/dev/null || true
-	@mv assets/daisyui.mjs assets/js/daisyui.mjs 2>/dev/null || true
-	@mv assets/daisyui-theme.mjs assets/js/daisyui-theme.mjs 2>/dev/null || true
-	@mv assets/tailwindcss ./tailwindcss 2>/dev/null || true
-	@if [ -f assets/css/input.css ]; then \
-		sed -i.bak 's|./daisyui.mjs|../js/daisyui.mjs|g' assets/css/input.css && rm assets/css/input.css.bak; \
-	fi%s`, jsDownloads)
-
-	dockerSetupRun := fmt.Sprintf(`RUN cd assets && curl -sL daisyui.com/fast | bash
-# Organize assets
-RUN mkdir -p assets/css assets/js && \
-    mv assets/input.css assets/css/ && \
-    mv assets/output.css assets/css/ && \
-    mv assets/daisyui.mjs assets/js/ && \
-    mv assets/daisyui-theme.mjs assets/js/ && \
-    mv assets/tailwindcss .
-# Fix imports
-RUN sed -i 's|./daisyui.mjs|../js/daisyui.mjs|g' assets/css/input.css
+	// Go version: go.mod, Docker builder image, golangci-lint
+	replacements[placeholderGoDirective] = goModVersion(goVersion(opts))
+	replacements[placeholderGoVersion] = goVersion(opts)
+	replacements[placeholderGoLangVersion] = goLanguageVersion(goVersion(opts))
+
+	// Subpath the app is mounted under (--base-path)
+	replacements[placeholderBasePath] = opts.BasePath
+
+	// Mirror for asset downloads (--asset-mirror)
+	replacements[placeholderAssetMirror] = opts.AssetMirror
+
+	// Default Setup (DaisyUI): pinned tools from tools.lock, then the plugin
+	setupCmd := fmt.Sprintf(`@echo "📥 Installing templ, air, Tailwind CSS (tools.lock) + DaisyUI %[1]s..."
+	@$(GOFORGE) tools install --asset-mirror "$(ASSET_MIRROR)"
+	@mkdir -p assets/css assets/js%[2]s
+	@[ -f assets/css/input.css ] || printf '@import "tailwindcss";\n@source not "../js/daisyui{,*}.mjs";\n@plugin "../js/daisyui.mjs";\n' > assets/css/input.css%[3]s`, daisyUIVersion, assetDownloads(daisyUIAssets(), false), jsDownloads)
+
+	dockerSetupRun := fmt.Sprintf(`%s
+RUN mkdir -p assets/css assets/js
+%s
 # Download JS
-%s`, dockerJsDownloads)
+%s`, dockerTailwindRun(), assetDownloads(daisyUIAssets(), true), dockerJsDownloads)
+	dockerInputCSS := `RUN [ -f assets/css/input.css ] || printf '@import "tailwindcss";\n@source not "../js/daisyui{,*}.mjs";\n@plugin "../js/daisyui.mjs";\n' > assets/css/input.css`
 
-	devCmd := `@make -j2 dev-air dev-tailwind`
-	cssWatchCmd := `@./tailwindcss -i assets/css/input.css -o assets/css/output.css --watch`
-	cssBuildCmd := `@./tailwindcss -i assets/css/input.css -o assets/css/output.css`
+	cssBuildCmd := `@tailwindcss -i assets/css/input.css -o assets/css/output.css`
 	airBuildCmd := `templ generate && go build -o ./tmp/main ./cmd/server`
 
 	// Default Docker Build CSS (DaisyUI)
 	dockerBuildCss := `RUN ./tailwindcss -i assets/css/input.css -o assets/css/output.css --minify`
 
-	tailwindPlugin := ""
-	daisyuiConfig := ""
-
 	// Overrides for Basecoat
 	if opts.CSSFramework == CSSFrameworkBasecoat {
 		// Determine input.css content based on theme
@@ -334,28 +1248,21 @@ RUN sed -i 's|./daisyui.mjs|../js/daisyui.mjs|g' assets/css/input.css
 @import "./custom.css";`
 		}
 
-		setupCmd = fmt.Sprintf(`@echo "📥 Installing Tailwind CSS + Basecoat..."
+		setupCmd = fmt.Sprintf(`@echo "📥 Installing templ, air, Tailwind CSS (tools.lock) + Basecoat..."
+	@$(GOFORGE) tools install --asset-mirror "$(ASSET_MIRROR)"
 	@mkdir -p assets/css assets/js
-	@cd assets && curl -sL daisyui.com/fast | bash
-	@mv assets/tailwindcss ./tailwindcss 2>/dev/null || true
 	@echo "Creating assets/css/input.css..."
-	@echo '%s' > assets/css/input.css
-	@echo "Cleaning up DaisyUI files..."
-	@rm assets/input.css assets/output.css assets/daisyui.mjs assets/daisyui-theme.mjs 2>/dev/null || true%s`, inputCssContent, jsDownloads)
-
-		dockerSetupRun = fmt.Sprintf(`RUN cd assets && curl -sL daisyui.com/fast | bash
-RUN mkdir -p assets/js && \
-    mv assets/tailwindcss .
-RUN echo '%s' > assets/css/input.css
-RUN rm assets/input.css assets/output.css assets/daisyui* 2>/dev/null || true
+	@echo '%s' > assets/css/input.css%s`, inputCssContent, jsDownloads)
+
+		dockerSetupRun = fmt.Sprintf(`%s
+RUN mkdir -p assets/css assets/js
 # Download JS
-%s`, inputCssContent, dockerJsDownloads)
+%s`, dockerTailwindRun(), dockerJsDownloads)
+		dockerInputCSS = fmt.Sprintf(`RUN echo '%s' > assets/css/input.css`, inputCssContent)
 
-		devCmd = `@make dev-air` // Air handles build
-		cssWatchCmd = `@echo "CSS watching handled by Air"`
-		cssBuildCmd = `@./tailwindcss -i assets/css/input.css -o assets/css/output.css`
+		cssBuildCmd = `@tailwindcss -i assets/css/input.css -o assets/css/output.css`
 
-		airBuildCmd = `templ generate && ./tailwindcss -i ./assets/css/input.css -o ./assets/css/output.css --minify && go build -o ./tmp/main ./cmd/server`
+		airBuildCmd = `templ generate && tailwindcss -i ./assets/css/input.css -o ./assets/css/output.css --minify && go build -o ./tmp/main ./cmd/server`
 
 		dockerBuildCss = `RUN ./tailwindcss -i assets/css/input.css -o assets/css/output.css --minify`
 	}
@@ -364,43 +1271,59 @@ RUN rm assets/input.css assets/output.css assets/daisyui* 2>/dev/null || true
 	replacements[placeholderSetupCommand] = setupCmd
 	replacements[placeholderCiSetupCommand] = setupCmd
 	replacements[placeholderDockerSetupRun] = dockerSetupRun
+	replacements[placeholderDockerInputCSS] = dockerInputCSS
 
-	replacements[placeholderDevCommand] = devCmd
-	replacements[placeholderCssWatchCmd] = cssWatchCmd
 	replacements[placeholderCssBuildCmd] = cssBuildCmd
 	replacements[placeholderAirBuildCmd] = airBuildCmd
 	replacements[placeholderDockerBuildCss] = dockerBuildCss
 
-	replacements[placeholderTailwindPlugin] = tailwindPlugin
-	replacements[placeholderDaisyuiConfig] = daisyuiConfig
+	// TypeScript sources checked by tsconfig.json
+	var tsInclude []string
+	if opts.Vite {
+		tsInclude = append(tsInclude, `"frontend/src"`)
+	}
+	if opts.TypeScript {
+		tsInclude = append(tsInclude, `"src/ts"`)
+	}
+	replacements[placeholderTsconfigInclude] = strings.Join(tsInclude, ", ")
+
+	// Project metadata (legal pages)
+	replacements[placeholderProjectName] = filepath.Base(opts.ProjectName)
+	replacements[placeholderGeneratedDate] = time.Now().Format("January 2, 2006")
 
 	return replacements
 }
 
 // getFrontendScripts returns the appropriate script tags for the selected frontend (Local Files)
 func getFrontendScripts(opts Options) string {
-	htmxScript := `<script src="/assets/js/htmx.min.js"></script>`
+	htmxScript := `<script src="` + opts.BasePath + `/assets/js/htmx.min.js"></script>`
 	scripts := htmxScript
 
 	switch opts.Frontend {
 	case FrontendHTMXHyperscript:
 		scripts += `
 			<!-- Hyperscript -->
-			<script src="/assets/js/hyperscript.min.js"></script>`
+			<script src="` + opts.BasePath + `/assets/js/hyperscript.min.js"></script>`
 	case FrontendHTMXAlpine:
 		scripts += `
 			<!-- Alpine.js -->
-			<script defer src="/assets/js/alpinejs.min.js"></script>`
+			<script defer src="` + opts.BasePath + `/assets/js/alpinejs.min.js"></script>`
 	case FrontendHTMXSurreal:
 		scripts += `
 			<!-- Surreal -->
-			<script src="/assets/js/surreal.js"></script>`
+			<script src="` + opts.BasePath + `/assets/js/surreal.js"></script>`
 	}
 
 	if opts.CSSFramework == CSSFrameworkBasecoat {
 		scripts += `
 			<!-- Basecoat JS -->
-			<script defer src="/assets/js/basecoat.min.js"></script>`
+			<script defer src="` + opts.BasePath + `/assets/js/basecoat.min.js"></script>`
+	}
+
+	if opts.TypeScript {
+		scripts += `
+			<!-- App TypeScript (esbuild) -->
+			<script defer src="` + opts.BasePath + `/assets/js/app.js"></script>`
 	}
 
 	return scripts
@@ -1,10 +1,21 @@
 package generator
 
 import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 	"testing"
+
+	"github.com/FACorreiaa/goforge/internal/manifest"
 )
 
 func TestGenerate(t *testing.T) {
@@ -149,3 +160,2442 @@ func TestGenerateFileCount(t *testing.T) {
 		t.Errorf("Expected at least %d files, got %d", minExpectedFiles, fileCount)
 	}
 }
+
+// generateProject generates a project with the given options into a temp dir
+// and returns its path. Unset core options fall back to the CLI defaults.
+func generateProject(t *testing.T, opts Options) string {
+	t.Helper()
+
+	opts.ProjectName = filepath.Join(t.TempDir(), "app")
+	if opts.ModulePath == "" {
+		opts.ModulePath = "github.com/test/app"
+	}
+	if opts.Frontend == "" {
+		opts.Frontend = FrontendHTMX
+	}
+	if opts.CSSFramework == "" {
+		opts.CSSFramework = CSSFrameworkDaisyUI
+	}
+	if opts.DeployProvider == "" {
+		opts.DeployProvider = DeployNone
+	}
+
+	if err := GenerateWithOptions(opts); err != nil {
+		t.Fatalf("GenerateWithOptions failed: %v", err)
+	}
+	assertGoSources(t, opts.ProjectName)
+	return opts.ProjectName
+}
+
+// assertGoSources fails the test for every generated Go file that is not
+// gofmt-clean or imports a package it does not use, the mistakes the blocks
+// options leave out make most often
+func assertGoSources(t *testing.T, projectDir string) {
+	t.Helper()
+
+	err := filepath.WalkDir(projectDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".go" {
+			return err
+		}
+		rel, _ := filepath.Rel(projectDir, path)
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if formatted, err := format.Source(src); err != nil {
+			t.Errorf("%s does not parse: %v", rel, err)
+			return nil
+		} else if !bytes.Equal(formatted, src) {
+			t.Errorf("%s is not gofmt-clean", rel)
+		}
+		for _, name := range unusedImports(t, src) {
+			t.Errorf("%s imports %s without using it", rel, name)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// unusedImports lists the imports of src no selector refers to. A package is
+// assumed to be named after its path, without a major version or a go-, -go
+// or -golang affix.
+func unusedImports(t *testing.T, src []byte) []string {
+	t.Helper()
+
+	file, err := parser.ParseFile(token.NewFileSet(), "", src, parser.SkipObjectResolution)
+	if err != nil {
+		t.Fatal(err)
+	}
+	used := map[string]bool{}
+	ast.Inspect(file, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if id, ok := sel.X.(*ast.Ident); ok {
+				used[id.Name] = true
+			}
+		}
+		return true
+	})
+	var unused []string
+	for _, imp := range file.Imports {
+		importPath, _ := strconv.Unquote(imp.Path.Value)
+		name := path.Base(importPath)
+		if regexp.MustCompile(`^v[0-9]+$`).MatchString(name) {
+			name = path.Base(path.Dir(importPath))
+		}
+		name = strings.Split(name, ".")[0]
+		name = strings.TrimPrefix(strings.TrimSuffix(strings.TrimSuffix(name, "-golang"), "-go"), "go-")
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		if name != "_" && name != "." && !used[name] {
+			unused = append(unused, importPath)
+		}
+	}
+	return unused
+}
+
+// readProjectFile returns the content of a generated file
+func readProjectFile(t *testing.T, projectDir, file string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(filepath.Join(projectDir, file))
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", file, err)
+	}
+	return string(content)
+}
+
+// assertFilesExist fails the test for every file missing from the project
+func assertFilesExist(t *testing.T, projectDir string, files ...string) {
+	t.Helper()
+
+	for _, file := range files {
+		if _, err := os.Stat(filepath.Join(projectDir, file)); os.IsNotExist(err) {
+			t.Errorf("Expected file missing: %s", file)
+		}
+	}
+}
+
+// assertFilesMissing fails the test for every file present in the project
+func assertFilesMissing(t *testing.T, projectDir string, files ...string) {
+	t.Helper()
+
+	for _, file := range files {
+		if _, err := os.Stat(filepath.Join(projectDir, file)); !os.IsNotExist(err) {
+			t.Errorf("Unexpected file generated: %s", file)
+		}
+	}
+}
+
+func TestGenerateWithVite(t *testing.T) {
+	projectDir := generateProject(t, Options{Vite: true})
+
+	assertFilesExist(t, projectDir,
+		"vite.config.ts",
+		"package.json",
+		"tsconfig.json",
+		"frontend/src/main.ts",
+		"internal/vite/vite.go",
+		"internal/middleware/vite.go",
+		"views/components/vite.templ",
+	)
+
+	if !strings.Contains(readProjectFile(t, projectDir, "views/layouts/base.templ"), `@components.ViteScripts("frontend/src/main.ts")`) {
+		t.Error("base.templ does not include Vite script tags")
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, "internal/server/routes.go"), "ViteDevProxy") {
+		t.Error("routes.go does not mount the Vite dev proxy")
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, "Dockerfile"), "npm run build") {
+		t.Error("Dockerfile does not build the Vite bundle")
+	}
+}
+
+func TestGenerateWithoutVite(t *testing.T) {
+	projectDir := generateProject(t, Options{})
+
+	assertFilesMissing(t, projectDir, "vite.config.ts", "package.json", "tsconfig.json", "frontend", "internal/vite")
+
+	base := readProjectFile(t, projectDir, "views/layouts/base.templ")
+	if strings.Contains(base, "ViteScripts") || strings.Contains(base, "<!-- IF") {
+		t.Error("base.templ contains Vite content or unprocessed conditional tags")
+	}
+}
+
+func TestGenerateWithTypeScript(t *testing.T) {
+	projectDir := generateProject(t, Options{TypeScript: true})
+
+	assertFilesExist(t, projectDir, "src/ts/main.ts", "src/ts/htmx.d.ts", "tsconfig.json")
+	assertFilesMissing(t, projectDir, "package.json", "vite.config.ts")
+
+	if tsconfig := readProjectFile(t, projectDir, "tsconfig.json"); !strings.Contains(tsconfig, `"include": ["src/ts"]`) {
+		t.Errorf("tsconfig.json does not include src/ts:\n%s", tsconfig)
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, "views/layouts/base.templ"), "/assets/js/app.js") {
+		t.Error("base.templ does not load the esbuild bundle")
+	}
+
+	makefile := readProjectFile(t, projectDir, "Makefile")
+	for _, want := range []string{"ts-build:", "ts-watch:", "build: templ ts-build", "-j2 ts-watch dev-air"} {
+		if !strings.Contains(makefile, want) {
+			t.Errorf("Makefile missing %q", want)
+		}
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, "Dockerfile"), "esbuild src/ts/main.ts") {
+		t.Error("Dockerfile does not bundle TypeScript")
+	}
+}
+
+func TestGenerateWithViteAndTypeScript(t *testing.T) {
+	projectDir := generateProject(t, Options{Vite: true, TypeScript: true})
+
+	if tsconfig := readProjectFile(t, projectDir, "tsconfig.json"); !strings.Contains(tsconfig, `"include": ["frontend/src", "src/ts"]`) {
+		t.Errorf("tsconfig.json does not include both source trees:\n%s", tsconfig)
+	}
+}
+
+func TestGenerateWithPreview(t *testing.T) {
+	projectDir := generateProject(t, Options{Preview: true})
+
+	assertFilesExist(t, projectDir,
+		"cmd/preview/main.go",
+		"cmd/preview/stories.go",
+		"cmd/preview/gen/main.go",
+	)
+
+	if !strings.Contains(readProjectFile(t, projectDir, "cmd/preview/main.go"), `"github.com/test/app/views/layouts"`) {
+		t.Error("cmd/preview/main.go does not import the project's layouts")
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, "Makefile"), "preview:") {
+		t.Error("Makefile missing preview target")
+	}
+
+	defaultDir := generateProject(t, Options{})
+	assertFilesMissing(t, defaultDir, "cmd/preview")
+}
+
+func TestGenerateAccessibilityBaseline(t *testing.T) {
+	projectDir := generateProject(t, Options{})
+
+	assertFilesExist(t, projectDir, ".pa11yci.json")
+
+	base := readProjectFile(t, projectDir, "views/layouts/base.templ")
+	if !strings.Contains(base, `href="#main-content"`) {
+		t.Error("base.templ missing skip link")
+	}
+	if !strings.Contains(base, ":focus-visible") {
+		t.Error("base.templ missing focus styles")
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, "views/pages/index.templ"), `id="main-content"`) {
+		t.Error("index.templ missing skip link target")
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, "Makefile"), "a11y:") {
+		t.Error("Makefile missing a11y target")
+	}
+}
+
+func TestGenerateWithSEO(t *testing.T) {
+	projectDir := generateProject(t, Options{SEO: true, Preview: true})
+
+	assertFilesExist(t, projectDir, "internal/seo/seo.go", "views/components/seo.templ")
+
+	base := readProjectFile(t, projectDir, "views/layouts/base.templ")
+	for _, want := range []string{"templ Base(meta seo.PageMeta)", "@components.SEOMeta(meta)", `"github.com/test/app/internal/seo"`} {
+		if !strings.Contains(base, want) {
+			t.Errorf("base.templ missing %q", want)
+		}
+	}
+	if strings.Contains(base, "templ Base(title string)") {
+		t.Error("base.templ still declares the title-only layout")
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, "views/pages/index.templ"), "var homeMeta = seo.PageMeta{") {
+		t.Error("index.templ does not pass page metadata")
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, "cmd/preview/main.go"), "layouts.Base(meta)") {
+		t.Error("preview server does not use the PageMeta layout")
+	}
+
+	routes := readProjectFile(t, projectDir, "internal/server/routes.go")
+	for _, want := range []string{`Path: "/sitemap.xml"`, `Path: "/robots.txt"`} {
+		if !strings.Contains(routes, want) {
+			t.Errorf("routes.go missing %q", want)
+		}
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, ".env.example"), "BASE_URL=") {
+		t.Error(".env.example missing BASE_URL")
+	}
+}
+
+func TestGenerateWithStaticExport(t *testing.T) {
+	projectDir := generateProject(t, Options{StaticExport: true})
+
+	assertFilesExist(t, projectDir, "cmd/export/main.go")
+
+	makefile := readProjectFile(t, projectDir, "Makefile")
+	if !strings.Contains(makefile, "export: templ") || !strings.Contains(makefile, "go run ./cmd/export") {
+		t.Error("Makefile missing export target")
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, ".gitignore"), "dist/") {
+		t.Error(".gitignore does not ignore dist/")
+	}
+
+	defaultDir := generateProject(t, Options{})
+	assertFilesMissing(t, defaultDir, "cmd/export")
+	if strings.Contains(readProjectFile(t, defaultDir, "Makefile"), "cmd/export") {
+		t.Error("Makefile references cmd/export without the feature")
+	}
+}
+
+func TestGenerateWithContent(t *testing.T) {
+	projectDir := generateProject(t, Options{Content: true, SEO: true})
+
+	assertFilesExist(t, projectDir,
+		"content/content.go",
+		"content/posts/hello-world.md",
+		"internal/blog/blog.go",
+		"internal/blog/rss.go",
+		"internal/server/blog.go",
+		"views/pages/blog.templ",
+	)
+
+	routes := readProjectFile(t, projectDir, "internal/server/routes.go")
+	for _, want := range []string{`Path: "/blog", `, `Path: "/blog/feed.xml", `, `Path: "/blog/{slug}", `} {
+		if !strings.Contains(routes, want) {
+			t.Errorf("routes.go missing %q", want)
+		}
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, "go.mod"), "github.com/yuin/goldmark") {
+		t.Error("go.mod missing goldmark")
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, "views/pages/blog.templ"), "seo.PageMeta{Title: post.Title") {
+		t.Error("blog pages do not pass page metadata when SEO is enabled")
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, ".dockerignore"), "!content/**/*.md") {
+		t.Error(".dockerignore excludes Markdown posts from the build context")
+	}
+	if n := strings.Count(readProjectFile(t, projectDir, ".env.example"), "\nBASE_URL="); n != 1 {
+		t.Errorf(".env.example declares BASE_URL %d times, want 1", n)
+	}
+
+	plainDir := generateProject(t, Options{Content: true})
+	if !strings.Contains(readProjectFile(t, plainDir, "views/pages/blog.templ"), "@layouts.Base(post.Title)") {
+		t.Error("blog pages do not use the title layout without SEO")
+	}
+	if strings.Contains(readProjectFile(t, plainDir, "go.mod"), "goforge/scaffold") {
+		t.Error("go.mod still contains the placeholder module")
+	}
+}
+
+func TestGenerateWithSearch(t *testing.T) {
+	backends := map[string]string{
+		SearchPgTrgm:      "internal/search/pgtrgm.go",
+		SearchMeilisearch: "internal/search/meilisearch.go",
+		SearchBleve:       "internal/search/bleve.go",
+	}
+
+	for backend, file := range backends {
+		t.Run(backend, func(t *testing.T) {
+			projectDir := generateProject(t, Options{Search: backend, IncludeDB: true})
+
+			assertFilesExist(t, projectDir,
+				"internal/search/search.go",
+				"internal/search/hooks.go",
+				"internal/server/search.go",
+				"views/pages/search.templ",
+				file,
+			)
+			for other, otherFile := range backends {
+				if other != backend {
+					assertFilesMissing(t, projectDir, otherFile)
+				}
+			}
+
+			routes := readProjectFile(t, projectDir, "internal/server/routes.go")
+			if !strings.Contains(routes, `Path: "/search/results", `) {
+				t.Error("routes.go missing the live results route")
+			}
+
+			migration := "internal/database/migrations/00002_search.sql"
+			compose := readProjectFile(t, projectDir, "docker-compose.yml")
+			switch backend {
+			case SearchPgTrgm:
+				assertFilesExist(t, projectDir, migration)
+			case SearchMeilisearch:
+				assertFilesMissing(t, projectDir, migration)
+				if !strings.Contains(compose, "getmeili/meilisearch") {
+					t.Error("docker-compose.yml missing the Meilisearch service")
+				}
+			case SearchBleve:
+				assertFilesMissing(t, projectDir, migration)
+				if !strings.Contains(readProjectFile(t, projectDir, "go.mod"), "github.com/blevesearch/bleve/v2") {
+					t.Error("go.mod missing bleve")
+				}
+			}
+			if backend != SearchMeilisearch && strings.Contains(compose, "meilisearch") {
+				t.Error("docker-compose.yml includes Meilisearch for another backend")
+			}
+		})
+	}
+}
+
+func TestGenerateWithoutSearch(t *testing.T) {
+	projectDir := generateProject(t, Options{IncludeDB: true})
+
+	assertFilesMissing(t, projectDir, "internal/search", "views/pages/search.templ", "internal/database/migrations/00002_search.sql")
+	if strings.Contains(readProjectFile(t, projectDir, "views/components/navbar.templ"), `href="/search"`) {
+		t.Error("navbar links to search without a search backend")
+	}
+}
+
+func TestGenerateSearchPgTrgmRequiresDB(t *testing.T) {
+	err := GenerateWithOptions(Options{
+		ProjectName: filepath.Join(t.TempDir(), "app"),
+		ModulePath:  "github.com/test/app",
+		Search:      SearchPgTrgm,
+	})
+	if err == nil {
+		t.Fatal("expected an error for pg_trgm search without a database")
+	}
+}
+
+func TestGenerateWithGeoIP(t *testing.T) {
+	projectDir := generateProject(t, Options{GeoIP: true})
+
+	assertFilesExist(t, projectDir, "internal/geoip/geoip.go", "internal/middleware/geoip.go")
+
+	routes := readProjectFile(t, projectDir, "internal/server/routes.go")
+	if !strings.Contains(routes, "appmiddleware.GeoIP(s.geo)") {
+		t.Error("routes.go does not mount the GeoIP middleware")
+	}
+	if strings.Contains(routes, "middleware.RealIP") {
+		t.Error("routes.go still trusts forwarding headers via RealIP")
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, ".env.example"), "TRUSTED_PROXIES=") {
+		t.Error(".env.example missing TRUSTED_PROXIES")
+	}
+
+	plainDir := generateProject(t, Options{})
+	assertFilesMissing(t, plainDir, "internal/geoip", "internal/middleware/geoip.go")
+	if !strings.Contains(readProjectFile(t, plainDir, "internal/server/routes.go"), "r.Use(middleware.RealIP)") {
+		t.Error("routes.go dropped RealIP without GeoIP")
+	}
+}
+
+func TestGenerateWithAnalytics(t *testing.T) {
+	for provider, script := range map[string]string{
+		AnalyticsPlausible: `/js/script.js`,
+		AnalyticsUmami:     `data-website-id`,
+	} {
+		t.Run(provider, func(t *testing.T) {
+			projectDir := generateProject(t, Options{Analytics: provider})
+
+			assertFilesExist(t, projectDir, "internal/analytics/analytics.go", "views/components/analytics.templ")
+
+			if !strings.Contains(readProjectFile(t, projectDir, "views/components/analytics.templ"), script) {
+				t.Errorf("analytics.templ missing the %s tracker", provider)
+			}
+			if !strings.Contains(readProjectFile(t, projectDir, "views/layouts/base.templ"), "@components.AnalyticsScript()") {
+				t.Error("base.templ does not render the analytics script")
+			}
+			routes := readProjectFile(t, projectDir, "internal/server/routes.go")
+			if !strings.Contains(routes, "analytics.Proxy()") || !strings.Contains(routes, "r.Use(analytics.Middleware)") {
+				t.Error("routes.go does not mount the analytics middleware and proxy")
+			}
+		})
+	}
+
+	plainDir := generateProject(t, Options{Analytics: AnalyticsNone})
+	assertFilesMissing(t, plainDir, "internal/analytics", "views/components/analytics.templ")
+	if strings.Contains(readProjectFile(t, plainDir, "views/layouts/base.templ"), "components") {
+		t.Error("base.templ imports components without analytics")
+	}
+}
+
+func TestGenerateWithGDPR(t *testing.T) {
+	projectDir := generateProject(t, Options{GDPR: true, Analytics: AnalyticsPlausible})
+
+	assertFilesExist(t, projectDir,
+		"internal/consent/consent.go",
+		"internal/server/consent.go",
+		"views/components/consent.templ",
+		"views/pages/legal.templ",
+	)
+
+	legal := readProjectFile(t, projectDir, "views/pages/legal.templ")
+	if !strings.Contains(legal, `legalEntity  = "app"`) {
+		t.Error("legal pages are not pre-filled with the project name")
+	}
+	if strings.Contains(legal, "<!--") {
+		t.Error("legal pages contain unreplaced placeholders")
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, "views/layouts/base.templ"), "@components.ConsentBanner()") {
+		t.Error("base.templ does not render the consent banner")
+	}
+	routes := readProjectFile(t, projectDir, "internal/server/routes.go")
+	for _, want := range []string{`Method: http.MethodGet, Path: "/privacy", `, `Method: http.MethodGet, Path: "/terms", `, `Method: http.MethodPost, Path: "/consent", `, "r.Use(consent.Middleware)"} {
+		if !strings.Contains(routes, want) {
+			t.Errorf("routes.go missing %q", want)
+		}
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, ".env.example"), "ANALYTICS_REQUIRE_CONSENT=true") {
+		t.Error("analytics does not wait for consent when GDPR is enabled")
+	}
+
+	plainDir := generateProject(t, Options{Analytics: AnalyticsPlausible})
+	assertFilesMissing(t, plainDir, "internal/consent", "views/pages/legal.templ")
+	if !strings.Contains(readProjectFile(t, plainDir, ".env.example"), "ANALYTICS_REQUIRE_CONSENT=false") {
+		t.Error("analytics requires consent without a consent banner")
+	}
+}
+
+func TestGenerateWithErrors(t *testing.T) {
+	for _, backend := range []string{ErrorsSentry, ErrorsGlitchTip} {
+		t.Run(backend, func(t *testing.T) {
+			projectDir := generateProject(t, Options{Errors: backend})
+
+			assertFilesExist(t, projectDir, "internal/errorreport/errorreport.go", "internal/server/errorreport.go")
+
+			if !strings.Contains(readProjectFile(t, projectDir, "cmd/server/main.go"), "errorreport.Init(version)") {
+				t.Error("main.go does not initialize error reporting with the release")
+			}
+			routes := readProjectFile(t, projectDir, "internal/server/routes.go")
+			if !strings.Contains(routes, "r.Use(errorreport.Middleware)") || !strings.Contains(routes, `"/debug/error-report"`) {
+				t.Error("routes.go missing the error reporting middleware or test route")
+			}
+			if !strings.Contains(readProjectFile(t, projectDir, "go.mod"), "github.com/getsentry/sentry-go") {
+				t.Error("go.mod missing sentry-go")
+			}
+			if !strings.Contains(readProjectFile(t, projectDir, "Makefile"), "errors-release:") {
+				t.Error("Makefile missing errors-release target")
+			}
+		})
+	}
+
+	plainDir := generateProject(t, Options{})
+	assertFilesMissing(t, plainDir, "internal/errorreport")
+	if !strings.Contains(readProjectFile(t, plainDir, "Makefile"), "-X main.version=$(VERSION)") {
+		t.Error("make build does not stamp the version")
+	}
+}
+
+func TestGenerateWithPprof(t *testing.T) {
+	projectDir := generateProject(t, Options{Pprof: true})
+
+	assertFilesExist(t, projectDir, "internal/diagnostics/diagnostics.go")
+
+	if !strings.Contains(readProjectFile(t, projectDir, "cmd/server/main.go"), "diagnostics.Start()") {
+		t.Error("main.go does not start the diagnostics server")
+	}
+	if strings.Contains(readProjectFile(t, projectDir, "internal/server/routes.go"), "pprof") {
+		t.Error("pprof must not be mounted on the public router")
+	}
+	makefile := readProjectFile(t, projectDir, "Makefile")
+	for _, target := range []string{"profile-cpu:", "profile-heap:", "profile-trace:"} {
+		if !strings.Contains(makefile, target) {
+			t.Errorf("Makefile missing %s", target)
+		}
+	}
+
+	plainDir := generateProject(t, Options{})
+	assertFilesMissing(t, plainDir, "internal/diagnostics")
+	if strings.Contains(readProjectFile(t, plainDir, "Makefile"), "profile-cpu:") {
+		t.Error("Makefile has profiling targets without --pprof")
+	}
+}
+
+func TestGenerateWithLoadTest(t *testing.T) {
+	tools := map[string]string{
+		LoadTestK6:     "loadtest/k6/script.js",
+		LoadTestVegeta: "loadtest/vegeta/targets.txt",
+	}
+
+	for tool, script := range tools {
+		t.Run(tool, func(t *testing.T) {
+			projectDir := generateProject(t, Options{LoadTest: tool, IncludeDB: true})
+
+			assertFilesExist(t, projectDir, script, ".github/workflows/loadtest.yml")
+			for other, otherScript := range tools {
+				if other != tool {
+					assertFilesMissing(t, projectDir, otherScript)
+				}
+			}
+
+			if !strings.Contains(readProjectFile(t, projectDir, "Makefile"), "loadtest-docker:") {
+				t.Error("Makefile missing loadtest targets")
+			}
+			workflow := readProjectFile(t, projectDir, ".github/workflows/loadtest.yml")
+			if !strings.Contains(workflow, "workflow_dispatch") || !strings.Contains(workflow, "postgres:16-alpine") {
+				t.Error("load test workflow is not manual or lacks the database service")
+			}
+		})
+	}
+
+	plainDir := generateProject(t, Options{})
+	assertFilesMissing(t, plainDir, "loadtest", ".github/workflows/loadtest.yml")
+}
+
+func TestGenerateWithE2E(t *testing.T) {
+	projectDir := generateProject(t, Options{E2E: true, GDPR: true})
+
+	assertFilesExist(t, projectDir,
+		"e2e/package.json",
+		"e2e/playwright.config.ts",
+		"e2e/tests/home.spec.ts",
+		"e2e/tests/consent.spec.ts",
+		".github/workflows/e2e.yml",
+	)
+	assertFilesMissing(t, projectDir, "e2e/tests/search.spec.ts", "e2e/tests/blog.spec.ts")
+
+	if !strings.Contains(readProjectFile(t, projectDir, "e2e/tests/home.spec.ts"), "cookie_consent") {
+		t.Error("home spec does not dismiss the consent banner")
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, "docker-compose.yml"), `profiles: ["test"]`) {
+		t.Error("docker-compose.yml missing the e2e test profile")
+	}
+
+	plainDir := generateProject(t, Options{})
+	assertFilesMissing(t, plainDir, "e2e", ".github/workflows/e2e.yml")
+	if strings.Contains(readProjectFile(t, plainDir, "docker-compose.yml"), "playwright") {
+		t.Error("docker-compose.yml includes Playwright without --e2e")
+	}
+}
+
+func TestGenerateWithOpenAPI(t *testing.T) {
+	projectDir := generateProject(t, Options{OpenAPI: true, IncludeDB: true})
+
+	assertFilesExist(t, projectDir,
+		"api/openapi.yaml",
+		"internal/server/openapi_test.go",
+		".github/workflows/contract.yml",
+	)
+
+	spec := readProjectFile(t, projectDir, "api/openapi.yaml")
+	for _, path := range []string{"  /health:", "  /api/hello:"} {
+		if !strings.Contains(spec, path) {
+			t.Errorf("openapi.yaml does not document %s", strings.TrimSpace(path))
+		}
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, "go.mod"), "gopkg.in/yaml.v3") {
+		t.Error("go.mod missing yaml.v3 for the spec drift test")
+	}
+	workflow := readProjectFile(t, projectDir, ".github/workflows/contract.yml")
+	for _, tool := range []string{"TestRoutesMatchOpenAPISpec", "oasdiff", "contract-test"} {
+		if !strings.Contains(workflow, tool) {
+			t.Errorf("contract workflow missing %s", tool)
+		}
+	}
+
+	plainDir := generateProject(t, Options{})
+	assertFilesMissing(t, plainDir, "api", "internal/server/openapi_test.go")
+}
+
+func TestGenerateBenchmarks(t *testing.T) {
+	projectDir := generateProject(t, Options{})
+
+	assertFilesExist(t, projectDir,
+		"views/pages/pages_bench_test.go",
+		"internal/server/routes_bench_test.go",
+	)
+
+	makefile := readProjectFile(t, projectDir, "Makefile")
+	for _, target := range []string{"bench:", "bench-baseline:", "benchstat"} {
+		if !strings.Contains(makefile, target) {
+			t.Errorf("Makefile missing %s", target)
+		}
+	}
+}
+
+func TestGenerateDockerDev(t *testing.T) {
+	projectDir := generateProject(t, Options{TypeScript: true, Content: true})
+
+	assertFilesExist(t, projectDir, "docker-compose.override.yml")
+	compose := readProjectFile(t, projectDir, "docker-compose.override.yml")
+	for _, want := range []string{"target: dev", "develop:", "path: ./views", "path: ./src/ts", "path: ./content", "action: rebuild"} {
+		if !strings.Contains(compose, want) {
+			t.Errorf("docker-compose.override.yml missing %q", want)
+		}
+	}
+	dockerfile := readProjectFile(t, projectDir, "Dockerfile")
+	if !strings.Contains(dockerfile, "FROM builder AS dev") || !strings.Contains(dockerfile, "--watch=forever & air") {
+		t.Error("Dockerfile has no dev stage running esbuild and Air")
+	}
+	if strings.LastIndex(dockerfile, "FROM ") != strings.Index(dockerfile, "FROM alpine") {
+		t.Error("the runner must stay the last Dockerfile stage")
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, "Makefile"), "dev-docker:") {
+		t.Error("Makefile missing dev-docker")
+	}
+
+	plainDir := generateProject(t, Options{})
+	compose = readProjectFile(t, plainDir, "docker-compose.override.yml")
+	if strings.Contains(compose, "src/ts") || strings.Contains(compose, "VITE_DEV_URL") {
+		t.Error("docker-compose.override.yml watches features that are not selected")
+	}
+}
+
+func TestGenerateEnvProfiles(t *testing.T) {
+	projectDir := generateProject(t, Options{IncludeDB: true, Errors: ErrorsSentry, Binaries: "server,worker"})
+
+	assertFilesExist(t, projectDir, "internal/config/profile.go", "internal/config/env/development.env",
+		"internal/config/env/staging.env", "internal/config/env/production.env", "docker-compose.prod.yml")
+	assertFilesMissing(t, projectDir, "docker-compose.dev.yml")
+	if staging := readProjectFile(t, projectDir, "internal/config/env/staging.env"); !strings.Contains(staging, "GO_ENV=production") || !strings.Contains(staging, "SENTRY_ENVIRONMENT=staging") {
+		t.Errorf("staging profile:\n%s", staging)
+	}
+	for _, file := range []string{"internal/server/server.go", "cmd/worker/main.go"} {
+		if content := readProjectFile(t, projectDir, file); strings.Contains(content, "godotenv/autoload") || !strings.Contains(content, "Loads .env and the APP_ENV profile") {
+			t.Errorf("%s does not load the environment through internal/config", file)
+		}
+	}
+	if compose := readProjectFile(t, projectDir, "docker-compose.yml"); strings.Contains(compose, "GO_ENV") || !strings.Contains(compose, "APP_ENV=${APP_ENV:-production}") {
+		t.Error("docker-compose.yml does not select the profile with APP_ENV")
+	}
+	prod := readProjectFile(t, projectDir, "docker-compose.prod.yml")
+	for _, want := range []string{"path: .env.${APP_ENV:-production}", "  worker:", "ports: !reset []"} {
+		if !strings.Contains(prod, want) {
+			t.Errorf("docker-compose.prod.yml missing %q", want)
+		}
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, "Makefile"), "COMPOSE_PROD := APP_ENV=$(APP_ENV) docker compose -f docker-compose.yml -f docker-compose.prod.yml") {
+		t.Error("Makefile does not run the production stack with docker-compose.prod.yml")
+	}
+
+	plainDir := generateProject(t, Options{})
+	if prod := readProjectFile(t, plainDir, "docker-compose.prod.yml"); strings.Contains(prod, "worker") || strings.Contains(prod, "db:") {
+		t.Errorf("docker-compose.prod.yml has services that are not generated:\n%s", prod)
+	}
+}
+
+func TestGenerateSecurityAudit(t *testing.T) {
+	projectDir := generateProject(t, Options{})
+
+	assertFilesExist(t, projectDir, ".github/workflows/audit.yml")
+	makefile := readProjectFile(t, projectDir, "Makefile")
+	for _, want := range []string{"audit: audit-code audit-image", "govulncheck", "trivy image"} {
+		if !strings.Contains(makefile, want) {
+			t.Errorf("Makefile missing %s", want)
+		}
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, ".github/workflows/audit.yml"), "schedule:") {
+		t.Error("audit workflow is not scheduled")
+	}
+}
+
+func TestGenerateWithJobs(t *testing.T) {
+	projectDir := generateProject(t, Options{Jobs: true, IncludeDB: true})
+
+	assertFilesExist(t, projectDir,
+		"internal/jobs/jobs.go",
+		"internal/jobs/registry.go",
+	)
+	if !strings.Contains(readProjectFile(t, projectDir, "internal/jobs/registry.go"), workersMarker) {
+		t.Error("registry.go missing the workers marker")
+	}
+	main := readProjectFile(t, projectDir, "cmd/server/main.go")
+	for _, want := range []string{"jobs.Register(runner)", "runner.Stop(ctx)", "server.NewServer(server.WithJobs(runner))"} {
+		if !strings.Contains(main, want) {
+			t.Errorf("main.go missing %s", want)
+		}
+	}
+
+	plainDir := generateProject(t, Options{})
+	assertFilesMissing(t, plainDir, "internal/jobs")
+	if strings.Contains(readProjectFile(t, plainDir, "cmd/server/main.go"), "jobs") {
+		t.Error("main.go references jobs without --jobs")
+	}
+}
+
+func TestGenerateWithSBOM(t *testing.T) {
+	projectDir := generateProject(t, Options{SBOM: true})
+
+	assertFilesExist(t, projectDir, ".syft.yaml", ".github/workflows/sbom.yml")
+
+	makefile := readProjectFile(t, projectDir, "Makefile")
+	for _, target := range []string{"sbom-tools:", "sbom:", "sbom-image: docker-build"} {
+		if !strings.Contains(makefile, target) {
+			t.Errorf("Makefile missing %s", target)
+		}
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, ".goreleaser.yml"), "sboms:") {
+		t.Error(".goreleaser.yml does not generate release SBOMs")
+	}
+	workflow := readProjectFile(t, projectDir, ".github/workflows/sbom.yml")
+	for _, want := range []string{"make sbom", "make sbom-image", "gh release upload"} {
+		if !strings.Contains(workflow, want) {
+			t.Errorf("SBOM workflow missing %s", want)
+		}
+	}
+
+	plainDir := generateProject(t, Options{})
+	assertFilesMissing(t, plainDir, ".syft.yaml", ".github/workflows/sbom.yml")
+	if strings.Contains(readProjectFile(t, plainDir, ".goreleaser.yml"), "sboms:") {
+		t.Error(".goreleaser.yml generates SBOMs without --sbom")
+	}
+}
+
+func TestGenerateWithHTTPSDev(t *testing.T) {
+	projectDir := generateProject(t, Options{HTTPSDev: true, GDPR: true})
+
+	assertFilesExist(t, projectDir, "internal/middleware/https.go", "internal/server/https.go")
+	if !strings.Contains(readProjectFile(t, projectDir, "cmd/server/main.go"), "srv.ServeTLS(ln, certFile, keyFile)") {
+		t.Error("main.go does not serve TLS")
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, "internal/server/routes.go"), "appmiddleware.HSTSMaxAge()") {
+		t.Error("routes.go does not use the scheme-aware HSTS max-age")
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, "internal/consent/consent.go"), "Secure:   middleware.IsHTTPS(r)") {
+		t.Error("consent cookie is not Secure by scheme")
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, "internal/server/consent.go"), "consent.Save(w, r, choice)") {
+		t.Error("consent handler does not pass the request to Save")
+	}
+	makefile := readProjectFile(t, projectDir, "Makefile")
+	for _, want := range []string{"go install filippo.io/mkcert@latest", "certs: certs/localhost.pem", "mkcert -install"} {
+		if !strings.Contains(makefile, want) {
+			t.Errorf("Makefile missing %q", want)
+		}
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, ".env.example"), "TLS_CERT_FILE=certs/localhost.pem") {
+		t.Error(".env.example missing TLS_CERT_FILE")
+	}
+
+	plainDir := generateProject(t, Options{GDPR: true})
+	assertFilesMissing(t, plainDir, "internal/middleware/https.go", "internal/server/https.go")
+	if strings.Contains(readProjectFile(t, plainDir, "cmd/server/main.go"), "TLS") {
+		t.Error("main.go serves TLS without --https-dev")
+	}
+	if !strings.Contains(readProjectFile(t, plainDir, "internal/consent/consent.go"), `os.Getenv("GO_ENV") == "production"`) {
+		t.Error("consent cookie changed without --https-dev")
+	}
+}
+
+func TestGenerateCaching(t *testing.T) {
+	projectDir := generateProject(t, Options{Vite: true, BasePath: "/app"})
+
+	assertFilesExist(t, projectDir, "internal/middleware/cache.go")
+	routes := readProjectFile(t, projectDir, "internal/server/routes.go")
+	for _, want := range []string{
+		"appmiddleware.Compress(cfg.CompressionLevel)",
+		`ImmutablePrefix: "/app/assets/static/vite/assets/"`,
+		`NoStorePrefixes: []string{"/app/api/", "/app/health", "/app/ready", "/app/drain"}`,
+		"appmiddleware.ETag(fs)",
+	} {
+		if !strings.Contains(routes, want) {
+			t.Errorf("routes.go missing %q", want)
+		}
+	}
+	if strings.Contains(routes, "middleware.Compress(5)") {
+		t.Error("routes.go still uses the fixed gzip compressor")
+	}
+	config := readProjectFile(t, projectDir, "internal/config/config.go")
+	for _, env := range []string{"COMPRESSION_LEVEL", "CACHE_ASSETS_MAX_AGE", "CACHE_PAGES_MAX_AGE"} {
+		if !strings.Contains(config, env) {
+			t.Errorf("config.go does not read %s", env)
+		}
+	}
+
+	if strings.Contains(readProjectFile(t, generateProject(t, Options{}), "internal/server/routes.go"), "ImmutablePrefix") {
+		t.Error("routes.go marks Vite chunks immutable without Vite")
+	}
+}
+
+func TestGenerateWithEmbedAssets(t *testing.T) {
+	projectDir := generateProject(t, Options{EmbedAssets: true})
+
+	assertFilesExist(t, projectDir, "internal/static/static.go", "assets/compress.go")
+	if efs := readProjectFile(t, projectDir, "assets/efs.go"); !strings.Contains(efs, "//go:generate go run compress.go") || !strings.Contains(efs, "//go:embed css/output.css* js/*.js*") {
+		t.Errorf("assets/efs.go does not embed the precompressed variants:\n%s", efs)
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, "internal/server/routes.go"), "static.New(assets.Files)") {
+		t.Error("routes.go does not serve assets through the static handler")
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, "go.mod"), "github.com/andybalholm/brotli") {
+		t.Error("go.mod missing the brotli encoder")
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, "Makefile"), "go generate ./assets") {
+		t.Error("make build does not precompress assets")
+	}
+	dockerfile := readProjectFile(t, projectDir, "Dockerfile")
+	if !strings.Contains(dockerfile, "go generate ./assets") || strings.Contains(dockerfile, "COPY --from=builder /app/assets") {
+		t.Error("Dockerfile does not precompress assets or still copies them next to the binary")
+	}
+
+	plainDir := generateProject(t, Options{})
+	assertFilesMissing(t, plainDir, "internal/static/static.go", "assets/compress.go")
+	if !strings.Contains(readProjectFile(t, plainDir, "internal/server/routes.go"), "http.FileServer(http.FS(assets.Files))") {
+		t.Error("routes.go changed without --embed-assets")
+	}
+	if !strings.Contains(readProjectFile(t, plainDir, "Dockerfile"), "COPY --from=builder /app/assets/css/output.css") {
+		t.Error("Dockerfile no longer copies assets without --embed-assets")
+	}
+}
+
+func TestGenerateDBTx(t *testing.T) {
+	projectDir := generateProject(t, Options{IncludeDB: true})
+
+	tx := readProjectFile(t, projectDir, "internal/database/tx.go")
+	for _, want := range []string{
+		"func WithTx(ctx context.Context, pool *pgxpool.Pool, fn func(ctx context.Context) error) (err error)",
+		"outer.Begin(ctx) // savepoint",
+		"context.WithoutCancel(ctx)",
+		"func Conn(ctx context.Context, pool *pgxpool.Pool) Querier",
+	} {
+		if !strings.Contains(tx, want) {
+			t.Errorf("tx.go missing %q", want)
+		}
+	}
+
+	replicaDir := generateProject(t, Options{IncludeDB: true, DBReplicas: true})
+	if !strings.Contains(readProjectFile(t, replicaDir, "internal/database/replicas.go"), "return WithTx(ctx, r.primary, fn)") {
+		t.Error("Router does not run transactions on the primary")
+	}
+}
+
+func TestGenerateWithDBReplicas(t *testing.T) {
+	projectDir := generateProject(t, Options{IncludeDB: true, DBReplicas: true})
+
+	assertFilesExist(t, projectDir, "internal/database/replicas.go", "pgbouncer/pgbouncer.ini", "pgbouncer/userlist.txt")
+	db := readProjectFile(t, projectDir, "internal/database/database.go")
+	for _, want := range []string{"Router() *Router", "connectReplica()", `maxConns("DB_MAX_CONNS", 25)`} {
+		if !strings.Contains(db, want) {
+			t.Errorf("database.go missing %q", want)
+		}
+	}
+	compose := readProjectFile(t, projectDir, "docker-compose.yml")
+	for _, want := range []string{"  pgbouncer:\n", "@pgbouncer:6432/myapp", "DATABASE_READ_URL="} {
+		if !strings.Contains(compose, want) {
+			t.Errorf("docker-compose.yml missing %q", want)
+		}
+	}
+
+	plainDir := generateProject(t, Options{IncludeDB: true})
+	assertFilesMissing(t, plainDir, "internal/database/replicas.go", "pgbouncer")
+	if strings.Contains(readProjectFile(t, plainDir, "internal/database/database.go"), "Router") {
+		t.Error("database.go has a router without --db-replicas")
+	}
+
+	if err := GenerateWithOptions(Options{ProjectName: filepath.Join(t.TempDir(), "app"), DBReplicas: true}); err == nil {
+		t.Error("GenerateWithOptions() allowed read replicas without a database")
+	}
+}
+
+func TestGenerateDBBackup(t *testing.T) {
+	projectDir := generateProject(t, Options{IncludeDB: true})
+
+	makefile := readProjectFile(t, projectDir, "Makefile")
+	for _, want := range []string{"db-backup:", "pg_dump --format=custom", "db-restore:", "pg_restore --clean --if-exists"} {
+		if !strings.Contains(makefile, want) {
+			t.Errorf("Makefile missing %q", want)
+		}
+	}
+	compose := readProjectFile(t, projectDir, "docker-compose.yml")
+	if !strings.Contains(compose, "  db-backup:\n") || !strings.Contains(compose, `profiles: ["backup"]`) {
+		t.Error("docker-compose.yml missing the db-backup service")
+	}
+
+	noDBDir := generateProject(t, Options{})
+	if strings.Contains(readProjectFile(t, noDBDir, "Makefile"), "db-backup") {
+		t.Error("Makefile has db-backup without a database")
+	}
+	if strings.Contains(readProjectFile(t, noDBDir, "docker-compose.yml"), "db-backup") {
+		t.Error("docker-compose.yml has db-backup without a database")
+	}
+}
+
+func TestGenerateExampleResource(t *testing.T) {
+	projectDir := generateProject(t, Options{IncludeDB: true, ExampleResource: ExampleNote})
+
+	assertFilesExist(t, projectDir,
+		"internal/database/migrations/00001_create_notes.sql",
+		"internal/repository/repository.go",
+		"internal/repository/note.go",
+		"internal/server/notes.go",
+		"views/pages/notes.templ",
+	)
+	assertFilesMissing(t, projectDir, "internal/database/migrations/00001_init.sql", "internal/server/todos.go", "views/pages/todos.templ")
+	if repo := readProjectFile(t, projectDir, "internal/repository/note.go"); !strings.Contains(repo, "database.Conn(ctx, r.pool)") {
+		t.Error("note repository does not join database.WithTx transactions")
+	}
+	routes := readProjectFile(t, projectDir, "internal/server/routes.go")
+	for _, want := range []string{`Method: http.MethodGet, Path: "/notes", Handler: handle(s.handleNotes)}`, `Method: http.MethodDelete, Path: "/notes/{id}", Handler: handle(s.handleDeleteNote)}`} {
+		if !strings.Contains(routes, want) {
+			t.Errorf("routes.go missing %q", want)
+		}
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, "internal/server/server.go"), "repository.NewNoteRepository(s.db.GetPool())") {
+		t.Error("server.go does not create the note repository")
+	}
+	// The rendered files are recorded like the template files
+	m, errs := ValidateManifest(projectDir)
+	if len(errs) > 0 || m.Options["example-resource"] != ExampleNote || m.Files["internal/repository/note.go"] == "" {
+		t.Errorf("manifest example-resource = %q, note.go checksum %q, %v", m.Options["example-resource"], m.Files["internal/repository/note.go"], errs)
+	}
+
+	todoDir := generateProject(t, Options{IncludeDB: true, DBReplicas: true, ExampleResource: ExampleTodo})
+	assertFilesExist(t, todoDir, "internal/database/migrations/00001_create_todos.sql", "internal/repository/todo.go", "internal/server/todos.go", "views/pages/todos.templ")
+	if repo := readProjectFile(t, todoDir, "internal/repository/todo.go"); !strings.Contains(repo, "r.db.Write(ctx)") {
+		t.Error("todo repository does not route through database.Router with --db-replicas")
+	}
+
+	noneDir := generateProject(t, Options{IncludeDB: true, ExampleResource: ExampleNone})
+	assertFilesMissing(t, noneDir, "internal/database/migrations/00001_init.sql", "internal/repository", "internal/server/notes.go")
+	assertFilesExist(t, noneDir, "internal/database/migrations")
+
+	defaultDir := generateProject(t, Options{IncludeDB: true})
+	assertFilesExist(t, defaultDir, "internal/database/migrations/00001_init.sql")
+	assertFilesMissing(t, defaultDir, "internal/repository")
+
+	if err := GenerateWithOptions(Options{ProjectName: filepath.Join(t.TempDir(), "app"), ExampleResource: ExampleTodo}); err == nil {
+		t.Error("GenerateWithOptions() allowed an example CRUD without a database")
+	}
+}
+
+func TestGenerateWithSystemdDeploy(t *testing.T) {
+	projectDir := generateProject(t, Options{DeployProvider: DeploySystemd, IncludeDB: true})
+
+	assertFilesExist(t, projectDir, "deploy/systemd/app.service", "deploy/systemd/install.sh", "deploy/systemd/app.env.example")
+	assertFilesMissing(t, projectDir, "deploy/Caddyfile", "deploy/deploy.sh", "deploy/setup-server.sh", "deploy/app.service")
+	unit := readProjectFile(t, projectDir, "deploy/systemd/app.service")
+	for _, want := range []string{"EnvironmentFile=/etc/myapp/myapp.env", "ProtectSystem=strict", "CapabilityBoundingSet=", "SystemCallFilter=@system-service"} {
+		if !strings.Contains(unit, want) {
+			t.Errorf("app.service missing %q", want)
+		}
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, "deploy/systemd/app.env.example"), "DATABASE_URL=") {
+		t.Error("app.env.example missing DATABASE_URL")
+	}
+	makefile := readProjectFile(t, projectDir, "Makefile")
+	for _, want := range []string{"deploy-ssh:", "rsync -az --delete", "deploy-env:"} {
+		if !strings.Contains(makefile, want) {
+			t.Errorf("Makefile missing %q", want)
+		}
+	}
+	if strings.Contains(makefile, "deploy/deploy.sh") {
+		t.Error("Makefile has Hetzner deploy targets with --deploy systemd")
+	}
+
+	hetznerDir := generateProject(t, Options{DeployProvider: DeployHetznerCaddy})
+	assertFilesExist(t, hetznerDir, "deploy/Caddyfile", "deploy/app.service")
+	assertFilesMissing(t, hetznerDir, "deploy/systemd")
+}
+
+func TestGenerateWithProxy(t *testing.T) {
+	for proxy, config := range map[string]string{
+		ProxyCaddy:   "proxy/caddy/Caddyfile",
+		ProxyNginx:   "proxy/nginx/templates/default.conf.template",
+		ProxyTraefik: "proxy/traefik/dynamic.yml",
+	} {
+		t.Run(proxy, func(t *testing.T) {
+			projectDir := generateProject(t, Options{Proxy: proxy})
+
+			assertFilesExist(t, projectDir, config)
+			compose := readProjectFile(t, projectDir, "docker-compose.yml")
+			for _, want := range []string{"  proxy:\n", `"443:443"`, "DOMAIN=${DOMAIN:-localhost}"} {
+				if !strings.Contains(compose, want) {
+					t.Errorf("docker-compose.yml missing %q", want)
+				}
+			}
+			if !strings.Contains(readProjectFile(t, projectDir, config), "/assets/") {
+				t.Errorf("%s does not cache assets", config)
+			}
+			if !strings.Contains(readProjectFile(t, projectDir, "Makefile"), "proxy-up:") {
+				t.Error("Makefile missing proxy-up")
+			}
+			for _, other := range []string{ProxyCaddy, ProxyNginx, ProxyTraefik} {
+				if other != proxy {
+					assertFilesMissing(t, projectDir, "proxy/"+other)
+				}
+			}
+		})
+	}
+
+	plainDir := generateProject(t, Options{})
+	assertFilesMissing(t, plainDir, "proxy")
+	if strings.Contains(readProjectFile(t, plainDir, "docker-compose.yml"), "proxy:") {
+		t.Error("docker-compose.yml has a proxy service without --proxy")
+	}
+}
+
+func TestGenerateZeroDowntime(t *testing.T) {
+	projectDir := generateProject(t, Options{Proxy: ProxyNginx, DeployProvider: DeploySystemd})
+
+	assertFilesExist(t, projectDir, "internal/server/drain.go", "internal/server/listen.go", "deploy/rollout.sh", "deploy/systemd/app.socket")
+	main := readProjectFile(t, projectDir, "cmd/server/main.go")
+	for _, want := range []string{"server.Listen(srv.Addr)", "srv.Serve(ln)", "server.Drain()", `os.Getenv("DRAIN_DELAY")`} {
+		if !strings.Contains(main, want) {
+			t.Errorf("main.go missing %q", want)
+		}
+	}
+	routes := readProjectFile(t, projectDir, "internal/server/routes.go")
+	for _, want := range []string{`Path: "/ready", Handler: s.handleReady`, `Method: http.MethodPost, Path: "/drain"`} {
+		if !strings.Contains(routes, want) {
+			t.Errorf("routes.go missing %q", want)
+		}
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, "deploy/rollout.sh"), "nginx -s reload") {
+		t.Error("rollout.sh does not reload nginx")
+	}
+	prod := readProjectFile(t, projectDir, "docker-compose.prod.yml")
+	for _, want := range []string{"ports: !reset []", "http://localhost:8080/ready", "stop_grace_period:"} {
+		if !strings.Contains(prod, want) {
+			t.Errorf("docker-compose.prod.yml missing %q", want)
+		}
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, "Makefile"), "rollout:") {
+		t.Error("Makefile missing rollout")
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, "deploy/systemd/app.service"), "Requires=myapp.socket") {
+		t.Error("app.service is not socket activated")
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, "deploy/systemd/install.sh"), `"$RELEASE_DIR/systemd/app.socket"`) {
+		t.Error("install.sh does not install the socket unit")
+	}
+
+	plainDir := generateProject(t, Options{})
+	assertFilesExist(t, plainDir, "internal/server/drain.go")
+	assertFilesMissing(t, plainDir, "deploy")
+	if strings.Contains(readProjectFile(t, plainDir, "docker-compose.prod.yml"), "DRAIN_DELAY") {
+		t.Error("docker-compose.prod.yml sets up compose rollouts without --proxy")
+	}
+}
+
+func TestGenerateWithSign(t *testing.T) {
+	projectDir := generateProject(t, Options{Sign: true, SBOM: true})
+
+	assertFilesExist(t, projectDir, ".github/workflows/release.yml")
+	workflow := readProjectFile(t, projectDir, ".github/workflows/release.yml")
+	for _, want := range []string{"id-token: write", "cosign sign --yes", "attest-build-provenance", "download-syft"} {
+		if !strings.Contains(workflow, want) {
+			t.Errorf("release workflow missing %s", want)
+		}
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, ".goreleaser.yml"), "signs:") {
+		t.Error(".goreleaser.yml does not sign checksums")
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, "Makefile"), "verify-image:") {
+		t.Error("Makefile missing verify-image")
+	}
+
+	noSBOM := generateProject(t, Options{Sign: true})
+	if strings.Contains(readProjectFile(t, noSBOM, ".github/workflows/release.yml"), "syft") {
+		t.Error("release workflow installs syft without --sbom")
+	}
+
+	plainDir := generateProject(t, Options{})
+	assertFilesMissing(t, plainDir, ".github/workflows/release.yml")
+	if strings.Contains(readProjectFile(t, plainDir, ".goreleaser.yml"), "cosign") {
+		t.Error(".goreleaser.yml signs without --sign")
+	}
+}
+
+func TestGenerateLintPresets(t *testing.T) {
+	tests := []struct {
+		preset       string
+		want, absent []string
+	}{
+		{LintStrict, []string{"- wsl", "- gosec", "min-complexity: 15", "funlen:"}, nil},
+		{LintStandard, []string{"- gosec", "min-complexity: 25"}, []string{"- wsl", "funlen:", "- style"}},
+		{LintMinimal, []string{"- errcheck", "- staticcheck"}, []string{"- gosec", "linters-settings:"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.preset, func(t *testing.T) {
+			projectDir := generateProject(t, Options{Lint: tt.preset})
+			config := readProjectFile(t, projectDir, ".golangci.yml")
+			if !strings.Contains(config, "("+tt.preset+" preset)") {
+				t.Error(".golangci.yml does not name its preset")
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(config, want) {
+					t.Errorf(".golangci.yml missing %q", want)
+				}
+			}
+			for _, absent := range tt.absent {
+				if strings.Contains(config, absent) {
+					t.Errorf(".golangci.yml contains %q", absent)
+				}
+			}
+		})
+	}
+
+	// The default matches the config shipped before presets existed
+	defaultDir := generateProject(t, Options{})
+	if !strings.Contains(readProjectFile(t, defaultDir, ".golangci.yml"), "(strict preset)") {
+		t.Error("default lint preset is not strict")
+	}
+}
+
+func TestGenerateLintGoVersion(t *testing.T) {
+	for _, channel := range Channels() {
+		projectDir := generateProject(t, Options{Channel: channel})
+		goMod := readProjectFile(t, projectDir, "go.mod")
+		match := regexp.MustCompile(`(?m)^go (\S+)$`).FindStringSubmatch(goMod)
+		if match == nil {
+			t.Fatalf("%s: go.mod has no go directive", channel)
+		}
+		if config := readProjectFile(t, projectDir, ".golangci.yml"); !strings.Contains(config, `go: "`+match[1]+`"`) {
+			t.Errorf("%s: .golangci.yml run.go does not match go %s", channel, match[1])
+		}
+	}
+}
+
+func TestGenerateChannels(t *testing.T) {
+	stable := generateProject(t, Options{})
+	edge := generateProject(t, Options{Channel: ChannelEdge})
+
+	tests := []struct {
+		file, stable, edge string
+	}{
+		{"go.mod", "\ngo 1.23\n", "\ngo 1.24\n"},
+		{"Dockerfile", "golang:1.23-alpine", "golang:1.24-alpine"},
+		{"views/pages/pages_bench_test.go", "i < b.N", "for b.Loop() {"},
+		{"internal/server/routes_bench_test.go", "i < b.N", "b.Loop(); i++"},
+	}
+	for _, tt := range tests {
+		if !strings.Contains(readProjectFile(t, stable, tt.file), tt.stable) {
+			t.Errorf("stable %s missing %q", tt.file, tt.stable)
+		}
+		if !strings.Contains(readProjectFile(t, edge, tt.file), tt.edge) {
+			t.Errorf("edge %s missing %q", tt.file, tt.edge)
+		}
+	}
+
+	for dir, channel := range map[string]string{stable: ChannelStable, edge: ChannelEdge} {
+		m, errs := ValidateManifest(dir)
+		if len(errs) > 0 {
+			t.Fatalf("ValidateManifest() = %v", errs)
+		}
+		if m.Template.Channel != channel || m.Template.Version != bundleVersions[channel] {
+			t.Errorf("manifest template = %+v, want channel %s at %s", m.Template, channel, bundleVersions[channel])
+		}
+	}
+
+	err := GenerateWithOptions(Options{ProjectName: filepath.Join(t.TempDir(), "app"), ModulePath: "github.com/test/app", Channel: "nightly"})
+	if err == nil || !strings.Contains(err.Error(), "unknown template channel") {
+		t.Errorf("GenerateWithOptions() with an unknown channel error = %v", err)
+	}
+}
+
+func TestGenerateGoVersion(t *testing.T) {
+	projectDir := generateProject(t, Options{GoVersion: "go1.25.1"})
+
+	tests := []struct {
+		file, want string
+	}{
+		{"go.mod", "\ngo 1.25\n\ntoolchain go1.25.1\n"},
+		{"Dockerfile", "FROM golang:1.25.1-alpine"},
+		{".golangci.yml", `go: "1.25"`},
+		{"internal/server/routes_bench_test.go", "b.Loop()"},
+	}
+	for _, tt := range tests {
+		if !strings.Contains(readProjectFile(t, projectDir, tt.file), tt.want) {
+			t.Errorf("%s missing %q", tt.file, tt.want)
+		}
+	}
+	m, errs := ValidateManifest(projectDir)
+	if len(errs) > 0 || m.Options["go-version"] != "1.25.1" {
+		t.Errorf("manifest go-version = %q, %v", m.Options["go-version"], errs)
+	}
+
+	// Without a patch release there is no toolchain line, and Go 1.23 has
+	// no testing.B.Loop
+	oldDir := generateProject(t, Options{GoVersion: "1.23", Channel: ChannelEdge})
+	if goMod := readProjectFile(t, oldDir, "go.mod"); !strings.Contains(goMod, "\ngo 1.23\n") || strings.Contains(goMod, "toolchain") {
+		t.Errorf("go.mod for 1.23 = %q", goMod)
+	}
+	if strings.Contains(readProjectFile(t, oldDir, "internal/server/routes_bench_test.go"), "b.Loop()") {
+		t.Error("benchmarks use b.Loop with Go 1.23")
+	}
+
+	for _, bad := range []string{"1.22", "2.0", "latest"} {
+		err := GenerateWithOptions(Options{ProjectName: filepath.Join(t.TempDir(), "app"), ModulePath: "github.com/test/app", GoVersion: bad})
+		if err == nil {
+			t.Errorf("GenerateWithOptions() with Go %q succeeded", bad)
+		}
+	}
+}
+
+func TestGenerateBasePath(t *testing.T) {
+	projectDir := generateProject(t, Options{BasePath: "app/", Proxy: ProxyCaddy, E2E: true})
+
+	tests := []struct {
+		file, want string
+	}{
+		{"internal/server/routes.go", `root.Mount("/app", r)`},
+		{"internal/server/routes.go", `http.StripPrefix("/app/assets", fs)`},
+		{"views/layouts/base.templ", `<script src="/app/assets/js/htmx.min.js"></script>`},
+		{"views/layouts/base.templ", `href="/app/assets/css/output.css"`},
+		{"views/pages/index.templ", `hx-get="/app/api/hello"`},
+		{"assets/static/manifest.json", `"scope": "/app/"`},
+		{"proxy/caddy/Caddyfile", "redir / /app/"},
+		{"proxy/caddy/Caddyfile", "@assets path /app/assets/*"},
+		{"e2e/tests/home.spec.ts", "page.goto('/app/')"},
+	}
+	for _, tt := range tests {
+		if !strings.Contains(readProjectFile(t, projectDir, tt.file), tt.want) {
+			t.Errorf("%s missing %q", tt.file, tt.want)
+		}
+	}
+	m, errs := ValidateManifest(projectDir)
+	if len(errs) > 0 || m.Options["base-path"] != "/app" {
+		t.Errorf("manifest base-path = %q, %v", m.Options["base-path"], errs)
+	}
+
+	rootDir := generateProject(t, Options{})
+	if routes := readProjectFile(t, rootDir, "internal/server/routes.go"); strings.Contains(routes, "root.Mount(") || !strings.Contains(routes, `http.StripPrefix("/assets", fs)`) {
+		t.Error("routes.go without --base-path is not served from the root")
+	}
+	if strings.Contains(readProjectFile(t, rootDir, "assets/static/manifest.json"), `"scope"`) {
+		t.Error("manifest.json has a scope without --base-path")
+	}
+
+	for _, bad := range []string{"/app?x", "/a b", "/app/../etc", "/health", "/ready"} {
+		if _, err := ParseBasePath(bad); err == nil {
+			t.Errorf("ParseBasePath(%q) succeeded, want an error", bad)
+		}
+	}
+	if p, err := ParseBasePath("/"); err != nil || p != "" {
+		t.Errorf(`ParseBasePath("/") = %q, %v`, p, err)
+	}
+}
+
+func TestGenerateBinaries(t *testing.T) {
+	projectDir := generateProject(t, Options{IncludeDB: true, Binaries: "cli, worker"})
+
+	tests := []struct {
+		file, want string
+	}{
+		{"cmd/worker/main.go", "func runOnce(ctx context.Context, db database.Service) error"},
+		{"cmd/cli/main.go", commandsMarker},
+		{"go.mod", cobraModule + " " + cobraVersion},
+		{"Dockerfile", "FROM alpine:3.19 AS worker"},
+		{"Dockerfile", "FROM alpine:3.19 AS cli"},
+		{"docker-compose.yml", "target: worker"},
+		{"Makefile", "run-worker:"},
+		{"Makefile", "run-cli:"},
+		{".goreleaser.yml", "main: ./cmd/cli"},
+	}
+	for _, tt := range tests {
+		if !strings.Contains(readProjectFile(t, projectDir, tt.file), tt.want) {
+			t.Errorf("%s missing %q", tt.file, tt.want)
+		}
+	}
+	dockerfile := readProjectFile(t, projectDir, "Dockerfile")
+	if !strings.HasPrefix(dockerfile[strings.LastIndex(dockerfile, "FROM "):], "FROM alpine:3.19 AS runner") {
+		t.Error("runner is not the last Dockerfile stage, so it is no longer the default target")
+	}
+	m, errs := ValidateManifest(projectDir)
+	if len(errs) > 0 || m.Options["binaries"] != "server,worker,cli" {
+		t.Errorf("manifest binaries = %q, %v", m.Options["binaries"], errs)
+	}
+
+	// `add command` extends the generated CLI
+	if _, err := AddCommand(projectDir, "backfill"); err != nil {
+		t.Fatalf("AddCommand() error: %v", err)
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, "cmd/cli/main.go"), "rootCmd.AddCommand(newBackfillCmd())") {
+		t.Error("add command did not register in the generated CLI")
+	}
+
+	serverDir := generateProject(t, Options{})
+	if _, err := os.Stat(filepath.Join(serverDir, "cmd", "worker")); !os.IsNotExist(err) {
+		t.Error("cmd/worker generated without --binaries worker")
+	}
+	if strings.Contains(readProjectFile(t, serverDir, "docker-compose.yml"), "worker:") {
+		t.Error("docker-compose.yml has a worker service without --binaries worker")
+	}
+
+	if _, err := ParseBinaries("server,cron"); err == nil {
+		t.Error(`ParseBinaries("server,cron") succeeded, want an error`)
+	}
+}
+
+func TestGenerateRouteRegistry(t *testing.T) {
+	projectDir := generateProject(t, Options{SEO: true})
+
+	assertFilesExist(t, projectDir, "internal/router/router.go")
+	routes := readProjectFile(t, projectDir, "internal/server/routes.go")
+	for _, want := range []string{
+		"router.Mount(r, s.Routes())",
+		"func (s *Server) Routes() []router.Route {",
+		`{Name: "home", Method: http.MethodGet, Path: "/", Handler: handle(s.handleHome)},`,
+		"seo.SitemapHandler(router.Paths(routes, http.MethodGet)",
+	} {
+		if !strings.Contains(routes, want) {
+			t.Errorf("routes.go missing %q", want)
+		}
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, "cmd/server/main.go"), "router.Print(os.Stdout, (&server.Server{}).Routes())") {
+		t.Error("cmd/server has no routes subcommand")
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, "Makefile"), "go run ./cmd/server routes") {
+		t.Error("Makefile has no routes target")
+	}
+
+	r, err := BuildReport(projectDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, route := range r.Routes {
+		found = found || route == Route{"GET", "/sitemap.xml", `seo.SitemapHandler(router.Paths(routes, http.MethodGet), "/api", "/health", "/ready")`}
+	}
+	if !found {
+		t.Errorf("report routes do not include the registry's sitemap entry: %v", r.Routes)
+	}
+}
+
+func TestGenerateServerOptions(t *testing.T) {
+	projectDir := generateProject(t, Options{IncludeDB: true, Jobs: true})
+
+	srv := readProjectFile(t, projectDir, "internal/server/server.go")
+	for _, want := range []string{
+		"type Option func(*Server)",
+		"func NewServer(opts ...Option) *http.Server {",
+		"func WithDB(db database.Service) Option {",
+		"func WithJobs(runner *jobs.Runner) Option {",
+	} {
+		if !strings.Contains(srv, want) {
+			t.Errorf("server.go missing %q", want)
+		}
+	}
+}
+
+func TestGenerateHandlerErrors(t *testing.T) {
+	projectDir := generateProject(t, Options{IncludeDB: true, ExampleResource: ExampleNote})
+
+	assertFilesExist(t, projectDir, "internal/logging/logging.go", "internal/server/handler.go")
+	routes := readProjectFile(t, projectDir, "internal/server/routes.go")
+	for _, want := range []string{"r.Use(logging.Middleware)", "r.NotFound(handle("} {
+		if !strings.Contains(routes, want) {
+			t.Errorf("routes.go missing %q", want)
+		}
+	}
+	notes := readProjectFile(t, projectDir, "internal/server/notes.go")
+	if !strings.Contains(notes, "func (s *Server) handleNotes(w http.ResponseWriter, r *http.Request) error {") {
+		t.Error("notes handlers do not return errors")
+	}
+	if strings.Contains(notes, "http.Error(") {
+		t.Error("notes handlers still write errors themselves")
+	}
+}
+
+func TestGenerateLean(t *testing.T) {
+	projectDir := generateProject(t, Options{IncludeDB: true, Lean: true})
+
+	assertFilesMissing(t, projectDir, "assets/static/sw.js", "assets/static/manifest.json", "internal/database/migrations/00001_init.sql")
+	checks := map[string][]string{
+		"views/layouts/base.templ": {"serviceWorker", "manifest.json"},
+		"views/pages/index.templ":  {"featureCard", "HTMX in Action"},
+		"assets/efs.go":            {"static/*"},
+		"Dockerfile":               {"/app/assets/static"},
+		"README.md":                {"## 📁 Project Structure", "## 📱 PWA Support", "## 📚 Resources"},
+	}
+	for file, unwanted := range checks {
+		content := readProjectFile(t, projectDir, file)
+		for _, s := range unwanted {
+			if strings.Contains(content, s) {
+				t.Errorf("lean %s contains %q", file, s)
+			}
+		}
+	}
+
+	// Vite still builds into assets/static
+	viteDir := generateProject(t, Options{Lean: true, Vite: true})
+	if efs := readProjectFile(t, viteDir, "assets/efs.go"); !strings.Contains(efs, "static/*") {
+		t.Error("lean Vite project does not embed assets/static")
+	}
+}
+
+func TestGenerateDataTable(t *testing.T) {
+	dataTableFiles := []string{"internal/repository/datatable.go", "internal/server/datatable.go", "views/pages/datatable.templ"}
+	assertFilesMissing(t, generateProject(t, Options{IncludeDB: true, ExampleResource: ExampleNote}), dataTableFiles...)
+
+	projectDir := generateProject(t, Options{IncludeDB: true, ExampleResource: ExampleNote, DataTable: true, SEO: true})
+	assertFilesExist(t, projectDir, dataTableFiles...)
+	checks := map[string][]string{
+		"internal/repository/datatable.go": {"func (r *NoteRepository) Table(", "database.Conn(ctx, r.pool)", `var NoteTableSorts = []string{"id", "title", "created_at", "updated_at"}`},
+		"internal/server/datatable.go":     {"func (s *Server) handleNotesTable(", "func (s *Server) handleNotesCSV(", `"/notes/table"`},
+		"internal/server/routes.go":        {`Path: "/notes/table", Handler: handle(s.handleNotesTable)`, `Path: "/notes/table.csv"`, `"/ready", "/notes/table.csv")`},
+		"views/components/navbar.templ":    {`href="/notes/table"`},
+		"views/pages/datatable.templ":      {`tableClass         = "table table-zebra"`, "templ DataTableResults(t DataTable)", "seo.PageMeta{"},
+		"README.md":                        {"### Data Table", "`/notes/table`"},
+	}
+	for file, wants := range checks {
+		content := readProjectFile(t, projectDir, file)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s missing %q", file, want)
+			}
+		}
+		if strings.Contains(content, "Todo") || strings.Contains(content, "todos") {
+			t.Errorf("%s mentions todos in a notes project", file)
+		}
+	}
+
+	projectDir = generateProject(t, Options{IncludeDB: true, DBReplicas: true, ExampleResource: ExampleTodo, DataTable: true, CSSFramework: CSSFrameworkBasecoat})
+	for file, want := range map[string]string{
+		"internal/repository/datatable.go": "r.db.Read(ctx)",
+		"internal/server/datatable.go":     "func (s *Server) handleTodosCSV(",
+		"views/pages/datatable.templ":      `tableCheckboxClass = "input"`,
+	} {
+		if content := readProjectFile(t, projectDir, file); !strings.Contains(content, want) || strings.Contains(content, "Note") {
+			t.Errorf("%s missing %q or mentions notes", file, want)
+		}
+	}
+
+	if err := GenerateWithOptions(Options{ProjectName: filepath.Join(t.TempDir(), "app"), ModulePath: "github.com/test/app", IncludeDB: true, DataTable: true}); err == nil {
+		t.Error("generated a data table without an example resource")
+	}
+}
+
+func TestGenerateImportExport(t *testing.T) {
+	importExportFiles := []string{"internal/importexport/importexport.go", "internal/importexport/xlsx.go", "internal/importexport/exports.go", "internal/repository/importexport.go", "internal/server/importexport.go", "views/pages/importexport.templ"}
+	assertFilesMissing(t, generateProject(t, Options{IncludeDB: true, ExampleResource: ExampleNote}), importExportFiles...)
+
+	projectDir := generateProject(t, Options{IncludeDB: true, ExampleResource: ExampleNote, ImportExport: true, SEO: true})
+	assertFilesExist(t, projectDir, importExportFiles...)
+	assertFilesMissing(t, projectDir, "internal/repository/datatable.go")
+	checks := map[string][]string{
+		"internal/repository/importexport.go": {"func (r *NoteRepository) ListAfter(", "database.Conn(ctx, r.pool)"},
+		"internal/server/importexport.go":     {"func (s *Server) handleImportNotes(", "func (s *Server) exportNotes(", "database.WithTx(ctx, s.db.GetPool(), fn)"},
+		"internal/server/server.go":           {"exports *importexport.Exports", "s.exports = importexport.NewExports()"},
+		"internal/server/routes.go":           {`Path: "/notes/import", Handler: handle(s.handleImportNotes)`, `Path: "/notes/export.xlsx"`, `Path: "/notes/exports/{id}/download"`, `"/ready", "/notes/export.")`},
+		"views/components/navbar.templ":       {`href="/notes/import-export"`},
+		"views/pages/importexport.templ":      {`importProgressClass = "progress progress-primary w-full"`, `hx-trigger="every 1s"`, "seo.PageMeta{"},
+		"README.md":                           {"### Import & Export", "`/notes/import-export`"},
+	}
+	for file, wants := range checks {
+		content := readProjectFile(t, projectDir, file)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s missing %q", file, want)
+			}
+		}
+		if strings.Contains(content, "Todo") || strings.Contains(content, "todos") {
+			t.Errorf("%s mentions todos in a notes project", file)
+		}
+	}
+
+	projectDir = generateProject(t, Options{IncludeDB: true, DBReplicas: true, ExampleResource: ExampleTodo, ImportExport: true, CSSFramework: CSSFrameworkBasecoat})
+	for file, want := range map[string]string{
+		"internal/repository/importexport.go": "r.db.Read(ctx)",
+		"internal/server/importexport.go":     "s.db.Router().WithTx(ctx, fn)",
+		"views/pages/importexport.templ":      `importErrorClass    = "alert-destructive"`,
+	} {
+		if content := readProjectFile(t, projectDir, file); !strings.Contains(content, want) || strings.Contains(content, "Note") {
+			t.Errorf("%s missing %q or mentions notes", file, want)
+		}
+	}
+
+	if err := GenerateWithOptions(Options{ProjectName: filepath.Join(t.TempDir(), "app"), ModulePath: "github.com/test/app", IncludeDB: true, ImportExport: true}); err == nil {
+		t.Error("generated import and export without an example resource")
+	}
+}
+
+func TestGeneratePDF(t *testing.T) {
+	pdfFiles := []string{"internal/pdf/pdf.go", "internal/server/invoice.go", "views/documents/invoice.templ"}
+	plainDir := generateProject(t, Options{})
+	assertFilesMissing(t, plainDir, pdfFiles...)
+	if strings.Contains(readProjectFile(t, plainDir, "go.mod"), "chromedp") {
+		t.Error("go.mod requires chromedp without --pdf")
+	}
+
+	projectDir := generateProject(t, Options{PDF: true, SEO: true})
+	assertFilesExist(t, projectDir, pdfFiles...)
+	checks := map[string][]string{
+		"go.mod":                    {"github.com/chromedp/chromedp", "github.com/chromedp/cdproto"},
+		"internal/server/server.go": {"pdf  *pdf.Renderer", "renderer, err := pdf.New()", `"log"`},
+		"internal/server/routes.go": {`Path: "/invoices/sample.pdf", Handler: handle(s.handleInvoicePDF)`, `"/ready", "/invoices")`},
+		"docker-compose.yml":        {"CHROME_URL=ws://chrome:9222", "image: chromedp/headless-shell:", "depends_on:"},
+		".env.example":              {"CHROME_URL=", "PDF_CONCURRENCY=2"},
+		"Makefile":                  {"chrome: ##"},
+		"README.md":                 {"## 📄 PDF Documents"},
+	}
+	for file, wants := range checks {
+		content := readProjectFile(t, projectDir, file)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s missing %q", file, want)
+			}
+		}
+	}
+}
+
+func TestGenerateImages(t *testing.T) {
+	imageFiles := []string{"internal/images/images.go", "internal/images/signing.go", "internal/server/images.go", "views/components/picture.templ", "views/pages/images.templ"}
+	plainDir := generateProject(t, Options{})
+	assertFilesMissing(t, plainDir, imageFiles...)
+	if strings.Contains(readProjectFile(t, plainDir, ".gitignore"), "data/") {
+		t.Error(".gitignore ignores data/ without a feature that writes there")
+	}
+
+	projectDir := generateProject(t, Options{Images: true, Search: SearchBleve, BasePath: "/app"})
+	assertFilesExist(t, projectDir, imageFiles...)
+	checks := map[string][]string{
+		"go.mod":                         {"github.com/gen2brain/webp", "golang.org/x/image"},
+		"internal/images/signing.go":     {`const Prefix = "/app/images/"`},
+		"internal/server/server.go":      {"images *images.Store", "store, err := images.New()"},
+		"internal/server/routes.go":      {`Path: "/images/{id}/{file}", Handler: handle(s.handleImage)`},
+		"views/components/picture.templ": {`<source type="image/webp" srcset={ p.WebP } sizes={ p.Sizes }/>`},
+		"docker-compose.yml":             {"    volumes:\n      - search_data:/data\n      - images_data:/app/data/images\n", "  images_data:\n"},
+		"Dockerfile":                     {"RUN mkdir -p data/images"},
+		".env.example":                   {"IMAGES_SIGNING_KEY="},
+		".gitignore":                     {"data/"},
+		"README.md":                      {"## 🖼️ Image Uploads"},
+	}
+	for file, wants := range checks {
+		content := readProjectFile(t, projectDir, file)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s missing %q", file, want)
+			}
+		}
+	}
+}
+
+func TestGenerateNotifications(t *testing.T) {
+	notifyFiles := []string{"internal/notify/notify.go", "internal/notify/hub.go", "internal/notify/webhook.go", "internal/server/notifications.go", "views/components/notifications.templ", "views/pages/notifications.templ", "internal/database/migrations/00003_notifications.sql"}
+	plainDir := generateProject(t, Options{})
+	assertFilesMissing(t, plainDir, notifyFiles...)
+	if strings.Contains(readProjectFile(t, plainDir, "views/components/navbar.templ"), "NotificationBell") {
+		t.Error("navbar has a notification bell without --notifications")
+	}
+
+	if _, err := prepareOptions(Options{Notifications: true, IncludeDB: false}); err == nil {
+		t.Error("notifications without the database should be rejected")
+	}
+
+	projectDir := generateProject(t, Options{Notifications: true, IncludeDB: true, Jobs: true, BasePath: "/app"})
+	assertFilesExist(t, projectDir, append(notifyFiles, "internal/notify/jobs.go")...)
+	checks := map[string][]string{
+		"internal/server/server.go":            {"notifier *notify.Notifier", "s.notifier = notify.New(s.db.GetPool())", "notify.RegisterJobs(s.jobs, s.notifier)"},
+		"internal/server/routes.go":            {`Path: "/notifications/stream", Handler: handle(s.handleNotificationStream)`, `Path: "/notifications/{id}/read"`, `"/app/notifications"}`},
+		"cmd/server/main.go":                   {"srv := server.NewServer(server.WithJobs(runner))\n\trunner.Start()"},
+		"views/components/navbar.templ":        {"<li>@NotificationBell()</li>"},
+		"views/components/notifications.templ": {`new EventSource('/app/notifications/stream')`, `hx-trigger="load, notifications from:body"`},
+		".env.example":                         {"NOTIFY_WEBHOOK_URL="},
+		"README.md":                            {"## 🔔 Notifications", "notify.JobKind"},
+	}
+	for file, wants := range checks {
+		content := readProjectFile(t, projectDir, file)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s missing %q", file, want)
+			}
+		}
+	}
+
+	noJobsDir := generateProject(t, Options{Notifications: true, IncludeDB: true})
+	assertFilesMissing(t, noJobsDir, "internal/notify/jobs.go")
+	if strings.Contains(readProjectFile(t, noJobsDir, "internal/server/server.go"), "RegisterJobs") {
+		t.Error("server registers the notify job without --jobs")
+	}
+}
+
+func TestGenerateActivity(t *testing.T) {
+	activityFiles := []string{"internal/activity/activity.go", "internal/server/activity.go", "views/components/timeline.templ", "views/pages/activity.templ", "internal/database/migrations/00004_activity.sql"}
+	plainDir := generateProject(t, Options{IncludeDB: true, ExampleResource: ExampleNote})
+	assertFilesMissing(t, plainDir, activityFiles...)
+	if strings.Contains(readProjectFile(t, plainDir, "internal/server/notes.go"), "s.record(") {
+		t.Error("notes handlers record activity without --activity")
+	}
+
+	projectDir := generateProject(t, Options{Activity: true, IncludeDB: true, ExampleResource: ExampleTodo})
+	assertFilesExist(t, projectDir, activityFiles...)
+	assertFilesMissing(t, projectDir, "internal/activity/notify.go")
+	checks := map[string][]string{
+		"internal/server/server.go":       {"activity *activity.Recorder", "s.activity = activity.New(s.db.GetPool())"},
+		"internal/server/routes.go":       {`Path: "/activity", Handler: handle(s.handleActivity)`},
+		"internal/server/todos.go":        {`SubjectType: "todo"`, `verb = "completed"`, `Verb: "deleted"`},
+		"views/components/timeline.templ": {`hx-trigger="revealed"`},
+		"views/components/navbar.templ":   {`href="/activity"`},
+		"README.md":                       {"## 🕘 Activity Feed"},
+	}
+	for file, wants := range checks {
+		content := readProjectFile(t, projectDir, file)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s missing %q", file, want)
+			}
+		}
+	}
+	if strings.Contains(readProjectFile(t, projectDir, "internal/server/server.go"), "activity.Notify") {
+		t.Error("activity notifies recipients without --notifications")
+	}
+
+	notifyDir := generateProject(t, Options{Activity: true, Notifications: true, IncludeDB: true})
+	assertFilesExist(t, notifyDir, "internal/activity/notify.go")
+	if !strings.Contains(readProjectFile(t, notifyDir, "internal/server/server.go"), "s.activity.Hook(activity.Notify(s.notifier))") {
+		t.Error("activity does not notify event recipients with --notifications")
+	}
+}
+
+func TestGenerateSettings(t *testing.T) {
+	settingsFiles := []string{"internal/settings/settings.go", "internal/settings/tokens.go", "internal/settings/theme.go", "internal/server/settings.go", "views/pages/settings.templ", "internal/database/migrations/00005_settings.sql"}
+	plainDir := generateProject(t, Options{IncludeDB: true})
+	assertFilesMissing(t, plainDir, settingsFiles...)
+	if !strings.Contains(readProjectFile(t, plainDir, "views/layouts/base.templ"), `data-theme="dark"`) {
+		t.Error("layout lost its default theme without --settings")
+	}
+	if !strings.Contains(readProjectFile(t, plainDir, "go.mod"), "golang.org/x/crypto v0.31.0 // indirect") {
+		t.Error("go.mod lost the indirect x/crypto requirement")
+	}
+
+	for _, opts := range []Options{{Settings: true}, {Settings: true, IncludeDB: true, ExampleResource: ExampleNote}, {Settings: true, IncludeDB: true, Lean: true}} {
+		if _, err := prepareOptions(opts); err == nil {
+			t.Errorf("settings without the users table should be rejected: %+v", opts)
+		}
+	}
+
+	projectDir := generateProject(t, Options{Settings: true, IncludeDB: true})
+	assertFilesExist(t, projectDir, settingsFiles...)
+	checks := map[string][]string{
+		"go.mod":                        {"\tgolang.org/x/crypto v0.31.0\n"},
+		"internal/server/server.go":     {"settings *settings.Store", "s.settings = settings.New(s.db.GetPool())"},
+		"internal/server/routes.go":     {"r.Use(settings.Middleware)", `Path: "/settings/tokens/{id}", Handler: handle(s.handleRevokeToken)`},
+		"views/layouts/base.templ":      {`data-theme={ settings.Theme(ctx) }`},
+		"views/components/navbar.templ": {`href="/settings"`},
+		".env.example":                  {"DEV_USER="},
+		"README.md":                     {"## 👤 Account Settings"},
+	}
+	for file, wants := range checks {
+		content := readProjectFile(t, projectDir, file)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s missing %q", file, want)
+			}
+		}
+	}
+	if strings.Contains(readProjectFile(t, projectDir, "go.mod"), "x/crypto v0.31.0 // indirect") {
+		t.Error("go.mod lists x/crypto as indirect although settings import it")
+	}
+}
+
+func TestGenerateOnboarding(t *testing.T) {
+	onboardingFiles := []string{"internal/onboarding/onboarding.go", "internal/server/onboarding.go", "views/components/progress.templ", "views/pages/onboarding.templ", "internal/database/migrations/00006_onboarding.sql"}
+	assertFilesMissing(t, generateProject(t, Options{IncludeDB: true}), onboardingFiles...)
+
+	if _, err := prepareOptions(Options{Onboarding: true}); err == nil {
+		t.Error("onboarding without the database should be rejected")
+	}
+
+	projectDir := generateProject(t, Options{Onboarding: true, IncludeDB: true})
+	assertFilesExist(t, projectDir, onboardingFiles...)
+	checks := map[string][]string{
+		"internal/server/server.go":       {"onboarding *onboarding.Store", "s.onboarding = onboarding.New(s.db.GetPool())"},
+		"internal/server/routes.go":       {`Path: "/onboarding/steps/{step}", Handler: handle(s.handleOnboardingStep)`},
+		"views/components/navbar.templ":   {`href="/onboarding"`},
+		"views/components/progress.templ": {`progressListClass    = "steps w-full"`},
+		"views/pages/onboarding.templ":    {`wizardInputClass    = "input input-bordered w-full"`, `hx-target="#onboarding-wizard"`},
+		"README.md":                       {"## 🧭 Onboarding Wizard"},
+	}
+	for file, wants := range checks {
+		content := readProjectFile(t, projectDir, file)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s missing %q", file, want)
+			}
+		}
+	}
+
+	basecoatDir := generateProject(t, Options{Onboarding: true, IncludeDB: true, CSSFramework: CSSFrameworkBasecoat})
+	if page := readProjectFile(t, basecoatDir, "views/pages/onboarding.templ"); !strings.Contains(page, `wizardInputClass    = "input w-full"`) || strings.Contains(page, "input-bordered") {
+		t.Error("onboarding page not styled for Basecoat")
+	}
+	if progress := readProjectFile(t, basecoatDir, "views/components/progress.templ"); strings.Contains(progress, `"steps w-full"`) {
+		t.Error("progress indicator uses DaisyUI steps with Basecoat")
+	}
+}
+
+func TestGenerateTeams(t *testing.T) {
+	teamsFiles := []string{"internal/teams/teams.go", "internal/teams/invitations.go", "internal/server/teams.go", "views/pages/teams.templ", "internal/database/migrations/00007_teams.sql"}
+	plainDir := generateProject(t, Options{IncludeDB: true})
+	assertFilesMissing(t, plainDir, append(teamsFiles, "internal/server/users.go")...)
+	if strings.Contains(readProjectFile(t, plainDir, ".env.example"), "DEV_USER=") {
+		t.Error(".env.example has DEV_USER without a feature using accounts")
+	}
+
+	for _, opts := range []Options{{Teams: true}, {Teams: true, IncludeDB: true, ExampleResource: ExampleTodo}} {
+		if _, err := prepareOptions(opts); err == nil {
+			t.Errorf("teams without the users table should be rejected: %+v", opts)
+		}
+	}
+
+	projectDir := generateProject(t, Options{Teams: true, IncludeDB: true})
+	assertFilesExist(t, projectDir, append(teamsFiles, "internal/server/users.go")...)
+	assertFilesMissing(t, projectDir, "internal/settings/settings.go", "internal/server/settings.go")
+	checks := map[string][]string{
+		"internal/server/server.go":     {"teams *teams.Store", "s.teams = teams.New(s.db.GetPool())"},
+		"internal/server/routes.go":     {`Path: "/teams/{slug}/members/{user}/role", Handler: handle(s.handleSetRole)`, `Path: "/invitations/{token}", Handler: handle(s.handleAcceptInvitation)`},
+		"views/components/navbar.templ": {`href="/teams"`},
+		".env.example":                  {"DEV_USER="},
+		"README.md":                     {"## 👥 Teams"},
+	}
+	for file, wants := range checks {
+		content := readProjectFile(t, projectDir, file)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s missing %q", file, want)
+			}
+		}
+	}
+}
+
+func TestGenerateBilling(t *testing.T) {
+	billingFiles := []string{"internal/billing/billing.go", "internal/billing/stripe.go", "internal/billing/webhook.go", "internal/server/billing.go", "views/pages/pricing.templ", "internal/database/migrations/00008_billing.sql"}
+	assertFilesMissing(t, generateProject(t, Options{IncludeDB: true}), billingFiles...)
+
+	for _, opts := range []Options{{Billing: true}, {Billing: true, IncludeDB: true, ExampleResource: ExampleNote}} {
+		if _, err := prepareOptions(opts); err == nil {
+			t.Errorf("billing without the users table should be rejected: %+v", opts)
+		}
+	}
+
+	projectDir := generateProject(t, Options{Billing: true, IncludeDB: true})
+	assertFilesExist(t, projectDir, append(billingFiles, "internal/server/users.go")...)
+	assertFilesMissing(t, projectDir, "internal/teams/teams.go", "internal/server/teams.go")
+	checks := map[string][]string{
+		"internal/server/server.go":                      {"billing *billing.Store", "s.stripe = billing.NewClient()"},
+		"internal/server/routes.go":                      {`Path: "/pricing", Handler: handle(s.handlePricing)`, `Path: "/billing/webhook", Handler: handle(s.handleStripeWebhook)`},
+		"internal/database/migrations/00008_billing.sql": {"CREATE TABLE IF NOT EXISTS subscriptions", "('free', 'Free'"},
+		"views/components/navbar.templ":                  {`href="/pricing"`},
+		".env.example":                                   {"DEV_USER=", "STRIPE_SECRET_KEY=", "STRIPE_WEBHOOK_SECRET="},
+		"README.md":                                      {"## 💳 Billing", "stripe listen --forward-to localhost:8080/billing/webhook"},
+	}
+	for file, wants := range checks {
+		content := readProjectFile(t, projectDir, file)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s missing %q", file, want)
+			}
+		}
+	}
+}
+
+func TestGenerateDemo(t *testing.T) {
+	demoFiles := []string{"internal/demo/demo.go", "cmd/demo/main.go", "cmd/demo/screenshots.go", "views/components/demo.templ"}
+	assertFilesMissing(t, generateProject(t, Options{IncludeDB: true}), append(demoFiles, "internal/demo/seed.go")...)
+
+	projectDir := generateProject(t, Options{Demo: true, IncludeDB: true, ExampleResource: ExampleTodo})
+	assertFilesExist(t, projectDir, append(demoFiles, "internal/demo/seed.go")...)
+	checks := map[string][]string{
+		"internal/demo/seed.go":     {"INSERT INTO todos", "WHERE NOT EXISTS (SELECT 1 FROM todos)"},
+		"cmd/demo/main.go":          {`case "seed":`, "demo.Seed(context.Background(), db.GetPool())"},
+		"cmd/demo/screenshots.go":   {`"/todos",`},
+		"internal/server/routes.go": {"r.Use(demo.Middleware)"},
+		"views/layouts/base.templ":  {"@components.DemoBanner()"},
+		"go.mod":                    {"github.com/go-rod/rod"},
+		"Makefile":                  {"demo-seed:", "screenshots:"},
+		".env.example":              {"DEMO_MODE=false"},
+		"README.md":                 {"## 🎬 Demo Mode & Screenshots"},
+	}
+	for file, wants := range checks {
+		content := readProjectFile(t, projectDir, file)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s missing %q", file, want)
+			}
+		}
+	}
+	if strings.Contains(readProjectFile(t, projectDir, "internal/demo/seed.go"), "INSERT INTO users") {
+		t.Error("demo seeds users without the users example")
+	}
+
+	// Without the database there is nothing to seed
+	projectDir = generateProject(t, Options{Demo: true})
+	assertFilesExist(t, projectDir, demoFiles...)
+	assertFilesMissing(t, projectDir, "internal/demo/seed.go")
+	for file, unwanted := range map[string]string{"cmd/demo/main.go": "internal/database", "Makefile": "demo-seed"} {
+		if strings.Contains(readProjectFile(t, projectDir, file), unwanted) {
+			t.Errorf("%s has %q without the database", file, unwanted)
+		}
+	}
+}
+
+func TestGenerateSingleBinary(t *testing.T) {
+	projectDir := generateProject(t, Options{SingleBinary: true, Litestream: true, Binaries: "server,worker"})
+	assertFilesExist(t, projectDir, "internal/database/sqlite.go", "internal/database/migrations/README.md", "internal/database/migrations/00001_init.sql", "internal/static", "litestream.yml")
+	assertFilesMissing(t, projectDir, "internal/database/database.go", "internal/database/tx.go")
+	checks := map[string][]string{
+		"internal/database/sqlite.go":                 {"//go:embed migrations", `sql.Open("sqlite"`, `goose.SetDialect("sqlite3")`, "journal_mode(WAL)"},
+		"internal/database/migrations/00001_init.sql": {"randomblob(16)"},
+		"cmd/worker/main.go":                          {"db.DB().PingContext(ctx)"},
+		"go.mod":                                      {"modernc.org/sqlite", "github.com/pressly/goose/v3"},
+		"Makefile":                                    {"GOOSE_DRIVER := sqlite3", "sqlite3 \"$(DB_DSN)\" \".backup", "litestream replicate -config litestream.yml"},
+		"docker-compose.yml":                          {"DATABASE_URL=/app/data/app.db", "app_data:/app/data"},
+		".env.example":                                {"DATABASE_URL=data/app.db", "LITESTREAM_REPLICA_URL="},
+		"README.md":                                   {"## 📦 Single Binary", "**Litestream**"},
+	}
+	for file, wants := range checks {
+		content := readProjectFile(t, projectDir, file)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s missing %q", file, want)
+			}
+		}
+	}
+	for _, file := range []string{"go.mod", "docker-compose.yml", ".env.example", "Makefile", "internal/database/migrations/00001_init.sql"} {
+		content := readProjectFile(t, projectDir, file)
+		if strings.Contains(content, "pgx") || strings.Contains(content, "postgres") || strings.Contains(content, "gen_random_uuid") {
+			t.Errorf("%s still refers to Postgres", file)
+		}
+	}
+
+	// Postgres keeps its files, and Litestream needs SQLite
+	projectDir = generateProject(t, Options{IncludeDB: true})
+	assertFilesExist(t, projectDir, "internal/database/database.go", "internal/database/tx.go")
+	assertFilesMissing(t, projectDir, "internal/database/sqlite.go", "internal/database/migrations/README.md", "litestream.yml")
+	if _, err := prepareOptions(Options{Litestream: true}); err == nil {
+		t.Error("accepted litestream without the single binary")
+	}
+	for _, opts := range []Options{
+		{SingleBinary: true, ExampleResource: ExampleNote},
+		{SingleBinary: true, Search: SearchPgTrgm},
+		{SingleBinary: true, DBReplicas: true},
+		{SingleBinary: true, Billing: true},
+	} {
+		if _, err := prepareOptions(opts); err == nil {
+			t.Errorf("accepted a Postgres-only feature with the single binary: %+v", opts)
+		}
+	}
+}
+
+func TestGenerateSQLiteReplication(t *testing.T) {
+	projectDir := generateProject(t, Options{SingleBinary: true, SQLiteReplication: SQLiteReplicationLiteFS})
+	assertFilesExist(t, projectDir, "litefs.yml", "fly.toml", "internal/database/litefs.go", "internal/middleware/litefs.go")
+	checks := map[string][]string{
+		"internal/database/sqlite.go":   {`sql.Open("sqlite"`, "Primary()"},
+		"internal/server/routes.go":     {"r.Use(appmiddleware.ForwardWrites)"},
+		"internal/middleware/litefs.go": {`"fly-replay"`, "httputil.NewSingleHostReverseProxy"},
+		"Dockerfile":                    {"AS litefs", `ENTRYPOINT ["litefs", "mount"]`},
+		"fly.toml":                      {`build-target = "litefs"`, `DATABASE_URL = "/litefs/app.db"`, "[mounts]"},
+		"Makefile":                      {"fly-deploy:"},
+	}
+	for file, wants := range checks {
+		content := readProjectFile(t, projectDir, file)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Errorf("litefs: %s missing %q", file, want)
+			}
+		}
+	}
+
+	projectDir = generateProject(t, Options{SingleBinary: true, SQLiteReplication: SQLiteReplicationTurso, E2E: true})
+	assertFilesExist(t, projectDir, "fly.toml", "internal/database/sqlite.go")
+	assertFilesMissing(t, projectDir, "litefs.yml", "internal/database/litefs.go", "internal/middleware/litefs.go")
+	checks = map[string][]string{
+		"internal/database/sqlite.go": {`sql.Open("libsql"`, "TURSO_AUTH_TOKEN", `goose.SetDialect("turso")`},
+		"go.mod":                      {"github.com/tursodatabase/libsql-client-go"},
+		"Makefile":                    {"turso-dev:", "GOOSE_DRIVER := turso"},
+		".env.example":                {"TURSO_AUTH_TOKEN="},
+		".github/workflows/e2e.yml":   {"libsql-server"},
+	}
+	for file, wants := range checks {
+		content := readProjectFile(t, projectDir, file)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Errorf("turso: %s missing %q", file, want)
+			}
+		}
+	}
+	if strings.Contains(readProjectFile(t, projectDir, "go.mod"), "modernc.org/sqlite") {
+		t.Error("turso: go.mod still requires the SQLite file driver")
+	}
+	if strings.Contains(readProjectFile(t, projectDir, "Dockerfile"), "AS litefs") {
+		t.Error("turso: Dockerfile has the LiteFS target")
+	}
+
+	for _, opts := range []Options{
+		{IncludeDB: true, SQLiteReplication: SQLiteReplicationTurso},
+		{SingleBinary: true, Litestream: true, SQLiteReplication: SQLiteReplicationLiteFS},
+	} {
+		if _, err := prepareOptions(opts); err == nil {
+			t.Errorf("accepted an invalid replication setup: %+v", opts)
+		}
+	}
+}
+
+func TestGenerateDatabaseBackends(t *testing.T) {
+	projectDir := generateProject(t, Options{Database: DatabaseMySQL, Binaries: "server,worker", E2E: true})
+	assertFilesExist(t, projectDir, "internal/database/mysql.go", "internal/database/retry.go", "internal/database/migrations/00001_init.sql")
+	assertFilesMissing(t, projectDir, "internal/database/database.go", "internal/database/sqlite.go", "internal/database/tx.go", "internal/database/migrations/README.md")
+	checks := map[string][]string{
+		"internal/database/mysql.go":                  {"mysql.ParseDSN(dsn)", "config.ParseTime = true", "sql.OpenDB(connector)"},
+		"internal/database/migrations/00001_init.sql": {"DEFAULT (UUID())", "UNIQUE INDEX idx_users_email"},
+		"cmd/worker/main.go":                          {"db.DB().PingContext(ctx)"},
+		"go.mod":                                      {"github.com/go-sql-driver/mysql"},
+		"Makefile":                                    {"GOOSE_DRIVER := mysql", "mysqldump"},
+		"docker-compose.yml":                          {"image: mysql:8.4", "DATABASE_URL=root:mysql@tcp(db:3306)/myapp", "mysql_data:"},
+		".env.example":                                {"DATABASE_URL=root:mysql@tcp(localhost:3306)/myapp"},
+		".github/workflows/e2e.yml":                   {"image: mysql:8.4"},
+		"README.md":                                   {"## 🐬 MySQL"},
+	}
+	for file, wants := range checks {
+		content := readProjectFile(t, projectDir, file)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Errorf("mysql: %s missing %q", file, want)
+			}
+		}
+	}
+	for _, file := range []string{"go.mod", "docker-compose.yml", ".env.example", "Makefile", "internal/database/migrations/00001_init.sql"} {
+		content := readProjectFile(t, projectDir, file)
+		if strings.Contains(content, "pgx") || strings.Contains(content, "postgres") || strings.Contains(content, "gen_random_uuid") {
+			t.Errorf("mysql: %s still refers to Postgres", file)
+		}
+	}
+
+	// SQLite without the single binary keeps the assets on disk
+	projectDir = generateProject(t, Options{Database: DatabaseSQLite, Litestream: true})
+	assertFilesExist(t, projectDir, "internal/database/sqlite.go", "litestream.yml")
+	assertFilesMissing(t, projectDir, "internal/database/database.go", "internal/static")
+	if readme := readProjectFile(t, projectDir, "README.md"); !strings.Contains(readme, "## 🪶 SQLite") || strings.Contains(readme, "Single Binary") {
+		t.Error("sqlite: README describes the single binary")
+	}
+
+	// Manifests written before --db recorded it as a bool
+	var opts Options
+	if errs := setOptions(&opts, map[string]string{"db": "true"}, ""); len(errs) > 0 || databaseOf(opts) != DatabasePostgres {
+		t.Errorf("db: true = %q, %v", databaseOf(opts), errs)
+	}
+	opts = Options{IncludeDB: true}
+	if errs := setOptions(&opts, map[string]string{"db": "false"}, ""); len(errs) > 0 || databaseOf(opts) != DatabaseNone {
+		t.Errorf("db: false = %q, %v", databaseOf(opts), errs)
+	}
+
+	for _, opts := range []Options{
+		{Database: "oracle"},
+		{Database: DatabaseMySQL, SingleBinary: true},
+		{Database: DatabaseMySQL, ExampleResource: ExampleNote},
+		{Database: DatabaseMySQL, AutoMigrate: true},
+		{Database: DatabaseMySQL, Litestream: true},
+		{Database: DatabaseSQLite, AutoMigrate: true},
+		{Database: DatabaseNone, Jobs: true, Factories: true},
+	} {
+		if _, err := prepareOptions(opts); err == nil {
+			t.Errorf("accepted %+v", opts)
+		}
+	}
+}
+
+func TestGeneratePostGIS(t *testing.T) {
+	projectDir := generateProject(t, Options{IncludeDB: true, PostGIS: true, E2E: true})
+	assertFilesExist(t, projectDir, "internal/places/places.go", "internal/places/geojson.go", "internal/server/places.go", "views/pages/places.templ", "internal/database/migrations/00009_places.sql")
+	checks := map[string][]string{
+		"internal/database/migrations/00009_places.sql": {"CREATE EXTENSION IF NOT EXISTS postgis", "GEOGRAPHY(POINT, 4326)", "USING GIST (location)"},
+		"internal/places/places.go":                     {"ST_DWithin(location, origin.point, $3)"},
+		"internal/server/routes.go":                     {`Path: "/places.geojson"`, "https://tile.openstreetmap.org"},
+		"internal/server/server.go":                     {"s.places = places.New(s.db.GetPool())"},
+		"views/pages/places.templ":                      {"leaflet@1.9.4", "/places.geojson?lat="},
+		"views/components/navbar.templ":                 {`href="/places"`},
+		"docker-compose.yml":                            {"image: postgis/postgis:16-3.4-alpine"},
+		".github/workflows/e2e.yml":                     {"image: postgis/postgis:16-3.4-alpine"},
+	}
+	for file, wants := range checks {
+		content := readProjectFile(t, projectDir, file)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s missing %q", file, want)
+			}
+		}
+	}
+
+	projectDir = generateProject(t, Options{IncludeDB: true})
+	assertFilesMissing(t, projectDir, "internal/places", "internal/server/places.go", "views/pages/places.templ", "internal/database/migrations/00009_places.sql")
+	if compose := readProjectFile(t, projectDir, "docker-compose.yml"); strings.Contains(compose, "postgis") || !strings.Contains(compose, "image: postgres:16-alpine") {
+		t.Error("docker-compose.yml uses PostGIS without --postgis")
+	}
+	for _, opts := range []Options{{PostGIS: true}, {SingleBinary: true, PostGIS: true}} {
+		if _, err := prepareOptions(opts); err == nil {
+			t.Errorf("accepted PostGIS without Postgres: %+v", opts)
+		}
+	}
+}
+
+func TestGenerateFTS(t *testing.T) {
+	projectDir := generateProject(t, Options{IncludeDB: true, ExampleResource: ExampleNote, FTS: true})
+	assertFilesExist(t, projectDir, "internal/repository/fts.go", "internal/server/fts.go", "views/pages/fts.templ", "internal/database/migrations/00010_fts.sql")
+	checks := map[string][]string{
+		"internal/database/migrations/00010_fts.sql": {"ALTER TABLE notes ADD COLUMN IF NOT EXISTS search_vector tsvector", "BEFORE INSERT OR UPDATE OF title, body ON notes", "USING GIN (search_vector)"},
+		"internal/repository/fts.go":                 {"func (r *NoteRepository) Search(", "ts_rank_cd(search_vector, q)", "ts_headline('english', body, q, $3)"},
+		"internal/server/routes.go":                  {`Path: "/notes/search"`, `Path: "/notes/search/results"`},
+		"views/pages/fts.templ":                      {`hx-get={ path + "/results" }`, "<mark"},
+	}
+	for file, wants := range checks {
+		content := readProjectFile(t, projectDir, file)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s missing %q", file, want)
+			}
+		}
+	}
+	if strings.Contains(readProjectFile(t, projectDir, "internal/repository/fts.go"), "TodoRepository") {
+		t.Error("fts.go searches todos in a notes project")
+	}
+
+	projectDir = generateProject(t, Options{IncludeDB: true, ExampleResource: ExampleTodo, FTS: true})
+	if migration := readProjectFile(t, projectDir, "internal/database/migrations/00010_fts.sql"); !strings.Contains(migration, "ON todos") || strings.Contains(migration, "notes") {
+		t.Error("todo FTS migration does not target the todos table")
+	}
+	if _, err := prepareOptions(Options{IncludeDB: true, FTS: true}); err == nil {
+		t.Error("accepted full-text search without the note or todo example")
+	}
+}
+
+func TestGenerateAutoMigrate(t *testing.T) {
+	projectDir := generateProject(t, Options{IncludeDB: true, ExampleResource: ExampleNone, AutoMigrate: true})
+	assertFilesExist(t, projectDir, "internal/database/migrate.go", "internal/database/migrations/README.md")
+	checks := map[string][]string{
+		"internal/database/migrate.go": {"//go:embed migrations", "lock.NewPostgresSessionLocker()", "goose.WithSessionLocker(locker)"},
+		"cmd/server/main.go":           {"database.Migrate(context.Background(), database.New().GetPool())"},
+		"go.mod":                       {"github.com/pressly/goose/v3"},
+		".env.example":                 {"# AUTO_MIGRATE=true"},
+		"Makefile":                     {"db-up:"},
+	}
+	for file, wants := range checks {
+		content := readProjectFile(t, projectDir, file)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s missing %q", file, want)
+			}
+		}
+	}
+
+	projectDir = generateProject(t, Options{IncludeDB: true})
+	assertFilesMissing(t, projectDir, "internal/database/migrate.go", "internal/database/migrations/README.md")
+	if strings.Contains(readProjectFile(t, projectDir, "go.mod"), "goose") {
+		t.Error("go.mod requires goose without --auto-migrate")
+	}
+	for _, opts := range []Options{{AutoMigrate: true}, {SingleBinary: true, AutoMigrate: true}} {
+		if _, err := prepareOptions(opts); err == nil {
+			t.Errorf("accepted auto-migrate without Postgres: %+v", opts)
+		}
+	}
+}
+
+func TestGenerateDevDBTestcontainers(t *testing.T) {
+	projectDir := generateProject(t, Options{IncludeDB: true, PostGIS: true, DevDB: DevDBTestcontainers})
+	assertFilesExist(t, projectDir, "cmd/devstack/main.go")
+	checks := map[string][]string{
+		"cmd/devstack/main.go": {`const postgresImage = "postgis/postgis:16-3.4-alpine"`, "postgres.Run(ctx, postgresImage", `"DATABASE_URL="+dsn`, "testcontainers.TerminateContainer(c)"},
+		"Makefile":             {"go run ./cmd/devstack -- $(MAKE) dev-local", "dev-local: db-up"},
+		"go.mod":               {"github.com/testcontainers/testcontainers-go/modules/postgres"},
+		"README.md":            {"### Disposable Postgres"},
+	}
+	for file, wants := range checks {
+		content := readProjectFile(t, projectDir, file)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s missing %q", file, want)
+			}
+		}
+	}
+
+	projectDir = generateProject(t, Options{IncludeDB: true})
+	assertFilesMissing(t, projectDir, "cmd/devstack")
+	if makefile := readProjectFile(t, projectDir, "Makefile"); strings.Contains(makefile, "devstack") || !strings.Contains(makefile, "dev: ## Start development server with live reload (using Air)") {
+		t.Error("Makefile runs devstack without --dev-db testcontainers")
+	}
+	for _, opts := range []Options{{DevDB: DevDBTestcontainers}, {SingleBinary: true, DevDB: DevDBTestcontainers}} {
+		if _, err := prepareOptions(opts); err == nil {
+			t.Errorf("accepted the testcontainers dev database without Postgres: %+v", opts)
+		}
+	}
+}
+
+func TestGenerateStartupRetry(t *testing.T) {
+	projectDir := generateProject(t, Options{IncludeDB: true, DBReplicas: true, Proxy: ProxyCaddy, OpenAPI: true})
+	assertFilesExist(t, projectDir, "internal/database/retry.go")
+	checks := map[string][]string{
+		"internal/database/retry.go":    {`os.Getenv("STARTUP_TIMEOUT")`, "backoff = min(backoff*2, maxBackoff)"},
+		"internal/database/database.go": {`waitFor("database", db.Ping)`},
+		"internal/database/replicas.go": {`waitFor("read replica", pool.Ping)`},
+		"internal/server/drain.go":      {`s.db.Health()["status"] != "healthy"`},
+		"docker-compose.yml":            {"start_period: 60s", "    depends_on:\n      app:\n        condition: service_healthy"},
+		"api/openapi.yaml":              {"enum: [ready, draining, unavailable]"},
+	}
+	for file, wants := range checks {
+		content := readProjectFile(t, projectDir, file)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s missing %q", file, want)
+			}
+		}
+	}
+
+	// A SQLite file is opened, not waited for
+	projectDir = generateProject(t, Options{SingleBinary: true})
+	assertFilesMissing(t, projectDir, "internal/database/retry.go")
+	if strings.Contains(readProjectFile(t, projectDir, ".env.example"), "STARTUP_TIMEOUT") {
+		t.Error(".env.example has STARTUP_TIMEOUT for a SQLite file")
+	}
+	projectDir = generateProject(t, Options{SingleBinary: true, SQLiteReplication: SQLiteReplicationTurso})
+	if !strings.Contains(readProjectFile(t, projectDir, "internal/database/sqlite.go"), `waitFor("database", db.PingContext)`) {
+		t.Error("sqlite.go does not wait for Turso")
+	}
+}
+
+func TestGenerateReadmeLanguage(t *testing.T) {
+	readme := readProjectFile(t, generateProject(t, Options{}), "README.md")
+	if !strings.Contains(readme, "## 🚀 Quick Start\n\n```bash\n# 1. Install tools") || strings.Contains(readme, "snippet") {
+		t.Error("README quick start is not in English")
+	}
+
+	projectDir := generateProject(t, Options{Lang: "pt"})
+	readme = readProjectFile(t, projectDir, "README.md")
+	for _, want := range []string{"## 🚀 Início rápido", "# 2. Copiar o ficheiro de ambiente", "Abra [http://localhost:8080]", "`make setup` runs `goforge tools install`"} {
+		if !strings.Contains(readme, want) {
+			t.Errorf("pt README missing %q", want)
+		}
+	}
+	if strings.Contains(readme, "## 🚀 Quick Start") {
+		t.Error("pt README has the English quick start")
+	}
+	m, err := manifest.Load(projectDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Options["lang"] != "pt" {
+		t.Errorf("manifest lang = %q", m.Options["lang"])
+	}
+
+	if err := GenerateWithOptions(Options{ProjectName: filepath.Join(t.TempDir(), "app"), ModulePath: "github.com/test/app", Lang: "fr"}); err == nil {
+		t.Error("generated a project with an unsupported language")
+	}
+}
+
+func TestDockerfileLayerCaching(t *testing.T) {
+	projectDir := generateProject(t, Options{})
+	dockerfile := readProjectFile(t, projectDir, "Dockerfile")
+	if !strings.HasPrefix(dockerfile, "# syntax=docker/dockerfile:1\n") {
+		t.Error("Dockerfile does not declare the syntax that cache mounts need")
+	}
+
+	// Everything before the source copy must not depend on the sources
+	order := []string{"COPY go.mod go.sum ./", "go mod download", "go install github.com/a-h/templ/cmd/templ", "htmx.min.js", "COPY . .", "input.css", "go build"}
+	last := -1
+	for _, step := range order {
+		i := strings.Index(dockerfile, step)
+		if i < last {
+			t.Errorf("Dockerfile runs %q out of order", step)
+		}
+		last = i
+	}
+	for _, mount := range []string{"--mount=type=cache,target=/go/pkg/mod/cache/download go mod download", "--mount=type=cache,target=/root/.cache/go-build \\\n    CGO_ENABLED=0"} {
+		if !strings.Contains(dockerfile, mount) {
+			t.Errorf("Dockerfile missing cache mount %q", mount)
+		}
+	}
+}
+
+func TestGenerateFactories(t *testing.T) {
+	projectDir := generateProject(t, Options{IncludeDB: true, DBReplicas: true, ExampleResource: ExampleNote, Factories: true})
+	assertFilesExist(t, projectDir,
+		"pkg/factory/factory.go",
+		"pkg/factory/note.go",
+		"internal/database/dbtest/dbtest.go",
+		"internal/repository/note_test.go",
+	)
+	checks := map[string][]string{
+		"pkg/factory/note.go":                {"func Note() *NoteBuilder", `Title: fmt.Sprintf("title %d", n),`, "func (b *NoteBuilder) WithBody(v string) *NoteBuilder"},
+		"internal/repository/note_test.go":   {"ctx, pool := dbtest.Tx(t)", "repository.NewNoteRepository(database.NewRouter(pool, nil))", "factory.Note().Create(ctx, t, repo)"},
+		"internal/database/dbtest/dbtest.go": {`os.Getenv("TEST_DATABASE_URL")`, "return database.ContextWithTx(ctx, tx), pool"},
+		"internal/database/tx.go":            {"func ContextWithTx(ctx context.Context, tx pgx.Tx) context.Context"},
+		"Makefile":                           {"test-db: ##"},
+		"README.md":                          {"## 🧪 Test Data & Database Tests"},
+	}
+	for file, wants := range checks {
+		content := readProjectFile(t, projectDir, file)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s missing %q", file, want)
+			}
+		}
+	}
+
+	projectDir = generateProject(t, Options{IncludeDB: true, ExampleResource: ExampleNote})
+	assertFilesMissing(t, projectDir, "pkg/factory", "internal/database/dbtest", "internal/repository/note_test.go")
+	if strings.Contains(readProjectFile(t, projectDir, "internal/database/tx.go"), "ContextWithTx") {
+		t.Error("tx.go has ContextWithTx without --factories")
+	}
+	for _, opts := range []Options{{Factories: true}, {SingleBinary: true, Factories: true}} {
+		if _, err := prepareOptions(opts); err == nil {
+			t.Errorf("accepted test factories without Postgres: %+v", opts)
+		}
+	}
+}
+
+func TestGenerateMocks(t *testing.T) {
+	projectDir := generateProject(t, Options{IncludeDB: true, ExampleResource: ExampleNote, FTS: true, Mocks: MocksMockery})
+	assertFilesExist(t, projectDir, ".mockery.yaml", "internal/server/stores.go", "internal/server/mocks_test.go")
+	checks := map[string][]string{
+		".mockery.yaml":                 {"  github.com/test/app/internal/database:", "mockname: MockDatabase", "      NoteStore:"},
+		"internal/server/stores.go":     {"type NoteStore interface", "Search(ctx context.Context, query string, limit int) ([]repository.SearchHit, error)", "var _ NoteStore = (*repository.NoteRepository)(nil)"},
+		"internal/server/server.go":     {"notes NoteStore"},
+		"internal/server/mocks_test.go": {"mocks.NewMockNoteStore(t)", "var anyContext = mock.Anything"},
+		"Makefile":                      {"go run github.com/vektra/mockery/v2@$(MOCKERY_VERSION)", "test: mocks ## Run tests"},
+		"go.mod":                        {"github.com/stretchr/testify"},
+		".gitignore":                    {"internal/mocks/"},
+	}
+	for file, wants := range checks {
+		content := readProjectFile(t, projectDir, file)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s missing %q", file, want)
+			}
+		}
+	}
+
+	projectDir = generateProject(t, Options{IncludeDB: true, ExampleResource: ExampleTodo, Mocks: MocksGomock})
+	assertFilesMissing(t, projectDir, ".mockery.yaml")
+	checks = map[string][]string{
+		"internal/server/stores.go":     {"//go:generate go run go.uber.org/mock/mockgen -destination=../mocks/todo_store.go -package=mocks . TodoStore"},
+		"internal/database/database.go": {"-mock_names=Service=MockDatabase . Service"},
+		"internal/server/mocks_test.go": {"mocks.NewMockTodoStore(gomock.NewController(t))", "var anyContext = gomock.Any()"},
+		"Makefile":                      {"go generate -run mockgen ./..."},
+		"go.mod":                        {"go.uber.org/mock"},
+	}
+	for file, wants := range checks {
+		content := readProjectFile(t, projectDir, file)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s missing %q", file, want)
+			}
+		}
+	}
+
+	// Without an example resource only the database is mocked
+	projectDir = generateProject(t, Options{IncludeDB: true, Mocks: MocksGomock})
+	assertFilesMissing(t, projectDir, "internal/server/stores.go")
+	if test := readProjectFile(t, projectDir, "internal/server/mocks_test.go"); strings.Contains(test, "repository") {
+		t.Errorf("mocks_test.go uses the example resource:\n%s", test)
+	}
+
+	projectDir = generateProject(t, Options{IncludeDB: true, ExampleResource: ExampleNote})
+	assertFilesMissing(t, projectDir, "internal/server/stores.go", "internal/server/mocks_test.go")
+	if !strings.Contains(readProjectFile(t, projectDir, "internal/server/server.go"), "notes *repository.NoteRepository") {
+		t.Error("server.go uses NoteStore without --mocks")
+	}
+	if _, err := prepareOptions(Options{Mocks: MocksMockery}); err == nil {
+		t.Error("accepted mocks without the database")
+	}
+}
+
+func TestGenerateFuzz(t *testing.T) {
+	projectDir := generateProject(t, Options{IncludeDB: true, Fuzz: true, Onboarding: true, ExampleResource: ExampleNote, ImportExport: true, Content: true})
+	assertFilesExist(t, projectDir,
+		"pkg/helpers/validate_fuzz_test.go",
+		"internal/onboarding/onboarding_fuzz_test.go",
+		"internal/importexport/importexport_fuzz_test.go",
+		"internal/blog/blog_fuzz_test.go",
+		".github/workflows/fuzz.yml",
+	)
+	checks := map[string][]string{
+		"pkg/helpers/validate_fuzz_test.go": {"func FuzzSanitizeString(f *testing.F)"},
+		"Makefile":                          {"FUZZTIME ?= 30s", `go test -run='^$$' -fuzz="^$$fn$$" -fuzztime=$(FUZZTIME) $$dir`},
+		".github/workflows/fuzz.yml":        {"make ci-setup fuzz FUZZTIME="},
+		"README.md":                         {"## 🐛 Fuzz Tests", "`internal/onboarding` `FuzzValidate`"},
+	}
+	for file, wants := range checks {
+		content := readProjectFile(t, projectDir, file)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s missing %q", file, want)
+			}
+		}
+	}
+
+	// Only the helpers are fuzzed without the features that take input
+	projectDir = generateProject(t, Options{Fuzz: true})
+	assertFilesExist(t, projectDir, "pkg/helpers/validate_fuzz_test.go")
+	assertFilesMissing(t, projectDir, "internal/onboarding", "internal/importexport", "internal/blog")
+
+	projectDir = generateProject(t, Options{})
+	assertFilesMissing(t, projectDir, "pkg/helpers/validate_fuzz_test.go", ".github/workflows/fuzz.yml")
+	if strings.Contains(readProjectFile(t, projectDir, "Makefile"), "fuzz:") {
+		t.Error("Makefile has a fuzz target without --fuzz")
+	}
+}
+
+func TestGenerateSnapshots(t *testing.T) {
+	projectDir := generateProject(t, Options{IncludeDB: true, ExampleResource: ExampleTodo, GDPR: true, Snapshots: true})
+	assertFilesExist(t, projectDir,
+		"internal/snapshot/snapshot.go",
+		"views/components/components_snapshot_test.go",
+		"views/pages/pages_snapshot_test.go",
+	)
+	checks := map[string][]string{
+		"internal/snapshot/snapshot.go":                {`var update = flag.Bool("update"`, `filepath.Join("testdata", "snapshots", name+".html")`},
+		"views/components/components_snapshot_test.go": {`{"navbar", Navbar()},`, `{"consent-banner", ConsentBanner()},`},
+		"views/pages/pages_snapshot_test.go":           {`{"index", Index()},`, `{"todos", Todos([]repository.Todo{`, "snapshot.Match(t, tt.name, tt.page)"},
+		"Makefile":                                     {"go test -run Snapshots ./views/... -args -update"},
+	}
+	for file, wants := range checks {
+		content := readProjectFile(t, projectDir, file)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s missing %q", file, want)
+			}
+		}
+	}
+
+	projectDir = generateProject(t, Options{Snapshots: true})
+	if pages := readProjectFile(t, projectDir, "views/pages/pages_snapshot_test.go"); strings.Contains(pages, "repository") {
+		t.Errorf("page snapshots use the example resource without one:\n%s", pages)
+	}
+
+	projectDir = generateProject(t, Options{})
+	assertFilesMissing(t, projectDir, "internal/snapshot", "views/pages/pages_snapshot_test.go")
+}
+
+func TestGenerateStatusPage(t *testing.T) {
+	statusFiles := []string{"internal/server/status.go", "views/pages/status.templ"}
+	plainDir := generateProject(t, Options{IncludeDB: true, Jobs: true})
+	assertFilesMissing(t, plainDir, statusFiles...)
+	if strings.Contains(readProjectFile(t, plainDir, "internal/jobs/jobs.go"), "func (r *Runner) Stats()") {
+		t.Error("jobs runner has Stats without --status-page")
+	}
+
+	projectDir := generateProject(t, Options{StatusPage: true, IncludeDB: true, Jobs: true, SEO: true})
+	assertFilesExist(t, projectDir, statusFiles...)
+	checks := map[string][]string{
+		"cmd/server/main.go":        {"server.NewServer(server.WithJobs(runner), server.WithBuildInfo(server.BuildInfo{Version: version, Commit: commit, Date: date}))"},
+		"internal/server/server.go": {"build   BuildInfo", "s.started = time.Now()"},
+		"internal/server/routes.go": {`Path: "/status", Handler: handle(s.handleStatus)`, `"/status"}`, `"/status")},`},
+		"internal/server/status.go": {`os.Getenv("STATUS_PASSWORD")`, "s.db.GetPool().Stat()", "s.db.GetPool().Query(ctx, ", "s.jobs.Stats()"},
+		"internal/jobs/jobs.go":     {"func (r *Runner) Stats() Stats"},
+		"views/pages/status.templ":  {`hx-get="/status" hx-trigger="every 5s"`, "Jobs []StatusStat", "NoIndex: true"},
+		".env.example":              {"# STATUS_PASSWORD="},
+		"README.md":                 {"## 📟 Status Page", "the depth of the background job queue"},
+	}
+	for file, wants := range checks {
+		content := readProjectFile(t, projectDir, file)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s missing %q", file, want)
+			}
+		}
+	}
+
+	// Without a database or jobs the page shows the build and the process
+	bareDir := generateProject(t, Options{StatusPage: true, BasePath: "/app"})
+	if !strings.Contains(readProjectFile(t, bareDir, "cmd/server/main.go"), "server.NewServer(server.WithBuildInfo(") {
+		t.Error("main does not pass the build info")
+	}
+	status := readProjectFile(t, bareDir, "internal/server/status.go")
+	for _, unwanted := range []string{"s.db", "s.jobs", "context", "strconv", "statusMigrations"} {
+		if strings.Contains(status, unwanted) {
+			t.Errorf("status.go uses %s without it", unwanted)
+		}
+	}
+	if page := readProjectFile(t, bareDir, "views/pages/status.templ"); !strings.Contains(page, `hx-get="/app/status"`) || strings.Contains(page, "Migrations") {
+		t.Errorf("status page without a database:\n%s", page)
+	}
+
+	sqlDir := generateProject(t, Options{StatusPage: true, Database: DatabaseMySQL})
+	if status := readProjectFile(t, sqlDir, "internal/server/status.go"); !strings.Contains(status, "s.db.DB().Stats()") || !strings.Contains(status, "s.db.DB().QueryContext(ctx, ") {
+		t.Errorf("status.go does not read the database/sql pool:\n%s", status)
+	}
+}
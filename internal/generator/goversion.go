@@ -0,0 +1,89 @@
+package generator
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// minGoMinor is the oldest Go 1.x release the templates build with
+const minGoMinor = 23
+
+// defaultGoVersions is the Go version each template channel generates when
+// none is chosen
+var defaultGoVersions = map[string]string{
+	ChannelStable: "1.23",
+	ChannelEdge:   "1.24",
+}
+
+var goVersionRe = regexp.MustCompile(`^(?:go)?1\.(\d+)(?:\.(\d+))?$`)
+
+// ParseGoVersion checks a Go release for --go-version and returns it without
+// the "go" prefix: "go1.24.2" and "1.24.2" both give "1.24.2"
+func ParseGoVersion(v string) (string, error) {
+	m := goVersionRe.FindStringSubmatch(strings.TrimSpace(v))
+	if m == nil {
+		return "", fmt.Errorf("invalid Go version %q (want 1.N or 1.N.P)", v)
+	}
+	if minor, _ := strconv.Atoi(m[1]); minor < minGoMinor {
+		return "", fmt.Errorf("unsupported Go version %q: generated projects need Go 1.%d or newer", v, minGoMinor)
+	}
+	return strings.TrimPrefix(strings.TrimSpace(v), "go"), nil
+}
+
+// DetectGoVersion returns the version of the go command on PATH, or "" when
+// there is none or it is not a supported release (devel, rc, too old)
+func DetectGoVersion() string {
+	out, err := exec.Command("go", "env", "GOVERSION").Output()
+	if err != nil {
+		return ""
+	}
+	v, err := ParseGoVersion(string(out))
+	if err != nil {
+		return ""
+	}
+	return v
+}
+
+// goVersion is the Go release a project targets: the chosen one, or the
+// channel default
+func goVersion(opts Options) string {
+	if opts.GoVersion != "" {
+		return opts.GoVersion
+	}
+	if v, ok := defaultGoVersions[opts.Channel]; ok {
+		return v
+	}
+	return defaultGoVersions[ChannelStable]
+}
+
+// goLanguageVersion is the 1.N language version of a Go release, used by the
+// go directive and golangci-lint
+func goLanguageVersion(v string) string {
+	if parts := strings.SplitN(v, ".", 3); len(parts) == 3 {
+		return parts[0] + "." + parts[1]
+	}
+	return v
+}
+
+// goMinor is the N of a 1.N[.P] Go release
+func goMinor(v string) int {
+	parts := strings.SplitN(v, ".", 3)
+	if len(parts) < 2 {
+		return 0
+	}
+	minor, _ := strconv.Atoi(parts[1])
+	return minor
+}
+
+// goModVersion renders the go directive, plus a toolchain directive when a
+// patch release is chosen
+func goModVersion(v string) string {
+	lang := goLanguageVersion(v)
+	if lang == v {
+		return "go " + lang
+	}
+	return "go " + lang + "\n\ntoolchain go" + v
+}
@@ -0,0 +1,333 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Output formats of `goforge graph`
+const (
+	GraphMermaid = "mermaid"
+	GraphDOT     = "dot"
+)
+
+// Kinds of node in a route graph, in the order requests flow through them:
+// pages are the exported components of views/pages
+const (
+	GraphRoute     = "route"
+	GraphHandler   = "handler"
+	GraphPage      = "page"
+	GraphComponent = "component"
+)
+
+var (
+	// templDeclRe is a top-level templ component declaration
+	templDeclRe = regexp.MustCompile(`(?m)^templ\s+(\w+)\(`)
+	// templTopLevelRe starts any top-level declaration of a .templ file
+	templTopLevelRe = regexp.MustCompile(`(?m)^(?:templ|func|type|var|const|css|script)\s`)
+	// templCallRe is a component call, @Name(...) or @pkg.Name(...)
+	templCallRe = regexp.MustCompile(`@(?:(\w+)\.)?(\w+)\(`)
+	// templImportRe is an import of a .templ file, with its optional alias
+	templImportRe = regexp.MustCompile(`(?m)^\s*(?:import\s+)?(?:(\w+)\s+)?"([^"\s]+)"\s*$`)
+)
+
+// GraphNode is a route, handler or Templ component
+type GraphNode struct {
+	ID    string // unique and safe as a Mermaid or DOT identifier
+	Kind  string
+	Label string
+}
+
+// GraphEdge points from a node to one it uses
+type GraphEdge struct {
+	From, To string
+}
+
+// Graph connects the routes of a project to the handlers serving them, the
+// Templ pages those render and the components the pages are made of
+type Graph struct {
+	Nodes []GraphNode
+	Edges []GraphEdge
+}
+
+// templComponent is a component declared in a .templ file
+type templComponent struct {
+	pkg, name string // package import path, component name
+	calls     []string
+}
+
+// key identifies a component across packages
+func (c templComponent) key() string { return c.pkg + "." + c.name }
+
+// BuildGraph reads the route registry in internal/server/routes.go, the
+// handlers of internal/server and the project's .templ files into a graph.
+// A handler is linked to the components it renders, directly or through
+// the helpers it calls; only components reachable from a route are kept.
+func BuildGraph(projectDir string) (*Graph, error) {
+	module, err := projectModule(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	routes, err := readRoutes(filepath.Join(projectDir, "internal", "server", "routes.go"))
+	if err != nil {
+		return nil, err
+	}
+	components, err := readTemplComponents(projectDir, module)
+	if err != nil {
+		return nil, err
+	}
+	files, err := parseGoFiles(filepath.Join(projectDir, "internal", "server"))
+	if err != nil {
+		return nil, err
+	}
+
+	// What each function of internal/server renders and which of the
+	// others it calls, by name
+	type funcUses struct {
+		renders, calls []string
+	}
+	funcs := map[string]*funcUses{}
+	for _, f := range files {
+		imports := map[string]string{}
+		for _, imp := range f.file.Imports {
+			p, _ := strconv.Unquote(imp.Path.Value)
+			name := path.Base(p)
+			if imp.Name != nil {
+				name = imp.Name.Name
+			}
+			imports[name] = p
+		}
+		for _, decl := range f.file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil {
+				continue
+			}
+			uses := &funcUses{}
+			funcs[fn.Name.Name] = uses
+			ast.Inspect(fn.Body, func(n ast.Node) bool {
+				switch n := n.(type) {
+				case *ast.SelectorExpr:
+					x, ok := n.X.(*ast.Ident)
+					if !ok {
+						break
+					}
+					if p, ok := imports[x.Name]; ok {
+						if _, ok := components[p+"."+n.Sel.Name]; ok {
+							uses.renders = append(uses.renders, p+"."+n.Sel.Name)
+						}
+					} else {
+						uses.calls = append(uses.calls, n.Sel.Name) // s.method
+					}
+				case *ast.CallExpr:
+					if id, ok := n.Fun.(*ast.Ident); ok {
+						uses.calls = append(uses.calls, id.Name)
+					}
+				}
+				return true
+			})
+		}
+	}
+
+	g := &Graph{}
+	added := map[string]bool{}
+	addNode := func(id, kind, label string) {
+		if !added[id] {
+			added[id] = true
+			g.Nodes = append(g.Nodes, GraphNode{ID: id, Kind: kind, Label: label})
+		}
+	}
+	edges := map[GraphEdge]bool{}
+	addEdge := func(from, to string) {
+		if e := (GraphEdge{From: from, To: to}); !edges[e] {
+			edges[e] = true
+			g.Edges = append(g.Edges, e)
+		}
+	}
+
+	// addComponent adds a component and, depth first, the ones it calls
+	var addComponent func(key string) string
+	addComponent = func(key string) string {
+		c := components[key]
+		id := graphID("c", strings.TrimPrefix(key, module+"/"))
+		if added[id] {
+			return id
+		}
+		kind := GraphComponent
+		if path.Base(c.pkg) == "pages" && ast.IsExported(c.name) {
+			kind = GraphPage
+		}
+		addNode(id, kind, path.Base(c.pkg)+"."+c.name)
+		for _, call := range c.calls {
+			addEdge(id, addComponent(call))
+		}
+		return id
+	}
+
+	for i, route := range routes {
+		routeID := fmt.Sprintf("r%d", i)
+		addNode(routeID, GraphRoute, route.Method+" "+route.Path)
+		for _, m := range handlerRefRe.FindAllStringSubmatch(route.Handler, -1) {
+			handlerID := graphID("h", m[1])
+			addNode(handlerID, GraphHandler, m[1])
+			addEdge(routeID, handlerID)
+
+			// The components the handler renders, through any helper
+			var rendered []string
+			seen := map[string]bool{}
+			pending := []string{m[1]}
+			for len(pending) > 0 {
+				name := pending[0]
+				pending = pending[1:]
+				uses, ok := funcs[name]
+				if !ok || seen[name] {
+					continue
+				}
+				seen[name] = true
+				rendered = append(rendered, uses.renders...)
+				pending = append(pending, uses.calls...)
+			}
+			for _, key := range rendered {
+				addEdge(handlerID, addComponent(key))
+			}
+		}
+	}
+	return g, nil
+}
+
+// readTemplComponents lists the components of every .templ file in the
+// project, by package import path and name, with the components each calls
+func readTemplComponents(projectDir, module string) (map[string]templComponent, error) {
+	type templFile struct {
+		pkg     string
+		imports map[string]string
+		source  string
+	}
+	var files []templFile
+	err := filepath.WalkDir(projectDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != projectDir && renameSkipDirs[d.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(p) != ".templ" {
+			return nil
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(projectDir, filepath.Dir(p))
+		f := templFile{pkg: module + "/" + filepath.ToSlash(rel), imports: map[string]string{}, source: string(data)}
+		header := f.source
+		if loc := templTopLevelRe.FindStringIndex(header); loc != nil {
+			header = header[:loc[0]]
+		}
+		for _, m := range templImportRe.FindAllStringSubmatch(header, -1) {
+			name := path.Base(m[2])
+			if m[1] != "" {
+				name = m[1]
+			}
+			f.imports[name] = m[2]
+		}
+		files = append(files, f)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	components := map[string]templComponent{}
+	type body struct {
+		c    templComponent
+		file templFile
+		text string
+	}
+	var bodies []body
+	for _, f := range files {
+		for _, loc := range templDeclRe.FindAllStringSubmatchIndex(f.source, -1) {
+			c := templComponent{pkg: f.pkg, name: f.source[loc[2]:loc[3]]}
+			text := f.source[loc[1]:]
+			if next := templTopLevelRe.FindStringIndex(text); next != nil {
+				text = text[:next[0]]
+			}
+			components[c.key()] = c
+			bodies = append(bodies, body{c: c, file: f, text: text})
+		}
+	}
+	for _, b := range bodies {
+		seen := map[string]bool{}
+		for _, m := range templCallRe.FindAllStringSubmatch(b.text, -1) {
+			pkg := b.c.pkg
+			if m[1] != "" {
+				if pkg = b.file.imports[m[1]]; pkg == "" {
+					continue // templ.Raw and other non-component calls
+				}
+			}
+			key := pkg + "." + m[2]
+			if _, ok := components[key]; ok && !seen[key] && key != b.c.key() {
+				seen[key] = true
+				b.c.calls = append(b.c.calls, key)
+			}
+		}
+		sort.Strings(b.c.calls)
+		components[b.c.key()] = b.c
+	}
+	return components, nil
+}
+
+// graphIDRe matches what may not appear in a node ID
+var graphIDRe = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// graphID makes a Mermaid and DOT safe node ID from a name
+func graphID(prefix, name string) string {
+	return prefix + "_" + graphIDRe.ReplaceAllString(name, "_")
+}
+
+// graphShapes are the Mermaid node shapes and DOT node attributes by kind
+var graphShapes = map[string]struct{ open, close, dot string }{
+	GraphRoute:     {"([", "])", `shape=oval, style=filled, fillcolor="#dbeafe"`},
+	GraphHandler:   {"[", "]", `shape=box, style=filled, fillcolor="#fef3c7"`},
+	GraphPage:      {"[[", "]]", `shape=note, style=filled, fillcolor="#dcfce7"`},
+	GraphComponent: {"(", ")", `shape=component, style=filled, fillcolor="#f3e8ff"`},
+}
+
+// Mermaid renders the graph as a Mermaid flowchart, left to right
+func (g *Graph) Mermaid() string {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for _, n := range g.Nodes {
+		shape := graphShapes[n.Kind]
+		label := strings.ReplaceAll(n.Label, `"`, "#quot;")
+		fmt.Fprintf(&b, "    %s%s\"%s\"%s\n", n.ID, shape.open, label, shape.close)
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(&b, "    %s --> %s\n", e.From, e.To)
+	}
+	return b.String()
+}
+
+// DOT renders the graph for Graphviz: dot -Tsvg
+func (g *Graph) DOT() string {
+	var b strings.Builder
+	b.WriteString("digraph routes {\n    rankdir=LR;\n    node [fontname=\"Helvetica\"];\n")
+	for _, n := range g.Nodes {
+		fmt.Fprintf(&b, "    %s [label=%q, %s];\n", n.ID, n.Label, graphShapes[n.Kind].dot)
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(&b, "    %s -> %s;\n", e.From, e.To)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
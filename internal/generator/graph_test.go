@@ -0,0 +1,66 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildGraph(t *testing.T) {
+	projectDir := generateProject(t, Options{IncludeDB: true, ExampleResource: ExampleNote, DataTable: true})
+	g, err := BuildGraph(projectDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	labels := map[string]string{}
+	kinds := map[string]string{}
+	for _, n := range g.Nodes {
+		labels[n.ID] = n.Label
+		kinds[n.Label] = n.Kind
+	}
+	edges := map[string]bool{}
+	for _, e := range g.Edges {
+		edges[labels[e.From]+" -> "+labels[e.To]] = true
+	}
+	for _, want := range []string{
+		"GET /notes -> handleNotes",
+		"handleNotes -> pages.Notes",
+		"pages.Notes -> layouts.Base",
+		"pages.Notes -> components.Navbar",
+		"pages.Notes -> pages.NoteItem",
+		"pages.Index -> components.Footer",
+		"POST /notes -> handleCreateNote",
+		"handleCreateNote -> pages.NoteItem",
+		// Through the renderTable helper
+		"handleNotesTable -> pages.DataTableResults",
+	} {
+		if !edges[want] {
+			t.Errorf("graph missing edge %s", want)
+		}
+	}
+	for label, kind := range map[string]string{
+		"GET /":              GraphRoute,
+		"handleHome":         GraphHandler,
+		"pages.Index":        GraphPage,
+		"pages.featureCard":  GraphComponent,
+		"components.Navbar":  GraphComponent,
+		"DELETE /notes/{id}": GraphRoute,
+	} {
+		if kinds[label] != kind {
+			t.Errorf("%s is a %q node, want %q", label, kinds[label], kind)
+		}
+	}
+
+	mermaid := g.Mermaid()
+	for _, want := range []string{"flowchart LR\n", `r0(["`, `h_handleNotes["handleNotes"]`, `c_views_pages_Notes[["pages.Notes"]]`, "h_handleNotes --> c_views_pages_Notes\n"} {
+		if !strings.Contains(mermaid, want) {
+			t.Errorf("Mermaid output missing %q", want)
+		}
+	}
+	dot := g.DOT()
+	for _, want := range []string{"digraph routes {", `h_handleNotes [label="handleNotes", shape=box`, "h_handleNotes -> c_views_pages_Notes;"} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("DOT output missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/FACorreiaa/goforge/internal/manifest"
+)
+
+// HTMXSnippet is a ready-to-paste HTMX pattern printed by goforge snippets,
+// rendered from snippets/htmx/<name>.templ.tmpl
+type HTMXSnippet struct {
+	Name        string
+	Description string
+}
+
+// htmxSnippets is the registry of HTMX patterns, in the order they are listed
+var htmxSnippets = []HTMXSnippet{
+	{"infinite-scroll", "Load the next page when the last row scrolls into view"},
+	{"active-search", "Search as you type, debounced, with a loading indicator"},
+	{"inline-edit", "Click to edit a row in place, save or cancel without a reload"},
+	{"optimistic-ui", "Toggle a like button immediately and roll back if the request fails"},
+}
+
+// HTMXSnippets lists the patterns goforge snippets can print
+func HTMXSnippets() []HTMXSnippet {
+	return append([]HTMXSnippet(nil), htmxSnippets...)
+}
+
+// HTMXSnippetOptions are the project settings a pattern adapts to
+type HTMXSnippetOptions struct {
+	CSSFramework string // daisyui (the default), templui or basecoat
+	Frontend     string // with htmx-alpine, state lives in Alpine
+	BasePath     string // prefixed to the URLs
+}
+
+// HTMXSnippetOptionsFor reads the settings recorded in a project's manifest.
+// Outside a project it returns the defaults.
+func HTMXSnippetOptionsFor(projectDir string) (HTMXSnippetOptions, error) {
+	m, err := manifest.Load(projectDir)
+	if errors.Is(err, manifest.ErrNotFound) {
+		return HTMXSnippetOptions{CSSFramework: CSSFrameworkDaisyUI, Frontend: FrontendHTMX}, nil
+	}
+	if err != nil {
+		return HTMXSnippetOptions{}, err
+	}
+	opts, errs := optionsFromManifest(m)
+	if len(errs) > 0 {
+		return HTMXSnippetOptions{}, errors.Join(errs...)
+	}
+	return HTMXSnippetOptions{CSSFramework: opts.CSSFramework, Frontend: opts.Frontend, BasePath: opts.BasePath}, nil
+}
+
+// htmxSnippetData is what the pattern templates see
+type htmxSnippetData struct {
+	Basecoat bool
+	Alpine   bool
+	BasePath string
+	// Class holds the framework's classes by role: btn, btn-primary,
+	// btn-ghost, input, spinner, muted, divide, error
+	Class map[string]string
+}
+
+// htmxSnippetClasses are the component classes per CSS framework. TemplUI
+// projects build their styles with the DaisyUI plugin, so they share its
+// classes.
+var htmxSnippetClasses = map[string]map[string]string{
+	CSSFrameworkDaisyUI: {
+		"btn":         "btn btn-sm",
+		"btn-primary": "btn btn-primary btn-sm",
+		"btn-ghost":   "btn btn-ghost btn-sm",
+		"input":       "input input-bordered w-full",
+		"spinner":     "loading loading-spinner loading-sm",
+		"muted":       "text-base-content/60",
+		"divide":      "divide-y divide-base-300",
+		"error":       "text-error",
+	},
+	CSSFrameworkBasecoat: {
+		"btn":         "btn-sm-outline",
+		"btn-primary": "btn-sm",
+		"btn-ghost":   "btn-sm-ghost",
+		"input":       "input w-full",
+		"spinner":     "inline-block size-4 animate-spin rounded-full border-2 border-current border-t-transparent",
+		"muted":       "text-muted-foreground",
+		"divide":      "divide-y divide-border",
+		"error":       "text-destructive",
+	},
+}
+
+// RenderHTMXSnippet renders the named pattern for a project's settings
+func RenderHTMXSnippet(name string, opts HTMXSnippetOptions) (string, error) {
+	known := false
+	var names []string
+	for _, s := range htmxSnippets {
+		known = known || s.Name == name
+		names = append(names, s.Name)
+	}
+	if !known {
+		return "", fmt.Errorf("unknown snippet %q (available: %s)", name, strings.Join(names, ", "))
+	}
+	css := opts.CSSFramework
+	if css == CSSFrameworkTemplUI || css == "" {
+		css = CSSFrameworkDaisyUI
+	}
+	classes, ok := htmxSnippetClasses[css]
+	if !ok {
+		return "", fmt.Errorf("unknown CSS framework %q", opts.CSSFramework)
+	}
+	out, err := renderSnippet("htmx/"+name+".templ.tmpl", htmxSnippetData{
+		Basecoat: css == CSSFrameworkBasecoat,
+		Alpine:   opts.Frontend == FrontendHTMXAlpine,
+		BasePath: opts.BasePath,
+		Class:    classes,
+	})
+	return string(out), err
+}
@@ -0,0 +1,70 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderHTMXSnippet(t *testing.T) {
+	for _, s := range HTMXSnippets() {
+		for css, want := range map[string]string{
+			CSSFrameworkDaisyUI:  "divide-base-300",
+			CSSFrameworkTemplUI:  "divide-base-300",
+			CSSFrameworkBasecoat: "",
+		} {
+			out, err := RenderHTMXSnippet(s.Name, HTMXSnippetOptions{CSSFramework: css})
+			if err != nil {
+				t.Fatalf("%s/%s: %v", s.Name, css, err)
+			}
+			if !strings.HasPrefix(out, "// ") || !strings.Contains(out, "\ntempl ") || strings.Contains(out, "{{") {
+				t.Errorf("%s/%s is not a commented templ component:\n%s", s.Name, css, out)
+			}
+			if css == CSSFrameworkBasecoat && strings.Contains(out, "base-300") {
+				t.Errorf("%s/basecoat uses DaisyUI classes", s.Name)
+			}
+			if want != "" && strings.Contains(out, "divide") && !strings.Contains(out, want) {
+				t.Errorf("%s/%s missing %q", s.Name, css, want)
+			}
+		}
+	}
+
+	out, err := RenderHTMXSnippet("active-search", HTMXSnippetOptions{CSSFramework: CSSFrameworkBasecoat, BasePath: "/app"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`hx-get="/app/search"`, `class="input w-full"`, "animate-spin", "text-muted-foreground"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("active-search missing %q", want)
+		}
+	}
+
+	alpine, _ := RenderHTMXSnippet("optimistic-ui", HTMXSnippetOptions{Frontend: FrontendHTMXAlpine})
+	plain, _ := RenderHTMXSnippet("optimistic-ui", HTMXSnippetOptions{Frontend: FrontendHTMX})
+	if !strings.Contains(alpine, "x-data=") || strings.Contains(alpine, "likeToggle") {
+		t.Errorf("Alpine optimistic-ui:\n%s", alpine)
+	}
+	if strings.Contains(plain, "x-data") || !strings.Contains(plain, "hx-on::before-request") {
+		t.Errorf("plain optimistic-ui:\n%s", plain)
+	}
+
+	if _, err := RenderHTMXSnippet("modal", HTMXSnippetOptions{}); err == nil || !strings.Contains(err.Error(), "infinite-scroll") {
+		t.Errorf("unknown snippet error = %v", err)
+	}
+	if _, err := RenderHTMXSnippet("inline-edit", HTMXSnippetOptions{CSSFramework: "bootstrap"}); err == nil {
+		t.Error("rendered for an unknown CSS framework")
+	}
+}
+
+func TestHTMXSnippetOptionsFor(t *testing.T) {
+	projectDir := generateProject(t, Options{CSSFramework: CSSFrameworkBasecoat, Frontend: FrontendHTMXAlpine, BasePath: "/app"})
+	opts, err := HTMXSnippetOptionsFor(projectDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts != (HTMXSnippetOptions{CSSFramework: CSSFrameworkBasecoat, Frontend: FrontendHTMXAlpine, BasePath: "/app"}) {
+		t.Errorf("options = %+v", opts)
+	}
+	if opts, err := HTMXSnippetOptionsFor(t.TempDir()); err != nil || opts.CSSFramework != CSSFrameworkDaisyUI {
+		t.Errorf("outside a project = %+v, %v", opts, err)
+	}
+}
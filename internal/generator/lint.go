@@ -0,0 +1,243 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The checks of `goforge lint-project`, in report order
+const (
+	LintRoutes     = "routes"
+	LintTempl      = "templ"
+	LintEnv        = "env"
+	LintMigrations = "migrations"
+)
+
+// LintChecks lists the lint-project checks in report order
+func LintChecks() []string {
+	return []string{LintRoutes, LintTempl, LintEnv, LintMigrations}
+}
+
+// LintIssue is a place where a project breaks a scaffold convention
+type LintIssue struct {
+	Check string
+	// File is relative to the project, with :line when known
+	File    string
+	Message string
+}
+
+// handlerRefRe finds the Server methods a route registry entry points at
+var handlerRefRe = regexp.MustCompile(`\bs\.(handle\w+)\b`)
+
+// timestampVersion is the smallest goose timestamp version (YYYYMMDDHHMMSS);
+// the scaffold numbers its migrations 00001, 00002...
+const timestampVersion = 10_000_000_000_000
+
+// LintProject checks a generated project against the scaffold's conventions,
+// the mistakes go vet cannot see: routes whose handler is missing, Templ
+// files not regenerated since they changed, environment variables the code
+// reads that .env.example does not document, and goose migrations whose
+// versions clash or would run out of order. Issues come sorted by check,
+// then file.
+func LintProject(projectDir string) ([]LintIssue, error) {
+	if _, err := projectModule(projectDir); err != nil {
+		return nil, err
+	}
+	var issues []LintIssue
+	for _, check := range []func(string) ([]LintIssue, error){lintRoutes, lintTempl, lintEnv, lintMigrations} {
+		found, err := check(projectDir)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, found...)
+	}
+	return issues, nil
+}
+
+// lintRoutes reports route registry entries and chi routes in routes.go
+// whose handler is not a method declared in internal/server
+func lintRoutes(projectDir string) ([]LintIssue, error) {
+	routesRel := filepath.Join("internal", "server", "routes.go")
+	routes, err := readRoutes(filepath.Join(projectDir, routesRel))
+	if err != nil || len(routes) == 0 {
+		return nil, err
+	}
+	files, err := parseGoFiles(filepath.Join(projectDir, "internal", "server"))
+	if err != nil {
+		return nil, err
+	}
+	methods := map[string]bool{}
+	for _, f := range files {
+		for _, decl := range f.file.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv != nil {
+				methods[fn.Name.Name] = true
+			}
+		}
+	}
+
+	var issues []LintIssue
+	for _, route := range routes {
+		for _, m := range handlerRefRe.FindAllStringSubmatch(route.Handler, -1) {
+			if !methods[m[1]] {
+				issues = append(issues, LintIssue{
+					Check:   LintRoutes,
+					File:    filepath.ToSlash(routesRel),
+					Message: fmt.Sprintf("%s %s is handled by %s, which internal/server does not declare", route.Method, route.Path, m[1]),
+				})
+			}
+		}
+	}
+	return issues, nil
+}
+
+// lintTempl reports .templ files with no generated _templ.go next to them,
+// or one older than the component: make templ regenerates them
+func lintTempl(projectDir string) ([]LintIssue, error) {
+	var issues []LintIssue
+	err := filepath.WalkDir(projectDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != projectDir && renameSkipDirs[d.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) != ".templ" {
+			return nil
+		}
+		rel, _ := filepath.Rel(projectDir, path)
+		source, err := d.Info()
+		if err != nil {
+			return err
+		}
+		generated, err := os.Stat(strings.TrimSuffix(path, ".templ") + "_templ.go")
+		switch {
+		case os.IsNotExist(err):
+			issues = append(issues, LintIssue{Check: LintTempl, File: filepath.ToSlash(rel), Message: "not generated: run make templ"})
+		case err != nil:
+			return err
+		case generated.ModTime().Before(source.ModTime()):
+			issues = append(issues, LintIssue{Check: LintTempl, File: filepath.ToSlash(rel), Message: "changed since it was generated: run make templ"})
+		}
+		return nil
+	})
+	return issues, err
+}
+
+// lintEnv reports the environment variables the Go code reads that neither
+// .env.example nor an APP_ENV profile sets: the missing half of
+// `goforge env check`
+func lintEnv(projectDir string) ([]LintIssue, error) {
+	report, err := CheckEnv(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	var issues []LintIssue
+	for _, f := range report.Missing {
+		issues = append(issues, LintIssue{Check: LintEnv, File: f.File, Message: f.Name + " is read here but missing from .env.example"})
+	}
+	return issues, nil
+}
+
+// lintMigrations reports goose migrations goose cannot order: names without
+// a version, versions used twice, files missing the -- +goose Up annotation,
+// and timestamp versions next to numbered ones, which sort after every
+// numbered migration, including the ones added later
+func lintMigrations(projectDir string) ([]LintIssue, error) {
+	dirRel := filepath.Join("internal", "database", "migrations")
+	entries, err := os.ReadDir(filepath.Join(projectDir, dirRel))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []LintIssue
+	add := func(name, message string) {
+		issues = append(issues, LintIssue{Check: LintMigrations, File: filepath.ToSlash(filepath.Join(dirRel, name)), Message: message})
+	}
+	versions := map[int64]string{}
+	var numbered, timestamped []string
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".sql" {
+			continue
+		}
+		match := migrationNumRe.FindStringSubmatch(e.Name())
+		if match == nil {
+			add(e.Name(), "no version: goose expects <version>_<name>.sql")
+			continue
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			add(e.Name(), "version out of range")
+			continue
+		}
+		if first, ok := versions[version]; ok {
+			add(e.Name(), fmt.Sprintf("version %d is also used by %s: renumber one of them", version, first))
+		} else {
+			versions[version] = e.Name()
+		}
+		if version >= timestampVersion {
+			timestamped = append(timestamped, e.Name())
+		} else {
+			numbered = append(numbered, e.Name())
+		}
+
+		data, err := os.ReadFile(filepath.Join(projectDir, dirRel, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if !strings.Contains(string(data), "-- +goose Up") {
+			add(e.Name(), "missing the -- +goose Up annotation")
+		}
+	}
+	if len(numbered) > 0 {
+		for _, name := range timestamped {
+			add(name, "timestamp version sorts after every numbered migration: renumber it (goose fix)")
+		}
+	}
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].File < issues[j].File })
+	return issues, nil
+}
+
+// goFile is a parsed Go source file
+type goFile struct {
+	path string
+	fset *token.FileSet
+	file *ast.File
+}
+
+// parseGoFiles parses the non-test Go files of dir, generated ones included.
+// A file with syntax errors yields what parsed: go vet reports the errors.
+func parseGoFiles(dir string) ([]goFile, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	var files []goFile
+	fset := token.NewFileSet()
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, _ := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if file == nil {
+			continue
+		}
+		files = append(files, goFile{path: path, fset: fset, file: file})
+	}
+	return files, nil
+}
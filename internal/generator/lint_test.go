@@ -0,0 +1,91 @@
+package generator
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// templGenerate stands in for make templ: it writes a _templ.go next to
+// every component
+func templGenerate(t *testing.T, projectDir string) {
+	t.Helper()
+	err := filepath.WalkDir(projectDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || filepath.Ext(path) != ".templ" {
+			return err
+		}
+		return os.WriteFile(strings.TrimSuffix(path, ".templ")+"_templ.go", []byte("package x\n"), 0o644)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLintProjectClean(t *testing.T) {
+	for _, opts := range []Options{
+		{},
+		{IncludeDB: true, ExampleResource: ExampleNote, FTS: true, Jobs: true, Errors: ErrorsSentry, DBReplicas: true, AutoMigrate: true},
+		{IncludeDB: true, SingleBinary: true},
+	} {
+		projectDir := generateProject(t, opts)
+		templGenerate(t, projectDir)
+		issues, err := LintProject(projectDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, issue := range issues {
+			t.Errorf("%+v: %s: %s", opts, issue.File, issue.Message)
+		}
+	}
+}
+
+func TestLintProjectFindsIssues(t *testing.T) {
+	projectDir := generateProject(t, Options{IncludeDB: true, ExampleResource: ExampleNote})
+	templGenerate(t, projectDir)
+
+	write := func(rel, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(projectDir, rel), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	routes := readProjectFile(t, projectDir, "internal/server/routes.go")
+	routes = strings.Replace(routes, "\t\t// Pages\n", "\t\t// Pages\n\t\t{Name: \"about\", Method: http.MethodGet, Path: \"/about\", Handler: handle(s.handleAbout)},\n", 1)
+	write("internal/server/routes.go", routes)
+	write("internal/server/flags.go", "package server\n\nimport \"os\"\n\nfunc flag(key string) bool { return os.Getenv(key) == \"true\" }\n\nvar beta = flag(\"BETA_FEATURES\")\n")
+	write("internal/database/migrations/00001_users.sql", "-- +goose Up\nSELECT 1;\n")
+	write("internal/database/migrations/20240102030405_tags.sql", "-- +goose Up\nSELECT 1;\n")
+	write("internal/database/migrations/tags.sql", "SELECT 1;\n")
+
+	if err := os.Remove(filepath.Join(projectDir, "views", "pages", "notes_templ.go")); err != nil {
+		t.Fatal(err)
+	}
+	past := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(filepath.Join(projectDir, "views", "pages", "index_templ.go"), past, past); err != nil {
+		t.Fatal(err)
+	}
+
+	issues, err := LintProject(projectDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, issue := range issues {
+		got = append(got, issue.Check+" "+issue.File+": "+issue.Message)
+	}
+	want := []string{
+		"routes internal/server/routes.go: GET /about is handled by handleAbout, which internal/server does not declare",
+		"templ views/pages/index.templ: changed since it was generated: run make templ",
+		"templ views/pages/notes.templ: not generated: run make templ",
+		"env internal/server/flags.go:7: BETA_FEATURES is read here but missing from .env.example",
+		"migrations internal/database/migrations/00001_users.sql: version 1 is also used by 00001_create_notes.sql: renumber one of them",
+		"migrations internal/database/migrations/20240102030405_tags.sql: timestamp version sorts after every numbered migration: renumber it (goose fix)",
+		"migrations internal/database/migrations/tags.sql: no version: goose expects <version>_<name>.sql",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("issues:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
@@ -0,0 +1,391 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/FACorreiaa/goforge/internal/i18n"
+	"github.com/FACorreiaa/goforge/internal/manifest"
+)
+
+// Version is the goforge version recorded in project manifests; the CLI sets it
+var Version = "dev"
+
+// templateSourceEmbedded is the manifest template source for the templates
+// built into goforge
+const templateSourceEmbedded = "embedded"
+
+// optionSpec ties a `goforge new` flag to its Options field. String options
+// list their allowed values or check them; boolean options have neither.
+type optionSpec struct {
+	name   string
+	str    func(*Options) *string
+	flag   func(*Options) *bool
+	values []string
+	check  func(string) error
+}
+
+// manifestOptions are the options recorded in .goforge.yaml, by flag name
+var manifestOptions = []optionSpec{
+	{name: "frontend", str: func(o *Options) *string { return &o.Frontend },
+		values: []string{FrontendHTMX, FrontendHTMXHyperscript, FrontendHTMXAlpine, FrontendHTMXSurreal}},
+	{name: "css", str: func(o *Options) *string { return &o.CSSFramework },
+		values: []string{CSSFrameworkDaisyUI, CSSFrameworkTemplUI, CSSFrameworkBasecoat}},
+	{name: "theme", str: func(o *Options) *string { return &o.Theme },
+		values: []string{ThemeNone, ThemeCaffeine}},
+	{name: "deploy", str: func(o *Options) *string { return &o.DeployProvider },
+		values: []string{DeployNone, DeployHetznerCaddy, DeploySystemd}},
+	{name: "db", str: func(o *Options) *string { return &o.Database },
+		values: []string{DatabasePostgres, DatabaseMySQL, DatabaseSQLite, DatabaseNone}},
+	{name: "db-replicas", flag: func(o *Options) *bool { return &o.DBReplicas }},
+	{name: "postgis", flag: func(o *Options) *bool { return &o.PostGIS }},
+	{name: "auto-migrate", flag: func(o *Options) *bool { return &o.AutoMigrate }},
+	{name: "dev-db", str: func(o *Options) *string { return &o.DevDB },
+		values: []string{DevDBCompose, DevDBTestcontainers}},
+	{name: "factories", flag: func(o *Options) *bool { return &o.Factories }},
+	{name: "mocks", str: func(o *Options) *string { return &o.Mocks },
+		values: []string{MocksNone, MocksMockery, MocksGomock}},
+	{name: "example-resource", str: func(o *Options) *string { return &o.ExampleResource },
+		values: []string{ExampleUsers, ExampleNote, ExampleTodo, ExampleNone}},
+	{name: "datatable", flag: func(o *Options) *bool { return &o.DataTable }},
+	{name: "import-export", flag: func(o *Options) *bool { return &o.ImportExport }},
+	{name: "fts", flag: func(o *Options) *bool { return &o.FTS }},
+	{name: "pdf", flag: func(o *Options) *bool { return &o.PDF }},
+	{name: "images", flag: func(o *Options) *bool { return &o.Images }},
+	{name: "notifications", flag: func(o *Options) *bool { return &o.Notifications }},
+	{name: "activity", flag: func(o *Options) *bool { return &o.Activity }},
+	{name: "settings", flag: func(o *Options) *bool { return &o.Settings }},
+	{name: "onboarding", flag: func(o *Options) *bool { return &o.Onboarding }},
+	{name: "teams", flag: func(o *Options) *bool { return &o.Teams }},
+	{name: "billing", flag: func(o *Options) *bool { return &o.Billing }},
+	{name: "demo", flag: func(o *Options) *bool { return &o.Demo }},
+	{name: "single-binary", flag: func(o *Options) *bool { return &o.SingleBinary }},
+	{name: "litestream", flag: func(o *Options) *bool { return &o.Litestream }},
+	{name: "sqlite-replication", str: func(o *Options) *string { return &o.SQLiteReplication },
+		values: []string{SQLiteReplicationNone, SQLiteReplicationLiteFS, SQLiteReplicationTurso}},
+	{name: "hooks", flag: func(o *Options) *bool { return &o.IncludeHooks }},
+	{name: "vite", flag: func(o *Options) *bool { return &o.Vite }},
+	{name: "typescript", flag: func(o *Options) *bool { return &o.TypeScript }},
+	{name: "preview", flag: func(o *Options) *bool { return &o.Preview }},
+	{name: "seo", flag: func(o *Options) *bool { return &o.SEO }},
+	{name: "static-export", flag: func(o *Options) *bool { return &o.StaticExport }},
+	{name: "content", flag: func(o *Options) *bool { return &o.Content }},
+	{name: "search", str: func(o *Options) *string { return &o.Search },
+		values: []string{SearchNone, SearchPgTrgm, SearchMeilisearch, SearchBleve}},
+	{name: "geoip", flag: func(o *Options) *bool { return &o.GeoIP }},
+	{name: "analytics", str: func(o *Options) *string { return &o.Analytics },
+		values: []string{AnalyticsNone, AnalyticsPlausible, AnalyticsUmami}},
+	{name: "gdpr", flag: func(o *Options) *bool { return &o.GDPR }},
+	{name: "errors", str: func(o *Options) *string { return &o.Errors },
+		values: []string{ErrorsNone, ErrorsSentry, ErrorsGlitchTip}},
+	{name: "pprof", flag: func(o *Options) *bool { return &o.Pprof }},
+	{name: "status-page", flag: func(o *Options) *bool { return &o.StatusPage }},
+	{name: "loadtest", str: func(o *Options) *string { return &o.LoadTest },
+		values: []string{LoadTestNone, LoadTestK6, LoadTestVegeta}},
+	{name: "e2e", flag: func(o *Options) *bool { return &o.E2E }},
+	{name: "fuzz", flag: func(o *Options) *bool { return &o.Fuzz }},
+	{name: "snapshots", flag: func(o *Options) *bool { return &o.Snapshots }},
+	{name: "openapi", flag: func(o *Options) *bool { return &o.OpenAPI }},
+	{name: "jobs", flag: func(o *Options) *bool { return &o.Jobs }},
+	{name: "sbom", flag: func(o *Options) *bool { return &o.SBOM }},
+	{name: "sign", flag: func(o *Options) *bool { return &o.Sign }},
+	{name: "https-dev", flag: func(o *Options) *bool { return &o.HTTPSDev }},
+	{name: "embed-assets", flag: func(o *Options) *bool { return &o.EmbedAssets }},
+	{name: "proxy", str: func(o *Options) *string { return &o.Proxy },
+		values: []string{ProxyNone, ProxyCaddy, ProxyNginx, ProxyTraefik}},
+	{name: "binaries", str: func(o *Options) *string { return &o.Binaries },
+		check: func(v string) error { _, err := ParseBinaries(v); return err }},
+	{name: "base-path", str: func(o *Options) *string { return &o.BasePath },
+		check: func(v string) error { _, err := ParseBasePath(v); return err }},
+	{name: "asset-mirror", str: func(o *Options) *string { return &o.AssetMirror },
+		check: func(v string) error { _, err := ParseAssetMirror(v); return err }},
+	{name: "lint", str: func(o *Options) *string { return &o.Lint },
+		values: []string{LintStrict, LintStandard, LintMinimal}},
+	{name: "go-version", str: func(o *Options) *string { return &o.GoVersion },
+		check: func(v string) error { _, err := ParseGoVersion(v); return err }},
+	{name: "lean", flag: func(o *Options) *bool { return &o.Lean }},
+	{name: "lang", str: func(o *Options) *string { return &o.Lang },
+		values: i18n.Languages},
+}
+
+// optionsToManifest records opts by flag name. Unset string options are
+// recorded as "none" where that is a valid value.
+func optionsToManifest(opts Options) map[string]string {
+	values := make(map[string]string, len(manifestOptions))
+	for _, spec := range manifestOptions {
+		if spec.flag != nil {
+			values[spec.name] = strconv.FormatBool(*spec.flag(&opts))
+			continue
+		}
+		v := *spec.str(&opts)
+		if v == "" && containsString(spec.values, "none") {
+			v = "none"
+		}
+		values[spec.name] = v
+	}
+	values["db"] = databaseOf(opts)
+	return values
+}
+
+// optionsFromManifest rebuilds the generation options recorded in a manifest,
+// reporting unknown options and invalid values
+func optionsFromManifest(m *manifest.Manifest) (Options, []error) {
+	opts := Options{ProjectName: m.Project.Name, ModulePath: m.Project.Module, Channel: m.Template.Channel}
+	var errs []error
+	if m.Template.Channel != "" {
+		if _, err := BundleVersion(m.Template.Channel); err != nil {
+			errs = append(errs, fmt.Errorf("template.channel: %w", err))
+		}
+	}
+	errs = append(errs, setOptions(&opts, m.Options, "options.")...)
+	opts.IncludeDB = hasDatabase(opts)
+	return opts, errs
+}
+
+// setOptions sets the options named in values (by flag name) on opts. Errors
+// name the option with prefix, as in "options.search".
+func setOptions(opts *Options, values map[string]string, prefix string) []error {
+	// db was true or false before the database backends: keep reading it
+	if b, err := strconv.ParseBool(values["db"]); err == nil {
+		opts.Database, opts.IncludeDB = "", b
+		values = maps.Clone(values)
+		delete(values, "db")
+	}
+	var errs []error
+	known := map[string]bool{}
+	for _, spec := range manifestOptions {
+		known[spec.name] = true
+		v, ok := values[spec.name]
+		if !ok {
+			continue
+		}
+		if spec.flag != nil {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s%s must be true or false, got %q", prefix, spec.name, v))
+			}
+			*spec.flag(opts) = b
+			continue
+		}
+		if spec.check != nil && v != "" {
+			if err := spec.check(v); err != nil {
+				errs = append(errs, fmt.Errorf("%s%s: %w", prefix, spec.name, err))
+			}
+		} else if v != "" && !containsString(spec.values, v) {
+			errs = append(errs, fmt.Errorf("%s%s: unknown value %q (valid: %s)",
+				prefix, spec.name, v, strings.Join(spec.values, ", ")))
+		}
+		*spec.str(opts) = v
+	}
+	for name := range values {
+		if !known[name] {
+			errs = append(errs, fmt.Errorf("%s%s is not a goforge option", prefix, name))
+		}
+	}
+	return errs
+}
+
+// featureID is the manifest id of a feature condition ("SEARCH_PGTRGM" is
+// "search-pgtrgm"), so manifests do not depend on template internals
+func featureID(condition string) string {
+	return strings.ReplaceAll(strings.ToLower(condition), "_", "-")
+}
+
+// enabledFeatures returns the sorted ids of the features opts selects
+func enabledFeatures(opts Options) []string {
+	var features []string
+	for name, on := range featureConditions(opts) {
+		if on {
+			features = append(features, featureID(name))
+		}
+	}
+	sort.Strings(features)
+	return features
+}
+
+// newManifest describes a project generated with opts from the given
+// template source and bundle version
+func newManifest(opts Options, source, bundleVersion string, files map[string]string) *manifest.Manifest {
+	return &manifest.Manifest{
+		SchemaVersion: manifest.SchemaVersion,
+		Generator:     manifest.Generator{Version: Version},
+		Template: manifest.Template{
+			Source:  source,
+			Channel: opts.Channel,
+			Version: bundleVersion,
+		},
+		Project:  manifest.Project{Name: filepath.Base(opts.ProjectName), Module: opts.ModulePath},
+		Options:  optionsToManifest(opts),
+		Features: enabledFeatures(opts),
+		Files:    files,
+	}
+}
+
+// ValidateManifest loads the manifest of a project and checks it against
+// this goforge: schema, known options and features, consistency between the
+// two, and the module in go.mod. It returns the manifest when it could be read.
+func ValidateManifest(projectDir string) (*manifest.Manifest, []error) {
+	m, err := manifest.Load(projectDir)
+	if err != nil {
+		return nil, []error{err}
+	}
+	errs := m.Validate()
+
+	opts, optErrs := optionsFromManifest(m)
+	errs = append(errs, optErrs...)
+
+	known := map[string]bool{}
+	for name := range featureConditions(Options{}) {
+		known[featureID(name)] = true
+	}
+	for _, f := range m.Features {
+		if !known[f] {
+			errs = append(errs, fmt.Errorf("features: unknown feature %s", f))
+		}
+	}
+	// Projects adopted with `manifest init` have no recorded options to compare
+	if len(m.Options) > 0 && len(optErrs) == 0 {
+		if want := enabledFeatures(opts); strings.Join(want, ",") != strings.Join(m.Features, ",") {
+			errs = append(errs, fmt.Errorf("features %v do not match the options (want %v)", m.Features, want))
+		}
+	}
+
+	if module, err := projectModule(projectDir); err != nil {
+		errs = append(errs, err)
+	} else if m.Project.Module != "" && module != m.Project.Module {
+		errs = append(errs, fmt.Errorf("project.module is %s but go.mod declares %s", m.Project.Module, module))
+	}
+	return m, errs
+}
+
+// ManifestDrift compares a project's files with the checksums in its
+// manifest and returns those edited since goforge last wrote them and those
+// that no longer exist
+func ManifestDrift(projectDir string, m *manifest.Manifest) (modified, missing []string, err error) {
+	for rel, sum := range m.Files {
+		data, err := os.ReadFile(filepath.Join(projectDir, filepath.FromSlash(rel)))
+		if os.IsNotExist(err) {
+			missing = append(missing, rel)
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if manifest.Checksum(data) != sum {
+			modified = append(modified, rel)
+		}
+	}
+	sort.Strings(modified)
+	sort.Strings(missing)
+	return modified, missing, nil
+}
+
+// InitManifest writes a manifest for a project generated before goforge
+// recorded one. Options are unknown, so only the module and the checksums of
+// the current files are recorded.
+func InitManifest(projectDir string) (*manifest.Manifest, error) {
+	if _, err := os.Stat(manifest.Path(projectDir)); err == nil {
+		return nil, fmt.Errorf("%s already exists", manifest.Path(projectDir))
+	}
+	module, err := projectModule(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	abs, err := filepath.Abs(projectDir)
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string]string{}
+	err = filepath.WalkDir(projectDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != projectDir && (renameSkipDirs[d.Name()] || strings.HasPrefix(d.Name(), ".")) && d.Name() != ".github" {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || d.Name() == manifest.FileName {
+			return nil
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(projectDir, p)
+		files[filepath.ToSlash(rel)] = manifest.Checksum(data)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	m := &manifest.Manifest{
+		SchemaVersion: manifest.SchemaVersion,
+		Generator:     manifest.Generator{Version: Version},
+		Template:      manifest.Template{Source: "unknown"},
+		Project:       manifest.Project{Name: filepath.Base(abs), Module: module},
+		Options:       map[string]string{},
+		Files:         files,
+	}
+	return m, m.Save(projectDir)
+}
+
+// requireManifest loads the manifest post-generation commands need, so they
+// only run on projects goforge knows how it generated. Older schema versions
+// are migrated and saved by recordChanges.
+func requireManifest(projectDir string) (*manifest.Manifest, error) {
+	m, err := manifest.Load(projectDir)
+	if errors.Is(err, manifest.ErrNotFound) {
+		return nil, fmt.Errorf("%w: run 'goforge manifest init' in a project generated by an older goforge", err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// recordChanges refreshes the manifest after a post-generation command: the
+// files it generated get checksums, and the manifest is saved with the
+// current schema (Load already migrated it) and goforge version. Files the
+// command only edited (a route or worker registered at a marker) are left
+// out of generated and keep their checksums, so an upgrade treats them as
+// edited rather than overwriting the addition.
+func recordChanges(projectDir string, m *manifest.Manifest, generated []string) error {
+	if m.Files == nil {
+		m.Files = map[string]string{}
+	}
+	for _, rel := range generated {
+		rel = filepath.ToSlash(rel)
+		if rel == manifest.FileName {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(projectDir, filepath.FromSlash(rel)))
+		if err != nil {
+			return err
+		}
+		m.Files[path.Clean(rel)] = manifest.Checksum(data)
+	}
+	m.SchemaVersion = manifest.SchemaVersion
+	m.Generator.Version = Version
+	return m.Save(projectDir)
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/FACorreiaa/goforge/internal/manifest"
+)
+
+func TestGenerateWritesManifest(t *testing.T) {
+	projectDir := generateProject(t, Options{IncludeDB: true, Jobs: true, Search: SearchPgTrgm})
+
+	m, errs := ValidateManifest(projectDir)
+	if len(errs) > 0 {
+		t.Fatalf("ValidateManifest() = %v", errs)
+	}
+	if m.Project.Module != "github.com/test/app" || m.Template.Source != templateSourceEmbedded {
+		t.Errorf("manifest project/template = %+v %+v", m.Project, m.Template)
+	}
+	if want := []string{"db", "jobs", "search", "search-pgtrgm"}; !reflect.DeepEqual(m.Features, want) {
+		t.Errorf("features = %v, want %v", m.Features, want)
+	}
+	if m.Options["jobs"] != "true" || m.Options["search"] != SearchPgTrgm || m.Options["analytics"] != AnalyticsNone {
+		t.Errorf("options = %v", m.Options)
+	}
+
+	for _, f := range []string{"go.mod", "internal/jobs/jobs.go", "cmd/server/main.go"} {
+		if _, ok := m.Files[f]; !ok {
+			t.Errorf("manifest has no checksum for %s", f)
+		}
+	}
+	if _, ok := m.Files["internal/jobs/jobs.go.tmpl"]; ok {
+		t.Error("manifest records template names instead of generated paths")
+	}
+	modified, missing, err := ManifestDrift(projectDir, m)
+	if err != nil || len(modified)+len(missing) > 0 {
+		t.Errorf("ManifestDrift() on a fresh project = %v, %v, %v", modified, missing, err)
+	}
+
+	opts, errs := optionsFromManifest(m)
+	if len(errs) > 0 || !opts.Jobs || !opts.IncludeDB || opts.Search != SearchPgTrgm {
+		t.Errorf("optionsFromManifest() = %+v, %v", opts, errs)
+	}
+}
+
+func TestValidateManifestErrors(t *testing.T) {
+	projectDir := generateProject(t, Options{})
+	m, _ := manifest.Load(projectDir)
+	m.Options["frontend"] = "react"
+	m.Options["turbo"] = "true"
+	m.Features = append(m.Features, "rockets")
+	m.Project.Module = "github.com/test/other"
+	if err := m.Save(projectDir); err != nil {
+		t.Fatal(err)
+	}
+
+	_, errs := ValidateManifest(projectDir)
+	all := errors.Join(errs...).Error()
+	for _, want := range []string{`options.frontend: unknown value "react"`, "options.turbo is not a goforge option",
+		"unknown feature rockets", "go.mod declares github.com/test/app"} {
+		if !strings.Contains(all, want) {
+			t.Errorf("ValidateManifest() errors missing %q:\n%s", want, all)
+		}
+	}
+}
+
+func TestManifestDrift(t *testing.T) {
+	projectDir := generateProject(t, Options{})
+	m, _ := manifest.Load(projectDir)
+	if err := os.WriteFile(filepath.Join(projectDir, "Makefile"), []byte("all:\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(projectDir, "README.md")); err != nil {
+		t.Fatal(err)
+	}
+
+	modified, missing, err := ManifestDrift(projectDir, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(modified, []string{"Makefile"}) || !reflect.DeepEqual(missing, []string{"README.md"}) {
+		t.Errorf("ManifestDrift() = %v, %v", modified, missing)
+	}
+}
+
+func TestPostGenerationCommandsUpdateManifest(t *testing.T) {
+	projectDir := generateProject(t, Options{Jobs: true})
+	if _, err := AddWorker(projectDir, "cleanup"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := RenameModule(projectDir, "github.com/acme/shop"); err != nil {
+		t.Fatal(err)
+	}
+
+	m, errs := ValidateManifest(projectDir)
+	if len(errs) > 0 {
+		t.Fatalf("ValidateManifest() after add and rename = %v", errs)
+	}
+	if m.Project.Module != "github.com/acme/shop" {
+		t.Errorf("project.module = %s, want the renamed module", m.Project.Module)
+	}
+	if _, ok := m.Files["internal/jobs/cleanup.go"]; !ok {
+		t.Error("added worker not recorded in the manifest")
+	}
+	// The registry only gained a line: it keeps its generated checksum, so
+	// an upgrade treats it as edited
+	modified, missing, _ := ManifestDrift(projectDir, m)
+	if !reflect.DeepEqual(modified, []string{"internal/jobs/registry.go"}) || len(missing) > 0 {
+		t.Errorf("ManifestDrift() after add and rename = %v %v, want only the registry modified", modified, missing)
+	}
+}
+
+func TestPostGenerationCommandsUpgradeManifest(t *testing.T) {
+	projectDir := generateProject(t, Options{Jobs: true})
+	// Rewrite the manifest as schema 1, which named features by condition
+	data, err := os.ReadFile(manifest.Path(projectDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	v1 := strings.Replace(string(data), fmt.Sprintf("schema_version: %d", manifest.SchemaVersion), "schema_version: 1", 1)
+	v1 = strings.Replace(v1, "  - jobs\n", "  - JOBS\n", 1)
+	if err := os.WriteFile(manifest.Path(projectDir), []byte(v1), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := AddWorker(projectDir, "cleanup"); err != nil {
+		t.Fatalf("AddWorker() with a schema 1 manifest error: %v", err)
+	}
+	data, _ = os.ReadFile(manifest.Path(projectDir))
+	m, err := manifest.Decode(data)
+	if err != nil {
+		t.Fatalf("manifest not saved in the current schema: %v", err)
+	}
+	if !reflect.DeepEqual(m.Features, []string{"jobs"}) {
+		t.Errorf("features = %v, want feature ids", m.Features)
+	}
+}
+
+func TestPostGenerationCommandsRequireManifest(t *testing.T) {
+	projectDir := generateProject(t, Options{Jobs: true})
+	if err := os.Remove(manifest.Path(projectDir)); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := AddWorker(projectDir, "cleanup")
+	if !errors.Is(err, manifest.ErrNotFound) || !strings.Contains(err.Error(), "manifest init") {
+		t.Fatalf("AddWorker() without a manifest error = %v", err)
+	}
+	assertFilesMissing(t, projectDir, "internal/jobs/cleanup.go")
+
+	m, err := InitManifest(projectDir)
+	if err != nil {
+		t.Fatalf("InitManifest() error: %v", err)
+	}
+	if m.Project.Module != "github.com/test/app" || m.Files["go.mod"] == "" {
+		t.Errorf("InitManifest() = %+v", m)
+	}
+	if _, errs := ValidateManifest(projectDir); len(errs) > 0 {
+		t.Errorf("ValidateManifest() after init = %v", errs)
+	}
+	if _, err := AddWorker(projectDir, "cleanup"); err != nil {
+		t.Errorf("AddWorker() after init error: %v", err)
+	}
+	if _, err := InitManifest(projectDir); err == nil {
+		t.Error("InitManifest() overwrote an existing manifest")
+	}
+}
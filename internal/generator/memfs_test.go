@@ -0,0 +1,20 @@
+package generator
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestMemFS(t *testing.T) {
+	tree := memFS{
+		"Makefile":                   []byte("all:\n"),
+		"internal/server/server.go":  []byte("package server\n"),
+		"internal/server/routes.go":  []byte("package server\n"),
+		"internal/config/config.go":  []byte("package config\n"),
+		"views/pages/index.templ":    []byte("package pages\n"),
+		"internal/database/.gitkeep": nil,
+	}
+	if err := fstest.TestFS(tree, "Makefile", "internal/server/server.go", "internal/database/.gitkeep", "views/pages/index.templ"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,46 @@
+package generator
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// AssetMirrorEnv sets --asset-mirror and the tools install mirror when the
+// flag is not given
+const AssetMirrorEnv = "GOFORGE_ASSET_MIRROR"
+
+// ParseAssetMirror checks a mirror base URL for --asset-mirror and returns it
+// without a trailing slash. A mirror serves https://host/path at
+// <mirror>/host/path, as an Artifactory or Nexus generic remote repository
+// does; "" means downloading from the upstream hosts.
+func ParseAssetMirror(s string) (string, error) {
+	s = strings.TrimRight(strings.TrimSpace(s), "/")
+	if s == "" {
+		return "", nil
+	}
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" || u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("invalid asset mirror %q (want a base URL like https://artifacts.example.com/cdn)", s)
+	}
+	return s, nil
+}
+
+// mirrorURL is where mirror serves the upstream https URL rawURL
+func mirrorURL(mirror, rawURL string) string {
+	if mirror == "" {
+		return rawURL
+	}
+	return mirror + "/" + strings.TrimPrefix(rawURL, "https://")
+}
+
+// makeAssetURL and dockerAssetURL write an upstream https URL so that it goes
+// through $ASSET_MIRROR when that is set: with it empty, "https:/" + "/host"
+// gives back the upstream URL
+func makeAssetURL(rawURL string) string {
+	return "$(or $(ASSET_MIRROR),https:/)/" + strings.TrimPrefix(rawURL, "https://")
+}
+
+func dockerAssetURL(rawURL string) string {
+	return `"${ASSET_MIRROR:-https:/}/` + strings.TrimPrefix(rawURL, "https://") + `"`
+}
@@ -0,0 +1,680 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ModelOptions configure `generate model`
+type ModelOptions struct {
+	// Type selects the struct when a Go file declares several
+	Type string
+	// Form also emits a Templ form component for the model
+	Form bool
+}
+
+// modelField is a column of a generated model
+type modelField struct {
+	Name       string // Go field
+	Column     string
+	GoType     string
+	SQLType    string // only for models parsed from Go
+	PrimaryKey bool
+	HasDefault bool // managed by the database (identity, defaulted key or timestamp): never written
+	Nullable   bool
+}
+
+// model is the schema `generate model` works from, parsed from either a Go
+// struct or a CREATE TABLE statement
+type model struct {
+	Type      string
+	Table     string
+	Fields    []modelField
+	UpSQL     string // the SQL file as given (table, indexes...), for SQL input
+	SourceSQL bool
+}
+
+var (
+	createTableRe  = regexp.MustCompile(`(?is)create\s+table\s+(?:if\s+not\s+exists\s+)?([\w."]+)\s*\(`)
+	sqlCommentRe   = regexp.MustCompile(`--[^\n]*`)
+	migrationNumRe = regexp.MustCompile(`^(\d+)_`)
+)
+
+// goInitialisms are upper-cased in generated field names (UserID, not UserId)
+var goInitialisms = map[string]bool{"id": true, "url": true, "http": true, "api": true, "json": true, "sql": true, "uuid": true, "ip": true}
+
+// fieldName is the exported Go name for a column, honouring initialisms
+func fieldName(n Name) string {
+	var b strings.Builder
+	for _, w := range n.Words {
+		if goInitialisms[w] {
+			b.WriteString(strings.ToUpper(w))
+		} else {
+			b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+		}
+	}
+	return b.String()
+}
+
+// pluralize and singularize cover the regular English forms used in table names
+func pluralize(s string) string {
+	switch {
+	case strings.HasSuffix(s, "y") && !strings.HasSuffix(s, "ay") && !strings.HasSuffix(s, "ey") && !strings.HasSuffix(s, "oy"):
+		return s[:len(s)-1] + "ies"
+	case strings.HasSuffix(s, "s"), strings.HasSuffix(s, "x"), strings.HasSuffix(s, "ch"), strings.HasSuffix(s, "sh"):
+		return s + "es"
+	}
+	return s + "s"
+}
+
+func singularize(s string) string {
+	switch {
+	case strings.HasSuffix(s, "ies"):
+		return s[:len(s)-3] + "y"
+	case strings.HasSuffix(s, "sses"), strings.HasSuffix(s, "xes"), strings.HasSuffix(s, "ches"), strings.HasSuffix(s, "shes"):
+		return s[:len(s)-2]
+	case strings.HasSuffix(s, "ss"):
+		return s
+	case strings.HasSuffix(s, "s"):
+		return s[:len(s)-1]
+	}
+	return s
+}
+
+// parseModelStruct reads a struct declaration: columns come from `db` tags or
+// the snake_cased field name; an ID field becomes the primary key and
+// CreatedAt/UpdatedAt default to now()
+func parseModelStruct(src []byte, typeName string) (model, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), "model.go", src, 0)
+	if err != nil {
+		return model{}, fmt.Errorf("parse Go file: %w", err)
+	}
+
+	var spec *ast.TypeSpec
+	var st *ast.StructType
+	var structs []string
+	ast.Inspect(file, func(n ast.Node) bool {
+		ts, ok := n.(*ast.TypeSpec)
+		if !ok {
+			return true
+		}
+		if s, ok := ts.Type.(*ast.StructType); ok {
+			structs = append(structs, ts.Name.Name)
+			if typeName == "" || ts.Name.Name == typeName {
+				if spec == nil {
+					spec, st = ts, s
+				}
+			}
+		}
+		return false
+	})
+	switch {
+	case spec == nil && typeName != "":
+		return model{}, fmt.Errorf("no struct %s in the Go file", typeName)
+	case spec == nil:
+		return model{}, fmt.Errorf("no struct declaration in the Go file")
+	case typeName == "" && len(structs) > 1:
+		return model{}, fmt.Errorf("the Go file declares %s: choose one with --type", strings.Join(structs, ", "))
+	}
+
+	typ, err := ParseName(spec.Name.Name)
+	if err != nil {
+		return model{}, err
+	}
+	m := model{Type: spec.Name.Name, Table: pluralize(typ.Snake())}
+
+	for _, f := range st.Fields.List {
+		column := ""
+		if f.Tag != nil {
+			tag, _ := strconv.Unquote(f.Tag.Value)
+			column, _, _ = strings.Cut(reflect.StructTag(tag).Get("db"), ",")
+		}
+		if column == "-" {
+			continue
+		}
+		goType, nullable, err := goFieldType(f.Type)
+		if err != nil {
+			return model{}, err
+		}
+		for _, ident := range f.Names {
+			if !ident.IsExported() {
+				continue
+			}
+			n, err := ParseName(ident.Name)
+			if err != nil {
+				return model{}, err
+			}
+			field := modelField{Name: ident.Name, Column: column, GoType: goType, Nullable: nullable}
+			if field.Column == "" {
+				field.Column = n.Snake()
+			}
+			if err := field.setSQLType(); err != nil {
+				return model{}, fmt.Errorf("field %s: %w", ident.Name, err)
+			}
+			m.Fields = append(m.Fields, field)
+		}
+	}
+	return m, m.validate()
+}
+
+// goFieldType renders a supported field type, unwrapping pointers as nullable
+func goFieldType(expr ast.Expr) (string, bool, error) {
+	nullable := false
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr, nullable = star.X, true
+	}
+	var goType string
+	switch t := expr.(type) {
+	case *ast.Ident:
+		goType = t.Name
+	case *ast.SelectorExpr:
+		if pkg, ok := t.X.(*ast.Ident); ok {
+			goType = pkg.Name + "." + t.Sel.Name
+		}
+	case *ast.ArrayType:
+		if elt, ok := t.Elt.(*ast.Ident); ok && t.Len == nil && elt.Name == "byte" {
+			goType = "[]byte"
+		}
+	}
+	if _, ok := goSQLTypes[goType]; !ok {
+		return "", false, fmt.Errorf("unsupported field type %s: use string, bool, int*, float*, time.Time or []byte", exprString(expr))
+	}
+	if nullable && goType != "[]byte" {
+		return "*" + goType, true, nil
+	}
+	return goType, nullable || goType == "[]byte", nil
+}
+
+func exprString(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.SelectorExpr:
+		return exprString(t.X) + "." + t.Sel.Name
+	case *ast.ArrayType:
+		return "[]" + exprString(t.Elt)
+	case *ast.MapType:
+		return "map[" + exprString(t.Key) + "]" + exprString(t.Value)
+	}
+	return fmt.Sprintf("%T", expr)
+}
+
+// goSQLTypes maps Go field types to Postgres column types
+var goSQLTypes = map[string]string{
+	"string":    "TEXT",
+	"bool":      "BOOLEAN",
+	"int":       "BIGINT",
+	"int64":     "BIGINT",
+	"int32":     "INTEGER",
+	"int16":     "SMALLINT",
+	"float64":   "DOUBLE PRECISION",
+	"float32":   "REAL",
+	"time.Time": "TIMESTAMPTZ",
+	"[]byte":    "BYTEA",
+}
+
+// setSQLType derives the column definition of a field parsed from Go
+func (f *modelField) setSQLType() error {
+	base := strings.TrimPrefix(f.GoType, "*")
+	sqlType := goSQLTypes[base]
+
+	switch {
+	case f.Column == "id" && (base == "int" || base == "int64"):
+		f.SQLType, f.PrimaryKey, f.HasDefault = "BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY", true, true
+	case f.Column == "id" && base == "string":
+		f.SQLType, f.PrimaryKey, f.HasDefault = "UUID PRIMARY KEY DEFAULT gen_random_uuid()", true, true
+	case f.Column == "id":
+		return fmt.Errorf("id must be an int, int64 or string (UUID)")
+	case (f.Column == "created_at" || f.Column == "updated_at") && base == "time.Time" && !f.Nullable:
+		f.SQLType, f.HasDefault = "TIMESTAMPTZ NOT NULL DEFAULT now()", true
+	case f.Nullable:
+		f.SQLType = sqlType
+	default:
+		f.SQLType = sqlType + " NOT NULL"
+	}
+	return nil
+}
+
+// sqlGoTypes maps Postgres column types (without modifiers) to Go types
+var sqlGoTypes = map[string]string{
+	"text": "string", "varchar": "string", "character varying": "string", "char": "string",
+	"character": "string", "citext": "string", "uuid": "string", "inet": "string",
+	"boolean": "bool", "bool": "bool",
+	"smallint": "int16", "int2": "int16", "smallserial": "int16",
+	"integer": "int32", "int": "int32", "int4": "int32", "serial": "int32",
+	"bigint": "int64", "int8": "int64", "bigserial": "int64",
+	"real": "float32", "float4": "float32",
+	"double precision": "float64", "float8": "float64", "numeric": "float64", "decimal": "float64",
+	"timestamp": "time.Time", "timestamptz": "time.Time", "timestamp with time zone": "time.Time",
+	"timestamp without time zone": "time.Time", "date": "time.Time",
+	"bytea": "[]byte", "json": "[]byte", "jsonb": "[]byte",
+}
+
+// sqlConstraintWords end the type part of a column definition
+var sqlConstraintWords = map[string]bool{
+	"not": true, "null": true, "primary": true, "unique": true, "default": true, "references": true,
+	"check": true, "constraint": true, "generated": true, "collate": true,
+}
+
+// parseModelSQL reads the first CREATE TABLE statement of src
+func parseModelSQL(src string) (model, error) {
+	clean := sqlCommentRe.ReplaceAllString(src, "")
+	loc := createTableRe.FindStringSubmatchIndex(clean)
+	if loc == nil {
+		return model{}, fmt.Errorf("no CREATE TABLE statement found")
+	}
+	table := strings.ReplaceAll(clean[loc[2]:loc[3]], `"`, "")
+
+	body, _, err := parenBody(clean, loc[1]-1)
+	if err != nil {
+		return model{}, err
+	}
+	bare := table[strings.LastIndex(table, ".")+1:]
+	typ, err := ParseName(singularize(bare))
+	if err != nil {
+		return model{}, fmt.Errorf("table %s: %w", table, err)
+	}
+	m := model{Type: typ.Pascal(), Table: table, SourceSQL: true}
+	m.UpSQL = strings.TrimSpace(src)
+	if !strings.HasSuffix(m.UpSQL, ";") {
+		m.UpSQL += ";"
+	}
+
+	var tablePK []string
+	for _, def := range splitTopLevel(body) {
+		words := strings.Fields(def)
+		if len(words) == 0 {
+			continue
+		}
+		first := strings.ToLower(words[0])
+		switch first {
+		case "primary":
+			if open := strings.Index(def, "("); open >= 0 {
+				inner, _, err := parenBody(def, open)
+				if err != nil {
+					return model{}, err
+				}
+				for _, c := range strings.Split(inner, ",") {
+					tablePK = append(tablePK, strings.Trim(strings.TrimSpace(c), `"`))
+				}
+			}
+			continue
+		case "constraint", "unique", "foreign", "check", "exclude":
+			continue
+		}
+
+		field, err := parseSQLColumn(words)
+		if err != nil {
+			return model{}, fmt.Errorf("table %s: %w", table, err)
+		}
+		m.Fields = append(m.Fields, field)
+	}
+	for i := range m.Fields {
+		for _, pk := range tablePK {
+			if m.Fields[i].Column == pk {
+				m.Fields[i].PrimaryKey = true
+				m.Fields[i].Nullable = false
+				m.Fields[i].GoType = strings.TrimPrefix(m.Fields[i].GoType, "*")
+			}
+		}
+	}
+	return m, m.validate()
+}
+
+// parseSQLColumn maps one column definition to a field
+func parseSQLColumn(words []string) (modelField, error) {
+	column := strings.Trim(words[0], `"`)
+	n, err := ParseName(column)
+	if err != nil {
+		return modelField{}, fmt.Errorf("column %s: %w", column, err)
+	}
+
+	var typeWords []string
+	rest := words[1:]
+	for len(rest) > 0 && !sqlConstraintWords[strings.ToLower(rest[0])] {
+		typeWords = append(typeWords, strings.ToLower(rest[0]))
+		rest = rest[1:]
+	}
+	sqlType := strings.Join(typeWords, " ")
+	if i := strings.Index(sqlType, "("); i >= 0 {
+		sqlType = strings.TrimSpace(sqlType[:i] + sqlType[strings.LastIndex(sqlType, ")")+1:])
+	}
+	goType, ok := sqlGoTypes[sqlType]
+	if !ok {
+		return modelField{}, fmt.Errorf("column %s: unsupported type %q", column, sqlType)
+	}
+
+	constraints := " " + strings.ToLower(strings.Join(rest, " ")) + " "
+	field := modelField{
+		Name:       fieldName(n),
+		Column:     column,
+		GoType:     goType,
+		PrimaryKey: strings.Contains(constraints, " primary key "),
+	}
+	// Other defaults (DEFAULT 0, DEFAULT 'draft') stay writable
+	hasDefault := strings.Contains(constraints, " default ")
+	field.HasDefault = strings.Contains(constraints, " generated ") || strings.HasSuffix(sqlType, "serial") ||
+		(hasDefault && (field.PrimaryKey || goType == "time.Time"))
+	field.Nullable = !field.PrimaryKey && !strings.Contains(constraints, " not null ")
+	if field.Nullable && goType != "[]byte" {
+		field.GoType = "*" + goType
+	}
+	return field, nil
+}
+
+// parenBody returns the text inside the parenthesis opening at s[open] and
+// the index of the matching close
+func parenBody(s string, open int) (string, int, error) {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return s[open+1 : i], i, nil
+			}
+		}
+	}
+	return "", 0, fmt.Errorf("unbalanced parentheses in CREATE TABLE")
+}
+
+// splitTopLevel splits on commas outside parentheses
+func splitTopLevel(s string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, r := range s {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, strings.TrimSpace(s[start:]))
+}
+
+// validate requires a single-column primary key, which the repository
+// methods look rows up by
+func (m model) validate() error {
+	if len(m.Fields) == 0 {
+		return fmt.Errorf("%s has no columns", m.Type)
+	}
+	var pks int
+	for _, f := range m.Fields {
+		if f.PrimaryKey {
+			pks++
+		}
+	}
+	if pks != 1 {
+		return fmt.Errorf("%s needs exactly one primary key column (an ID field or PRIMARY KEY column), found %d", m.Type, pks)
+	}
+	return nil
+}
+
+// readModel parses a .sql file as CREATE TABLE and anything else as Go
+func readModel(inputPath, typeName string) (model, error) {
+	src, err := os.ReadFile(inputPath)
+	if err != nil {
+		return model{}, err
+	}
+	if strings.EqualFold(filepath.Ext(inputPath), ".sql") {
+		return parseModelSQL(string(src))
+	}
+	return parseModelStruct(src, typeName)
+}
+
+// nextMigration returns the next goose sequence number in dir, as 00003
+func nextMigration(dir string) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	highest := 0
+	for _, e := range entries {
+		if match := migrationNumRe.FindStringSubmatch(e.Name()); match != nil {
+			if n, _ := strconv.Atoi(match[1]); n > highest {
+				highest = n
+			}
+		}
+	}
+	return fmt.Sprintf("%05d", highest+1), nil
+}
+
+// modelData is what the model snippets render: the SQL is assembled here so
+// the templates stay declarative
+type modelData struct {
+	model
+	Var        string
+	PK         modelField
+	Columns    string
+	NeedsTime  bool
+	InsertSQL  string
+	InsertArgs string
+	UpdateSQL  string
+	UpdateArgs string
+	FormFields []formField
+	// FactoryFields are the columns a pkg/factory builder sets: every one
+	// the database does not fill in
+	FactoryFields []factoryField
+	FactorySeq    bool // a default uses the sequence number
+	FactoryFmt    bool
+	FactoryTime   bool
+	// Module is set for projects whose repositories query through the
+	// database package: Conn joins WithTx transactions (Tx), and
+	// database.Router routes reads and writes (Routed, --db-replicas)
+	Module string
+	Tx     bool
+	Routed bool
+}
+
+// formField is an input of the generated Templ form
+type formField struct {
+	Column    string
+	Label     string
+	InputType string
+	Step      string // "any" for floats
+	Required  bool
+}
+
+// factoryField is a With method of a generated test data builder, and the
+// default it starts from (the zero value when Value is empty)
+type factoryField struct {
+	Name    string
+	Param   string // the method's parameter type: pointers are taken by value
+	Pointer bool
+	Value   string
+}
+
+func newModelData(m model) modelData {
+	d := modelData{model: m}
+	d.Var = strings.ToLower(m.Type[:1]) + m.Type[1:]
+
+	var columns, insertCols, insertArgs, placeholders, sets, updateArgs []string
+	for _, f := range m.Fields {
+		columns = append(columns, f.Column)
+		if strings.Contains(f.GoType, "time.Time") {
+			d.NeedsTime = true
+		}
+		if f.PrimaryKey {
+			d.PK = f
+		}
+		if !f.HasDefault {
+			d.addFactoryField(f)
+			insertCols = append(insertCols, f.Column)
+			insertArgs = append(insertArgs, "m."+f.Name)
+			placeholders = append(placeholders, "$"+strconv.Itoa(len(insertCols)))
+		}
+		switch {
+		case f.PrimaryKey:
+		case f.Column == "updated_at" && f.HasDefault:
+			sets = append(sets, "updated_at = now()")
+		case !f.HasDefault:
+			updateArgs = append(updateArgs, "m."+f.Name)
+			sets = append(sets, fmt.Sprintf("%s = $%d", f.Column, len(updateArgs)))
+			d.FormFields = append(d.FormFields, newFormField(f))
+		}
+	}
+	d.Columns = strings.Join(columns, ", ")
+
+	if len(insertCols) == 0 {
+		d.InsertSQL = fmt.Sprintf("INSERT INTO %s DEFAULT VALUES RETURNING %s", m.Table, d.Columns)
+	} else {
+		d.InsertSQL = fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) RETURNING %s",
+			m.Table, strings.Join(insertCols, ", "), strings.Join(placeholders, ", "), d.Columns)
+		d.InsertArgs = ", " + strings.Join(insertArgs, ", ")
+	}
+	if len(updateArgs) > 0 {
+		d.UpdateSQL = fmt.Sprintf("UPDATE %s SET %s WHERE %s = $%d RETURNING %s",
+			m.Table, strings.Join(sets, ", "), d.PK.Column, len(updateArgs)+1, d.Columns)
+		d.UpdateArgs = ", " + strings.Join(append(updateArgs, "m."+d.PK.Name), ", ")
+	}
+	return d
+}
+
+// addFactoryField adds a builder field with a valid default: unique text
+// from the sequence for required strings and keys, now for required times
+// and an empty JSON object for required bytes. Other columns start at their
+// zero value, nullable ones at nil.
+func (d *modelData) addFactoryField(f modelField) {
+	ff := factoryField{Name: f.Name, Param: strings.TrimPrefix(f.GoType, "*"), Pointer: strings.HasPrefix(f.GoType, "*")}
+	n, _ := ParseName(f.Column)
+	switch {
+	case f.Nullable:
+	case f.PrimaryKey && ff.Param == "string":
+		ff.Value = fmt.Sprintf("fmt.Sprintf(%q, n)", d.Var+"-%d")
+	case f.PrimaryKey && ff.Param != "[]byte" && !strings.HasPrefix(ff.Param, "time") && ff.Param != "bool":
+		ff.Value = ff.Param + "(n)"
+	case ff.Param == "string" && strings.Contains(f.Column, "email"):
+		ff.Value = fmt.Sprintf("fmt.Sprintf(%q, n)", d.Var+"%d@example.com")
+	case ff.Param == "string":
+		ff.Value = fmt.Sprintf("fmt.Sprintf(%q, n)", strings.Join(n.Words, " ")+" %d")
+	case ff.Param == "time.Time":
+		ff.Value = "time.Now().UTC().Truncate(time.Microsecond)"
+	case ff.Param == "[]byte":
+		ff.Value = `[]byte("{}")`
+	}
+	d.FactorySeq = d.FactorySeq || strings.Contains(ff.Value, "(n)") || strings.Contains(ff.Value, ", n)")
+	d.FactoryFmt = d.FactoryFmt || strings.HasPrefix(ff.Value, "fmt.")
+	d.FactoryTime = d.FactoryTime || ff.Param == "time.Time"
+	d.FactoryFields = append(d.FactoryFields, ff)
+}
+
+func newFormField(f modelField) formField {
+	n, _ := ParseName(f.Column)
+	label := strings.Join(n.Words, " ")
+	ff := formField{Column: f.Column, Label: strings.ToUpper(label[:1]) + label[1:], InputType: "text", Required: !f.Nullable}
+
+	switch strings.TrimPrefix(f.GoType, "*") {
+	case "bool":
+		ff.InputType, ff.Required = "checkbox", false
+	case "int", "int16", "int32", "int64":
+		ff.InputType = "number"
+	case "float32", "float64":
+		ff.InputType, ff.Step = "number", "any"
+	case "time.Time":
+		ff.InputType = "datetime-local"
+	case "[]byte":
+		ff.InputType = "textarea"
+	}
+	if strings.Contains(f.Column, "email") {
+		ff.InputType = "email"
+	}
+	return ff
+}
+
+// factorySnippets are the test data builder of a model and the repository
+// test that uses it, for projects generated with --factories
+func factorySnippets(typ Name) []struct{ path, snippet string } {
+	return []struct{ path, snippet string }{
+		{filepath.Join("pkg", "factory", typ.Snake()+".go"), "factory.go.tmpl"},
+		{filepath.Join("internal", "repository", typ.Snake()+"_test.go"), "repository_test.go.tmpl"},
+	}
+}
+
+// GenerateModel reads a Go struct or a CREATE TABLE statement and adds the
+// matching goose migration and repository to a project with a database, plus
+// a Templ form component when opts.Form is set. It returns the created files,
+// relative to projectDir.
+func GenerateModel(projectDir, inputPath string, opts ModelOptions) ([]string, error) {
+	module, err := projectModule(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	mf, err := requireManifest(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	migrationsDir := filepath.Join(projectDir, "internal", "database", "migrations")
+	if _, err := os.Stat(migrationsDir); err != nil {
+		return nil, fmt.Errorf("%s has no database (internal/database/migrations): models need a project generated without --no-db", projectDir)
+	}
+
+	m, err := readModel(inputPath, opts.Type)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", inputPath, err)
+	}
+	d := newModelData(m)
+	d.Module = module
+	if _, err := os.Stat(filepath.Join(projectDir, "internal", "database", "tx.go")); err == nil {
+		d.Tx = true
+	}
+	if _, err := os.Stat(filepath.Join(projectDir, "internal", "database", "replicas.go")); err == nil {
+		d.Routed = true
+	}
+	typ, _ := ParseName(m.Type)
+
+	seq, err := nextMigration(migrationsDir)
+	if err != nil {
+		return nil, err
+	}
+	bareTable := m.Table[strings.LastIndex(m.Table, ".")+1:]
+	files := []struct{ path, snippet string }{
+		{filepath.Join("internal", "database", "migrations", seq+"_create_"+bareTable+".sql"), "model_migration.sql.tmpl"},
+		{filepath.Join("internal", "repository", typ.Snake()+".go"), "repository.go.tmpl"},
+	}
+	if opts.Form {
+		files = append(files, struct{ path, snippet string }{filepath.Join("views", "components", typ.Snake()+"_form.templ"), "model_form.templ.tmpl"})
+	}
+	if _, err := os.Stat(filepath.Join(projectDir, "pkg", "factory", "factory.go")); err == nil {
+		files = append(files, factorySnippets(typ)...)
+	}
+	for _, f := range files {
+		if _, err := os.Stat(filepath.Join(projectDir, f.path)); err == nil {
+			return nil, fmt.Errorf("%s already exists", f.path)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(projectDir, "internal", "repository", "repository.go")); os.IsNotExist(err) {
+		files = append(files, struct{ path, snippet string }{filepath.Join("internal", "repository", "repository.go"), "repository_base.go.tmpl"})
+	}
+
+	var changed []string
+	for _, f := range files {
+		content, err := renderSnippet(f.snippet, d)
+		if err != nil {
+			return nil, err
+		}
+		if err := writeNewFile(filepath.Join(projectDir, f.path), content); err != nil {
+			return nil, err
+		}
+		changed = append(changed, f.path)
+	}
+	if err := recordChanges(projectDir, mf, changed); err != nil {
+		return nil, err
+	}
+	return changed, nil
+}
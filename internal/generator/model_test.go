@@ -0,0 +1,248 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const noteStruct = `package schema
+
+import "time"
+
+type Note struct {
+	ID        int64
+	Title     string
+	Body      *string ` + "`db:\"content\"`" + `
+	Skip      string  ` + "`db:\"-\"`" + `
+	internal  string
+	CreatedAt time.Time
+}
+`
+
+const usersTable = `-- accounts
+CREATE TABLE IF NOT EXISTS users (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+    email VARCHAR(255) UNIQUE NOT NULL,
+    name VARCHAR(255),
+    price NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP
+    WITH
+        TIME ZONE DEFAULT NOW(),
+    CONSTRAINT users_email_check CHECK (email <> '')
+);
+CREATE INDEX idx_users_email ON users (email);
+`
+
+func TestParseModelStruct(t *testing.T) {
+	m, err := parseModelStruct([]byte(noteStruct), "")
+	if err != nil {
+		t.Fatalf("parseModelStruct() error: %v", err)
+	}
+	if m.Type != "Note" || m.Table != "notes" {
+		t.Errorf("got %s/%s, want Note/notes", m.Type, m.Table)
+	}
+
+	want := []string{
+		"id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY",
+		"title TEXT NOT NULL",
+		"content TEXT",
+		"created_at TIMESTAMPTZ NOT NULL DEFAULT now()",
+	}
+	if len(m.Fields) != len(want) {
+		t.Fatalf("got %d fields, want %d: %+v", len(m.Fields), len(want), m.Fields)
+	}
+	for i, f := range m.Fields {
+		if got := f.Column + " " + f.SQLType; got != want[i] {
+			t.Errorf("field %d = %q, want %q", i, got, want[i])
+		}
+	}
+}
+
+func TestParseModelSQL(t *testing.T) {
+	m, err := parseModelSQL(usersTable)
+	if err != nil {
+		t.Fatalf("parseModelSQL() error: %v", err)
+	}
+	if m.Type != "User" || m.Table != "users" {
+		t.Errorf("got %s/%s, want User/users", m.Type, m.Table)
+	}
+
+	want := []modelField{
+		{Name: "ID", Column: "id", GoType: "string", PrimaryKey: true, HasDefault: true},
+		{Name: "Email", Column: "email", GoType: "string"},
+		{Name: "Name", Column: "name", GoType: "*string", Nullable: true},
+		{Name: "Price", Column: "price", GoType: "float64"},
+		{Name: "CreatedAt", Column: "created_at", GoType: "*time.Time", HasDefault: true, Nullable: true},
+	}
+	if len(m.Fields) != len(want) {
+		t.Fatalf("got %d fields, want %d: %+v", len(m.Fields), len(want), m.Fields)
+	}
+	for i := range want {
+		if m.Fields[i] != want[i] {
+			t.Errorf("field %d = %+v, want %+v", i, m.Fields[i], want[i])
+		}
+	}
+	if !strings.Contains(m.UpSQL, "CREATE INDEX idx_users_email") {
+		t.Error("migration should keep the statements that follow CREATE TABLE")
+	}
+}
+
+func TestParseModelErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func() error
+		want string
+	}{
+		{"no primary key", func() error {
+			_, err := parseModelSQL("CREATE TABLE tags (name TEXT NOT NULL);")
+			return err
+		}, "primary key"},
+		{"unsupported SQL type", func() error {
+			_, err := parseModelSQL("CREATE TABLE tags (id INT PRIMARY KEY, labels TEXT[]);")
+			return err
+		}, "unsupported type"},
+		{"several structs", func() error {
+			_, err := parseModelStruct([]byte("package x\ntype A struct{ ID int }\ntype B struct{ ID int }\n"), "")
+			return err
+		}, "--type"},
+		{"unsupported Go type", func() error {
+			_, err := parseModelStruct([]byte("package x\ntype A struct{ ID int; Tags []string }\n"), "")
+			return err
+		}, "unsupported field type []string"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.run()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateModel(t *testing.T) {
+	projectDir := generateProject(t, Options{IncludeDB: true})
+	input := filepath.Join(t.TempDir(), "users.sql")
+	if err := os.WriteFile(input, []byte(usersTable), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := GenerateModel(projectDir, input, ModelOptions{Form: true}); err != nil {
+		t.Fatalf("GenerateModel() error: %v", err)
+	}
+	assertFilesExist(t, projectDir,
+		"internal/database/migrations/00002_create_users.sql",
+		"internal/repository/repository.go",
+		"internal/repository/user.go",
+		"views/components/user_form.templ",
+	)
+
+	repo := readProjectFile(t, projectDir, "internal/repository/user.go")
+	for _, want := range []string{
+		"INSERT INTO users (email, name, price) VALUES ($1, $2, $3)",
+		"UPDATE users SET email = $1, name = $2, price = $3 WHERE id = $4",
+		"func (r *UserRepository) Get(ctx context.Context, id string) (User, error)",
+		"rows, err := database.Conn(ctx, r.pool).Query(ctx, `SELECT",
+	} {
+		if !strings.Contains(repo, want) {
+			t.Errorf("repository missing %s", want)
+		}
+	}
+
+	form := readProjectFile(t, projectDir, "views/components/user_form.templ")
+	if !strings.Contains(form, `type="email"`) || strings.Contains(form, `name="created_at"`) {
+		t.Errorf("form fields should skip database-managed columns:\n%s", form)
+	}
+
+	if _, err := GenerateModel(projectDir, input, ModelOptions{}); err == nil {
+		t.Error("GenerateModel() overwrote an existing repository")
+	}
+}
+
+func TestGenerateModelWithoutTx(t *testing.T) {
+	// Projects generated before internal/database/tx.go query the pool directly
+	projectDir := generateProject(t, Options{IncludeDB: true})
+	if err := os.Remove(filepath.Join(projectDir, "internal", "database", "tx.go")); err != nil {
+		t.Fatal(err)
+	}
+	input := filepath.Join(t.TempDir(), "users.sql")
+	if err := os.WriteFile(input, []byte(usersTable), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := GenerateModel(projectDir, input, ModelOptions{}); err != nil {
+		t.Fatalf("GenerateModel() error: %v", err)
+	}
+	repo := readProjectFile(t, projectDir, "internal/repository/user.go")
+	if !strings.Contains(repo, "rows, err := r.pool.Query(ctx, `SELECT") || strings.Contains(repo, "internal/database") {
+		t.Errorf("repository should query the pool directly:\n%s", repo)
+	}
+}
+
+func TestGenerateModelWithReplicas(t *testing.T) {
+	projectDir := generateProject(t, Options{IncludeDB: true, DBReplicas: true})
+	input := filepath.Join(t.TempDir(), "users.sql")
+	if err := os.WriteFile(input, []byte(usersTable), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := GenerateModel(projectDir, input, ModelOptions{}); err != nil {
+		t.Fatalf("GenerateModel() error: %v", err)
+	}
+	repo := readProjectFile(t, projectDir, "internal/repository/user.go")
+	for _, want := range []string{
+		`"github.com/test/app/internal/database"`,
+		"func NewUserRepository(db *database.Router) *UserRepository",
+		"rows, err := r.db.Read(ctx).Query(ctx, `SELECT",
+		"rows, err := r.db.Write(ctx).Query(ctx, `INSERT",
+		"tag, err := r.db.Write(ctx).Exec(ctx, `DELETE",
+	} {
+		if !strings.Contains(repo, want) {
+			t.Errorf("repository missing %s", want)
+		}
+	}
+	if strings.Contains(repo, "pgxpool") {
+		t.Error("routed repository still uses pgxpool")
+	}
+}
+
+func TestGenerateModelWithoutDB(t *testing.T) {
+	projectDir := generateProject(t, Options{})
+
+	_, err := GenerateModel(projectDir, "notes.sql", ModelOptions{})
+	if err == nil || !strings.Contains(err.Error(), "--no-db") {
+		t.Fatalf("GenerateModel() error = %v, want it to explain the missing database", err)
+	}
+}
+
+func TestGenerateModelWithFactories(t *testing.T) {
+	projectDir := generateProject(t, Options{IncludeDB: true, Factories: true})
+	input := filepath.Join(t.TempDir(), "users.sql")
+	if err := os.WriteFile(input, []byte(usersTable), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := GenerateModel(projectDir, input, ModelOptions{}); err != nil {
+		t.Fatalf("GenerateModel() error: %v", err)
+	}
+	assertFilesExist(t, projectDir, "pkg/factory/user.go", "internal/repository/user_test.go")
+	factory := readProjectFile(t, projectDir, "pkg/factory/user.go")
+	for _, want := range []string{
+		`Email: fmt.Sprintf("user%d@example.com", n),`,
+		"func (b *UserBuilder) WithName(v string) *UserBuilder {\n\tb.m.Name = &v",
+		"func (b *UserBuilder) Create(ctx context.Context, t testing.TB, repo *repository.UserRepository) repository.User",
+	} {
+		if !strings.Contains(factory, want) {
+			t.Errorf("factory missing %s", want)
+		}
+	}
+	if strings.Contains(factory, "WithID") || strings.Contains(factory, "WithCreatedAt") {
+		t.Error("factory sets columns the database fills in")
+	}
+	if test := readProjectFile(t, projectDir, "internal/repository/user_test.go"); !strings.Contains(test, "ctx, pool := dbtest.Tx(t)") {
+		t.Errorf("repository test does not run in a dbtest transaction:\n%s", test)
+	}
+}
@@ -0,0 +1,144 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// modulesMarker is where `add module` adds feature module routes to the
+// registry in Server.Routes
+const modulesMarker = "// goforge:modules"
+
+// featureModule describes the package `add module` generates
+type featureModule struct {
+	Module    string // Go module of the project
+	Package   string // invoices
+	Type      string // Invoice
+	Var       string // invoice
+	PluralVar string // invoices, in messages
+	Title     string // Invoices
+	Kebab     string // line-items, for HTML ids
+	URL       string // /invoices
+	Table     string // invoices
+	BasePath  string // --base-path, prefixed to the module's links
+	HasDB     bool
+	HasSEO    bool
+}
+
+// newFeatureModule derives the module spellings from its name, singular or
+// plural: "invoice" and "invoices" both give package invoices, type Invoice
+// and routes under /invoices
+func newFeatureModule(name string) (featureModule, error) {
+	n, err := ParseName(name)
+	if err != nil {
+		return featureModule{}, err
+	}
+	singular, err := ParseName(singularize(n.Snake()))
+	if err != nil {
+		return featureModule{}, fmt.Errorf("invalid module name %q", name)
+	}
+	plural, _ := ParseName(pluralize(singular.Snake()))
+
+	fm := featureModule{
+		Package:   strings.Join(plural.Words, ""),
+		Type:      singular.Pascal(),
+		Var:       strings.Join(singular.Words, " "),
+		PluralVar: strings.Join(plural.Words, " "),
+		Kebab:     plural.Kebab(),
+		URL:       "/" + plural.Kebab(),
+		Table:     plural.Snake(),
+	}
+	fm.Title = strings.ToUpper(fm.PluralVar[:1]) + fm.PluralVar[1:]
+	return fm, nil
+}
+
+// AddModule adds a self-contained feature module to internal/modules: a
+// handler, service, repository, Templ views and a Routes function, whose
+// routes join the registry at the goforge:modules marker. Projects on
+// Postgres get a migration and a Postgres repository; the others keep the
+// module's data in memory. It returns the created and modified files,
+// relative to projectDir.
+func AddModule(projectDir, name string) ([]string, error) {
+	fm, err := newFeatureModule(name)
+	if err != nil {
+		return nil, err
+	}
+	if fm.Module, err = projectModule(projectDir); err != nil {
+		return nil, err
+	}
+	mf, err := requireManifest(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	fm.BasePath = mf.Options["base-path"]
+
+	routesRel := filepath.Join("internal", "server", "routes.go")
+	routes, err := os.ReadFile(filepath.Join(projectDir, routesRel))
+	if err != nil {
+		return nil, fmt.Errorf("%s has no %s: is it a GoForge project?", projectDir, routesRel)
+	}
+	if !strings.Contains(string(routes), modulesMarker) {
+		return nil, fmt.Errorf("%s has no %q marker: add it in Server.Routes, before the return", routesRel, modulesMarker)
+	}
+	if fm.Package == "routes" || strings.Contains(string(routes), "/"+fm.Package+"\"\n") {
+		return nil, fmt.Errorf("%s already uses the name %s: pick another module name", routesRel, fm.Package)
+	}
+
+	dir := filepath.Join("internal", "modules", fm.Package)
+	if _, err := os.Stat(filepath.Join(projectDir, dir)); err == nil {
+		return nil, fmt.Errorf("module %s already exists (%s)", fm.Package, dir)
+	}
+	migrationsDir := filepath.Join(projectDir, "internal", "database", "migrations")
+	_, dbErr := os.Stat(migrationsDir)
+	opts, _ := optionsFromManifest(mf)
+	fm.HasDB = dbErr == nil && usesPostgres(opts) // the repository is written for pgx
+	_, seoErr := os.Stat(filepath.Join(projectDir, "internal", "seo"))
+	fm.HasSEO = seoErr == nil
+
+	files := []struct{ path, snippet string }{
+		{filepath.Join(dir, "module.go"), "module.go.tmpl"},
+		{filepath.Join(dir, "handler.go"), "module_handler.go.tmpl"},
+		{filepath.Join(dir, "service.go"), "module_service.go.tmpl"},
+		{filepath.Join(dir, "service_test.go"), "module_service_test.go.tmpl"},
+		{filepath.Join(dir, "repository.go"), "module_repository.go.tmpl"},
+		{filepath.Join(dir, "views.templ"), "module_views.templ.tmpl"},
+	}
+	if fm.HasDB {
+		seq, err := nextMigration(migrationsDir)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, struct{ path, snippet string }{
+			filepath.Join("internal", "database", "migrations", seq+"_create_"+fm.Table+".sql"), "module_migration.sql.tmpl"})
+	}
+
+	var changed []string
+	for _, f := range files {
+		content, err := renderSnippet(f.snippet, fm)
+		if err != nil {
+			return nil, err
+		}
+		if err := writeNewFile(filepath.Join(projectDir, f.path), content); err != nil {
+			return nil, err
+		}
+		changed = append(changed, f.path)
+	}
+
+	routesPath := filepath.Join(projectDir, routesRel)
+	call := "routes = append(routes, " + fm.Package + ".Routes()...)"
+	if fm.HasDB {
+		call = "routes = append(routes, " + fm.Package + ".Routes(s.db)...)"
+	}
+	if err := addImport(routesPath, fm.Module+"/internal/modules/"+fm.Package); err != nil {
+		return nil, err
+	}
+	if err := insertAtMarker(routesPath, modulesMarker, call); err != nil {
+		return nil, err
+	}
+	if err := recordChanges(projectDir, mf, changed); err != nil {
+		return nil, err
+	}
+	return append(changed, routesRel), nil
+}
@@ -0,0 +1,76 @@
+package generator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/FACorreiaa/goforge/internal/manifest"
+)
+
+func TestAddModule(t *testing.T) {
+	projectDir := generateProject(t, Options{IncludeDB: true, SEO: true})
+
+	if _, err := AddModule(projectDir, "invoice"); err != nil {
+		t.Fatalf("AddModule() error: %v", err)
+	}
+	assertFilesExist(t, projectDir,
+		"internal/modules/invoices/module.go",
+		"internal/modules/invoices/handler.go",
+		"internal/modules/invoices/service.go",
+		"internal/modules/invoices/service_test.go",
+		"internal/modules/invoices/repository.go",
+		"internal/modules/invoices/views.templ",
+		"internal/database/migrations/00002_create_invoices.sql",
+	)
+
+	routes := readProjectFile(t, projectDir, "internal/server/routes.go")
+	for _, want := range []string{
+		`"github.com/test/app/internal/modules/invoices"`,
+		"routes = append(routes, invoices.Routes(s.db)...)\n\t" + modulesMarker,
+	} {
+		if !strings.Contains(routes, want) {
+			t.Errorf("routes.go missing %q", want)
+		}
+	}
+	if repo := readProjectFile(t, projectDir, "internal/modules/invoices/repository.go"); !strings.Contains(repo, "FROM invoices") {
+		t.Error("repository.go does not query the invoices table")
+	}
+	if views := readProjectFile(t, projectDir, "internal/modules/invoices/views.templ"); !strings.Contains(views, "seo.PageMeta{") {
+		t.Error("views.templ does not pass seo.PageMeta to the SEO layout")
+	}
+	m, err := manifest.Load(projectDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := m.Files["internal/database/migrations/00002_create_invoices.sql"]; !ok {
+		t.Error("migration not recorded in the manifest")
+	}
+	if modified, _, _ := ManifestDrift(projectDir, m); !reflect.DeepEqual(modified, []string{"internal/server/routes.go"}) {
+		t.Errorf("ManifestDrift() modified = %v, want routes.go to keep its generated checksum", modified)
+	}
+
+	if _, err := AddModule(projectDir, "invoices"); err == nil {
+		t.Error("AddModule() of an existing module succeeded, want an error")
+	}
+	if _, err := AddModule(projectDir, "page"); err == nil {
+		t.Error("AddModule(page) succeeded, want a package name conflict with views/pages")
+	}
+}
+
+func TestAddModuleWithoutDB(t *testing.T) {
+	projectDir := generateProject(t, Options{BasePath: "/app"})
+
+	if _, err := AddModule(projectDir, "line-items"); err != nil {
+		t.Fatalf("AddModule() error: %v", err)
+	}
+	if routes := readProjectFile(t, projectDir, "internal/server/routes.go"); !strings.Contains(routes, "routes = append(routes, lineitems.Routes()...)\n") {
+		t.Error("routes.go does not register the module without a pool")
+	}
+	if repo := readProjectFile(t, projectDir, "internal/modules/lineitems/repository.go"); !strings.Contains(repo, "type memoryRepository struct") {
+		t.Error("repository.go is not in memory without a database")
+	}
+	if handler := readProjectFile(t, projectDir, "internal/modules/lineitems/handler.go"); !strings.Contains(handler, `"/app/line-items"`) {
+		t.Error("handler.go redirect ignores the base path")
+	}
+}
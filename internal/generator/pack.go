@@ -0,0 +1,201 @@
+package generator
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+//go:generate go run ./packtemplates
+
+// baseGroup holds every template no other group claims
+const baseGroup = "base"
+
+// PackedDir is where go generate writes the template groups of builds with
+// the packed_templates tag, relative to this package
+const PackedDir = "packed"
+
+// templateGroup is a stack whose templates can be left out of the binary:
+// the templates whose paths start with one of its prefixes, each gated by
+// featurePaths
+type templateGroup struct {
+	name     string
+	prefixes []string
+}
+
+// templateGroups split the templates for builds with the packed_templates
+// tag: each is compressed on its own, unpacked the first time a project
+// needs it, and left out by the goforge_no_<name> build tag. A template
+// belongs to the first group with a prefix of its path, or to the base.
+var templateGroups = []templateGroup{
+	{"sqlite", []string{"internal/database/sqlite.go", "litestream.yml", "fly.toml", "litefs.yml", "internal/database/litefs.go", "internal/middleware/litefs.go"}},
+	{"deploy", []string{"deploy", "proxy", "pgbouncer"}},
+	{"vite", []string{"vite.config.ts", "package.json", "frontend", "internal/vite", "internal/middleware/vite.go", "views/components/vite.templ"}},
+	{"content", []string{"content", "internal/blog", "internal/server/blog.go", "views/pages/blog.templ"}},
+	{"search", []string{"internal/search", "internal/database/migrations/00002_search", "internal/server/search.go", "views/pages/search.templ"}},
+	{"pdf", []string{"internal/pdf", "internal/server/invoice.go", "views/documents"}},
+	{"images", []string{"internal/images", "internal/server/images.go", "views/components/picture.templ", "views/pages/images.templ"}},
+	{"notifications", []string{"internal/notify", "internal/server/notifications.go", "views/components/notifications.templ", "views/pages/notifications.templ", "internal/database/migrations/00003_notifications"}},
+	{"activity", []string{"internal/activity", "internal/server/activity.go", "views/components/timeline.templ", "views/pages/activity.templ", "internal/database/migrations/00004_activity"}},
+	{"settings", []string{"internal/settings", "internal/server/settings.go", "views/pages/settings.templ", "internal/database/migrations/00005_settings"}},
+	{"onboarding", []string{"internal/onboarding", "internal/server/onboarding.go", "views/components/progress.templ", "views/pages/onboarding.templ", "internal/database/migrations/00006_onboarding"}},
+	{"teams", []string{"internal/teams", "internal/server/teams.go", "views/pages/teams.templ", "internal/database/migrations/00007_teams"}},
+	{"billing", []string{"internal/billing", "internal/server/billing.go", "views/pages/pricing.templ", "internal/database/migrations/00008_billing"}},
+	{"postgis", []string{"internal/places", "internal/server/places.go", "views/pages/places.templ", "internal/database/migrations/00009_places"}},
+	{"demo", []string{"internal/demo", "cmd/demo", "views/components/demo.templ"}},
+	{"loadtest", []string{"loadtest", ".github/workflows/loadtest.yml"}},
+	{"e2e", []string{"e2e", ".github/workflows/e2e.yml"}},
+}
+
+// groupOf is the group a template belongs to
+func groupOf(relPath string) string {
+	for _, g := range templateGroups {
+		for _, prefix := range g.prefixes {
+			if strings.HasPrefix(relPath, prefix) { // as in skipPath
+				return g.name
+			}
+		}
+	}
+	return baseGroup
+}
+
+// neededGroups lists the groups generating opts reads, the base first
+func neededGroups(opts Options) []string {
+	groups := []string{baseGroup}
+	for _, g := range templateGroups {
+		for _, prefix := range g.prefixes {
+			if !skipPath(prefix, opts) {
+				groups = append(groups, g.name)
+				break
+			}
+		}
+	}
+	return groups
+}
+
+// checkGroups rejects options needing a group this goforge was built without
+func checkGroups(opts Options) error {
+	for _, g := range neededGroups(opts) {
+		if !availableGroup(g) {
+			return fmt.Errorf("this goforge was built without the %s templates (goforge_no_%s): use a full build", g, g)
+		}
+	}
+	return nil
+}
+
+// templatesOf returns the templates of the groups opts needs
+func templatesOf(opts Options) (fs.FS, error) {
+	return groupTemplates(neededGroups(opts))
+}
+
+// PackTemplates writes each template group as a gzip-compressed tar to
+// dir/packed/<group>.tar.gz, and next to it in dir the Go file embedding it
+// in builds with the packed_templates tag unless goforge_no_<group> is set.
+// The archives are reproducible: sorted, with no timestamps or owners. They
+// use gzip because the standard library has no zstd. zstd would pack them
+// about 11% smaller, saving some 26 KB of the binary.
+func PackTemplates(dir string) error {
+	files, err := templateFiles(Templates())
+	if err != nil {
+		return err
+	}
+	byGroup := map[string][]string{}
+	for p := range files {
+		g := groupOf(p)
+		byGroup[g] = append(byGroup[g], p)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, PackedDir), 0755); err != nil {
+		return err
+	}
+
+	for _, g := range append([]string{baseGroup}, groupNames()...) {
+		paths := byGroup[g]
+		sort.Strings(paths)
+		var buf bytes.Buffer
+		gz, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+		if err != nil {
+			return err
+		}
+		tw := tar.NewWriter(gz)
+		for _, p := range paths {
+			hdr := &tar.Header{Name: p, Mode: 0644, Size: int64(len(files[p])), Typeflag: tar.TypeReg, Format: tar.FormatPAX}
+			if err := tw.WriteHeader(hdr); err != nil {
+				return err
+			}
+			if _, err := io.WriteString(tw, files[p]); err != nil {
+				return err
+			}
+		}
+		if err := tw.Close(); err != nil {
+			return err
+		}
+		if err := gz.Close(); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(dir, PackedDir, g+".tar.gz"), buf.Bytes(), 0644); err != nil {
+			return err
+		}
+
+		constraint := "packed_templates"
+		if g != baseGroup {
+			constraint += " && !goforge_no_" + g
+		}
+		variable := "packed" + strings.ToUpper(g[:1]) + g[1:]
+		src := fmt.Sprintf(`// Code generated by go generate; DO NOT EDIT.
+
+//go:build %s
+
+package generator
+
+import _ "embed"
+
+//go:embed %s/%s.tar.gz
+var %s []byte
+
+func init() { packedGroups[%q] = %s }
+`, constraint, PackedDir, g, variable, g, variable)
+		if err := os.WriteFile(filepath.Join(dir, "zz_packed_"+g+".go"), []byte(src), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// groupNames lists the optional groups in table order
+func groupNames() []string {
+	names := make([]string, len(templateGroups))
+	for i, g := range templateGroups {
+		names[i] = g.name
+	}
+	return names
+}
+
+// unpackGroup reads a group archive written by PackTemplates into tree
+func unpackGroup(archive []byte, tree memFS) error {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return err
+	}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return err
+		}
+		tree[hdr.Name] = data
+	}
+}
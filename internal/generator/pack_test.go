@@ -0,0 +1,123 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestTemplateGroups(t *testing.T) {
+	files, err := templateFiles(Templates())
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := map[string]bool{}
+	for _, g := range templateGroups {
+		if seen[g.name] || g.name == baseGroup {
+			t.Errorf("group %s defined twice", g.name)
+		}
+		seen[g.name] = true
+		for _, prefix := range g.prefixes {
+			found := false
+			for p := range files {
+				found = found || strings.HasPrefix(p, prefix)
+			}
+			if !found {
+				t.Errorf("group %s: no template starts with %s", g.name, prefix)
+			}
+			// A group must be gated, or every project would need it
+			if !skipPath(prefix, Options{}) {
+				t.Errorf("group %s: %s is generated by default", g.name, prefix)
+			}
+		}
+	}
+
+	if got := neededGroups(Options{}); !reflect.DeepEqual(got, []string{baseGroup}) {
+		t.Errorf("neededGroups(default) = %v, want only the base", got)
+	}
+	got := neededGroups(Options{IncludeDB: true, Billing: true, Search: SearchBleve, DeployProvider: DeploySystemd})
+	if want := []string{baseGroup, "deploy", "search", "billing"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("neededGroups = %v, want %v", got, want)
+	}
+	for path, group := range map[string]string{
+		"internal/billing/billing.go.tmpl":               "billing",
+		"internal/server/billing.go.tmpl":                "billing",
+		"internal/server/server.go":                      baseGroup,
+		"deploy/systemd/app.service":                     "deploy",
+		"internal/database/migrations/00008_billing.sql": "billing",
+		"internal/database/database.go.tmpl":             baseGroup,
+	} {
+		if got := groupOf(path); got != group {
+			t.Errorf("groupOf(%s) = %s, want %s", path, got, group)
+		}
+	}
+}
+
+func TestPackTemplates(t *testing.T) {
+	dir := t.TempDir()
+	if err := PackTemplates(dir); err != nil {
+		t.Fatal(err)
+	}
+	want, err := templateFiles(Templates())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tree := fstest.MapFS{}
+	packed, raw := 0, 0
+	for _, g := range append([]string{baseGroup}, groupNames()...) {
+		archive, err := os.ReadFile(filepath.Join(dir, PackedDir, g+".tar.gz"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		packed += len(archive)
+		group := memFS{}
+		if err := unpackGroup(archive, group); err != nil {
+			t.Fatalf("%s: %v", g, err)
+		}
+		for p, f := range group {
+			if groupOf(p) != g {
+				t.Errorf("%s packed in %s, belongs to %s", p, g, groupOf(p))
+			}
+			tree[p] = &fstest.MapFile{Data: f, Mode: 0644}
+		}
+
+		src, err := os.ReadFile(filepath.Join(dir, "zz_packed_"+g+".go"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		constraint := "//go:build packed_templates\n"
+		if g != baseGroup {
+			constraint = "//go:build packed_templates && !goforge_no_" + g + "\n"
+		}
+		if !strings.Contains(string(src), constraint) || !strings.Contains(string(src), "//go:embed packed/"+g+".tar.gz\n") {
+			t.Errorf("zz_packed_%s.go:\n%s", g, src)
+		}
+	}
+
+	got := map[string]string{}
+	for p, f := range tree {
+		got[p] = string(f.Data)
+		raw += len(f.Data)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("the packed groups hold %d templates, want the %d embedded ones", len(got), len(want))
+	}
+	if packed*3 > raw {
+		t.Errorf("packed %d bytes of templates into %d", raw, packed)
+	}
+
+	// Packing is reproducible
+	again := t.TempDir()
+	if err := PackTemplates(again); err != nil {
+		t.Fatal(err)
+	}
+	a, _ := os.ReadFile(filepath.Join(dir, PackedDir, baseGroup+".tar.gz"))
+	b, _ := os.ReadFile(filepath.Join(again, PackedDir, baseGroup+".tar.gz"))
+	if string(a) != string(b) {
+		t.Error("PackTemplates wrote different archives for the same templates")
+	}
+}
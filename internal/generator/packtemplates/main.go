@@ -0,0 +1,18 @@
+// Command packtemplates writes the template groups of the generator package
+// compressed, for builds with the packed_templates tag. go generate runs it
+// in internal/generator.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/FACorreiaa/goforge/internal/generator"
+)
+
+func main() {
+	if err := generator.PackTemplates("."); err != nil {
+		fmt.Fprintln(os.Stderr, "packtemplates:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,248 @@
+package generator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Template changesets carry unified diffs of template files. The helpers here
+// produce them and apply them forwards (upgrade a template) or in reverse
+// (reconstruct the template an older bundle shipped).
+
+// patchContext is the number of unchanged lines around each hunk
+const patchContext = 3
+
+// noNewline marks a last line without a trailing newline, as diff(1) does
+const noNewline = `\ No newline at end of file`
+
+// diffOp is one line of a line diff: ' ' kept, '-' removed, '+' added
+type diffOp struct {
+	kind byte
+	line string
+}
+
+// diffLines returns the edit script from a to b, using the longest common
+// subsequence of lines. Template files are small, so the quadratic table is
+// fine once the common prefix and suffix are trimmed.
+func diffLines(a, b []string) []diffOp {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	ma, mb := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+
+	// lcs[i][j] is the LCS length of ma[i:] and mb[j:]
+	lcs := make([][]int, len(ma)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(mb)+1)
+	}
+	for i := len(ma) - 1; i >= 0; i-- {
+		for j := len(mb) - 1; j >= 0; j-- {
+			if ma[i] == mb[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	ops := make([]diffOp, 0, len(a)+len(b))
+	for _, l := range a[:prefix] {
+		ops = append(ops, diffOp{' ', l})
+	}
+	i, j := 0, 0
+	for i < len(ma) || j < len(mb) {
+		switch {
+		case i < len(ma) && j < len(mb) && ma[i] == mb[j]:
+			ops = append(ops, diffOp{' ', ma[i]})
+			i++
+			j++
+		case j < len(mb) && (i == len(ma) || lcs[i][j+1] >= lcs[i+1][j]):
+			ops = append(ops, diffOp{'+', mb[j]})
+			j++
+		default:
+			ops = append(ops, diffOp{'-', ma[i]})
+			i++
+		}
+	}
+	for _, l := range a[len(a)-suffix:] {
+		ops = append(ops, diffOp{' ', l})
+	}
+	return ops
+}
+
+// unifiedDiff returns the hunks (without file headers) that turn oldText
+// into newText, or "" when they are equal
+func unifiedDiff(oldText, newText string) string {
+	ops := diffLines(lines(oldText), lines(newText))
+
+	var b strings.Builder
+	for start := 0; start < len(ops); {
+		// Find the next change
+		for start < len(ops) && ops[start].kind == ' ' {
+			start++
+		}
+		if start == len(ops) {
+			break
+		}
+		// Extend the hunk while changes are within 2*context lines
+		end := start
+		for k := start; k < len(ops); k++ {
+			if ops[k].kind != ' ' {
+				end = k + 1
+				continue
+			}
+			if k-end >= 2*patchContext {
+				break
+			}
+		}
+		from := max(start-patchContext, 0)
+		to := min(end+patchContext, len(ops))
+
+		oldLine, newLine := 1, 1
+		for _, op := range ops[:from] {
+			if op.kind != '+' {
+				oldLine++
+			}
+			if op.kind != '-' {
+				newLine++
+			}
+		}
+		oldCount, newCount := 0, 0
+		for _, op := range ops[from:to] {
+			if op.kind != '+' {
+				oldCount++
+			}
+			if op.kind != '-' {
+				newCount++
+			}
+		}
+		fmt.Fprintf(&b, "@@ -%s +%s @@\n", hunkRange(oldLine, oldCount), hunkRange(newLine, newCount))
+		for _, op := range ops[from:to] {
+			b.WriteByte(op.kind)
+			b.WriteString(op.line)
+			if !strings.HasSuffix(op.line, "\n") {
+				b.WriteString("\n" + noNewline + "\n")
+			}
+		}
+		start = to
+	}
+	return b.String()
+}
+
+// hunkRange formats a hunk range; empty ranges point at the line before
+func hunkRange(line, count int) string {
+	if count == 0 {
+		line--
+	}
+	return strconv.Itoa(line) + "," + strconv.Itoa(count)
+}
+
+// lines splits text into lines that keep their "\n"; only the last line may
+// lack it
+func lines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	l := strings.SplitAfter(text, "\n")
+	if l[len(l)-1] == "" {
+		l = l[:len(l)-1]
+	}
+	return l
+}
+
+// hunk is one parsed "@@" section of a unified diff
+type hunk struct {
+	oldStart, newStart int
+	ops                []diffOp
+}
+
+// parsePatch reads the hunks of a unified diff produced by unifiedDiff
+func parsePatch(patch string) ([]hunk, error) {
+	var hunks []hunk
+	for _, l := range lines(patch) {
+		text := strings.TrimSuffix(l, "\n")
+		switch {
+		case strings.HasPrefix(text, "@@ "):
+			var oldStart, oldCount, newStart, newCount int
+			if _, err := fmt.Sscanf(text, "@@ -%d,%d +%d,%d @@", &oldStart, &oldCount, &newStart, &newCount); err != nil {
+				return nil, fmt.Errorf("bad hunk header %q", text)
+			}
+			// Empty ranges point at the line before
+			if oldCount == 0 {
+				oldStart++
+			}
+			if newCount == 0 {
+				newStart++
+			}
+			hunks = append(hunks, hunk{oldStart: oldStart, newStart: newStart})
+		case text == noNewline:
+			if len(hunks) == 0 || len(hunks[len(hunks)-1].ops) == 0 {
+				return nil, fmt.Errorf("%q outside a hunk", noNewline)
+			}
+			ops := hunks[len(hunks)-1].ops
+			ops[len(ops)-1].line = strings.TrimSuffix(ops[len(ops)-1].line, "\n")
+		case len(hunks) > 0 && text != "" && strings.ContainsRune(" -+", rune(text[0])):
+			hunks[len(hunks)-1].ops = append(hunks[len(hunks)-1].ops, diffOp{text[0], l[1:]})
+		default:
+			return nil, fmt.Errorf("unexpected patch line %q", text)
+		}
+	}
+	return hunks, nil
+}
+
+// applyPatch applies a unified diff to content, or undoes it when reverse is
+// set. Every context and removed line must match exactly.
+func applyPatch(content, patch string, reverse bool) (string, error) {
+	hunks, err := parsePatch(patch)
+	if err != nil {
+		return "", err
+	}
+	src := lines(content)
+	var out []string
+	pos := 0 // next unconsumed line of src
+	for _, h := range hunks {
+		start := h.oldStart - 1
+		if reverse {
+			start = h.newStart - 1
+		}
+		var want, repl []string
+		for _, op := range h.ops {
+			kind := op.kind
+			if reverse && kind != ' ' {
+				kind = '+' + '-' - kind
+			}
+			if kind != '+' {
+				want = append(want, op.line)
+			}
+			if kind != '-' {
+				repl = append(repl, op.line)
+			}
+		}
+		if start < pos || start+len(want) > len(src) || !equalLines(src[start:start+len(want)], want) {
+			return "", fmt.Errorf("hunk at line %d does not apply", start+1)
+		}
+		out = append(out, src[pos:start]...)
+		out = append(out, repl...)
+		pos = start + len(want)
+	}
+	out = append(out, src[pos:]...)
+	return strings.Join(out, ""), nil
+}
+
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,53 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiffRoundTrip(t *testing.T) {
+	long := strings.Repeat("line\n", 20)
+	tests := []struct {
+		name, old, new string
+	}{
+		{"add file", "", "a\nb\n"},
+		{"remove file", "a\nb\n", ""},
+		{"modify middle", "a\nb\nc\nd\ne\n", "a\nb\nX\nd\ne\n"},
+		{"insert at start", "a\nb\n", "new\na\nb\n"},
+		{"append", "a\nb\n", "a\nb\nc\n"},
+		{"no trailing newline", "a\nb", "a\nc"},
+		{"add trailing newline", "a\nb", "a\nb\n"},
+		{"two hunks", "first\n" + long + "last\n", "FIRST\n" + long + "LAST\n"},
+		{"repeated lines", "x\nx\nx\n", "x\ny\nx\nx\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch := unifiedDiff(tt.old, tt.new)
+			got, err := applyPatch(tt.old, patch, false)
+			if err != nil || got != tt.new {
+				t.Errorf("applyPatch() = %q, %v; want %q\npatch:\n%s", got, err, tt.new, patch)
+			}
+			got, err = applyPatch(tt.new, patch, true)
+			if err != nil || got != tt.old {
+				t.Errorf("applyPatch(reverse) = %q, %v; want %q\npatch:\n%s", got, err, tt.old, patch)
+			}
+		})
+	}
+
+	if patch := unifiedDiff("same\n", "same\n"); patch != "" {
+		t.Errorf("unifiedDiff() of equal texts = %q, want empty", patch)
+	}
+	if hunks := strings.Count(unifiedDiff("first\n"+long+"last\n", "FIRST\n"+long+"LAST\n"), "@@ -"); hunks != 2 {
+		t.Errorf("distant changes produced %d hunks, want 2", hunks)
+	}
+}
+
+func TestApplyPatchMismatch(t *testing.T) {
+	patch := unifiedDiff("a\nb\nc\n", "a\nB\nc\n")
+	if _, err := applyPatch("a\nedited\nc\n", patch, false); err == nil {
+		t.Error("applyPatch() applied to content that does not match the patch")
+	}
+	if _, err := applyPatch("a\nb\n", "not a patch\n", false); err == nil {
+		t.Error("applyPatch() accepted a malformed patch")
+	}
+}
@@ -0,0 +1,84 @@
+package generator
+
+import (
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// placeholderRe matches a placeholder or conditional tag, such as
+// <!-- BASE_PATH --> or <!-- /IF DB -->, none of which may survive rendering
+var placeholderRe = regexp.MustCompile(`<!-- /?(?:IF (?:NOT )?)?[A-Z][A-Z0-9_]* -->`)
+
+// placeholderAudit tracks the placeholders a run replaces, to catch template
+// drift: a placeholder or tag left in a generated file (a typo, or a
+// placeholder nobody sets) and a replacement no template uses
+type placeholderAudit struct {
+	replacements map[string]string
+	used         map[string]bool
+	leftover     []string // "file: tag"
+}
+
+func newPlaceholderAudit(replacements map[string]string) *placeholderAudit {
+	return &placeholderAudit{replacements: replacements, used: map[string]bool{}}
+}
+
+// record notes the placeholders template uses and any tags left in output,
+// its rendering as file
+func (a *placeholderAudit) record(file, template, output string) {
+	for k := range a.replacements {
+		if strings.Contains(template, k) {
+			a.used[k] = true
+		}
+	}
+	for _, tag := range placeholderRe.FindAllString(output, -1) {
+		a.leftover = append(a.leftover, file+": "+tag)
+	}
+}
+
+// err reports the drift the run found. A replacement this run did not use
+// only counts if no template or fragment uses it, since the files that do
+// may belong to features that are not selected.
+func (a *placeholderAudit) err() error {
+	problems := append([]string(nil), a.leftover...)
+	var unused []string
+	for k := range a.replacements {
+		if !a.used[k] && !bundleContains(k) {
+			unused = append(unused, k+": replacement not used by any template")
+		}
+	}
+	sort.Strings(unused)
+	problems = append(problems, unused...)
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("template placeholders out of sync:\n  %s", strings.Join(problems, "\n  "))
+}
+
+// bundleContains reports whether any embedded template or fragment
+// contains s
+func bundleContains(s string) bool {
+	for _, fsys := range []fs.FS{Templates(), fragmentFS} {
+		found := false
+		fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || found {
+				return err
+			}
+			data, err := fs.ReadFile(fsys, path)
+			if err != nil {
+				return err
+			}
+			if strings.Contains(string(data), s) {
+				found = true
+				return fs.SkipAll
+			}
+			return nil
+		})
+		if found {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,38 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPlaceholderAudit(t *testing.T) {
+	a := newPlaceholderAudit(map[string]string{
+		placeholderBasePath:            "/app",
+		"<!-- NOT_IN_ANY_TEMPLATE -->": "x",
+	})
+	a.record("Makefile", "run: <!-- BASE_PATH -->", "run: /app")
+	a.record("README.md", "<!-- IF DBB -->db<!-- /IF DBB -->", "<!-- IF DBB -->db<!-- /IF DBB -->")
+
+	err := a.err()
+	if err == nil {
+		t.Fatal("err() = nil, want drift")
+	}
+	for _, want := range []string{
+		"README.md: <!-- IF DBB -->",
+		"README.md: <!-- /IF DBB -->",
+		"<!-- NOT_IN_ANY_TEMPLATE -->: replacement not used by any template",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("err() = %v, missing %q", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), "BASE_PATH") {
+		t.Errorf("err() reports a used placeholder: %v", err)
+	}
+
+	// A replacement the run did not use is fine while some template uses it
+	a = newPlaceholderAudit(map[string]string{placeholderTsconfigInclude: ""})
+	if err := a.err(); err != nil {
+		t.Errorf("err() = %v for a placeholder used by a skipped template", err)
+	}
+}
@@ -0,0 +1,152 @@
+package generator
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/FACorreiaa/goforge/internal/manifest"
+)
+
+// backupSuffix is appended to the copy regen keeps of a file it replaces
+const backupSuffix = ".goforge-bak"
+
+// RegenResult describes what `goforge regen` did to a file
+type RegenResult struct {
+	// Path is the regenerated file, relative to the project directory
+	Path string
+	// Backup is where the replaced version was saved; empty if the file was
+	// missing or already matched its template
+	Backup string
+	// Unchanged reports that the file already matched its template
+	Unchanged bool
+}
+
+// Regen renders one project file again from its template, with the options
+// recorded in the manifest and the template as it was in the project's
+// bundle version, to restore a broken or deleted scaffold file. The current
+// version is kept next to it with a .goforge-bak suffix. Regenerating a file
+// that matches its template changes nothing.
+func Regen(projectDir, file string) (*RegenResult, error) {
+	m, err := requireManifest(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(m.Options) == 0 {
+		return nil, fmt.Errorf("%s records no generation options (created by 'manifest init'): templates cannot be rendered", manifest.FileName)
+	}
+	opts, errs := optionsFromManifest(m)
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("%s: %w", manifest.FileName, errs[0])
+	}
+	if opts.Vars, err = readProjectVars(projectDir); err != nil {
+		return nil, err
+	}
+
+	rel := path.Clean(filepath.ToSlash(file))
+	if path.IsAbs(rel) || rel == "." || rel == ".." || strings.HasPrefix(rel, "../") {
+		return nil, fmt.Errorf("%s: give a path inside the project", file)
+	}
+	if rel == manifest.FileName || rel == filepath.ToSlash(ReportPath) {
+		return nil, fmt.Errorf("%s is not rendered from a template", rel)
+	}
+
+	tmplPath, tmpl, err := sourceTemplate(m, rel)
+	if err != nil {
+		return nil, err
+	}
+	if skipPath(tmplPath, opts) {
+		return nil, fmt.Errorf("%s belongs to a feature this project was not generated with", rel)
+	}
+	content, err := renderFile(tmplPath, tmpl, opts, getReplacements(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RegenResult{Path: rel}
+	target := filepath.Join(projectDir, filepath.FromSlash(rel))
+	mode := fs.FileMode(0644)
+	current, err := os.ReadFile(target)
+	switch {
+	case err == nil && string(current) == content:
+		result.Unchanged = true
+		return result, nil
+	case err == nil:
+		backup := target + backupSuffix
+		if _, err := os.Stat(backup); err == nil {
+			return nil, fmt.Errorf("%s already exists: move it away before regenerating %s", rel+backupSuffix, rel)
+		}
+		if info, err := os.Stat(target); err == nil {
+			mode = info.Mode().Perm()
+		}
+		if err := os.WriteFile(backup, current, mode); err != nil {
+			return nil, err
+		}
+		result.Backup = rel + backupSuffix
+	case !os.IsNotExist(err):
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(target, []byte(content), mode); err != nil {
+		return nil, err
+	}
+	return result, recordChanges(projectDir, m, []string{rel})
+}
+
+// sourceTemplate finds the template rel was rendered from (rel itself or
+// rel.tmpl) and returns it as of the project's bundle version, undoing the
+// changesets released since
+func sourceTemplate(m *manifest.Manifest, rel string) (string, string, error) {
+	templates := Templates()
+	tmplPath := ""
+	for _, candidate := range []string{rel + ".tmpl", rel} {
+		if info, err := fs.Stat(templates, candidate); err == nil && !info.IsDir() {
+			tmplPath = candidate
+			break
+		}
+	}
+	if tmplPath == "" {
+		if _, tracked := m.Files[rel]; tracked {
+			return "", "", fmt.Errorf("%s was not generated from a template (it was written from a snippet by a goforge add command or for the selected options)", rel)
+		}
+		return "", "", fmt.Errorf("%s is not a scaffold file", rel)
+	}
+	data, err := fs.ReadFile(templates, tmplPath)
+	if err != nil {
+		return "", "", err
+	}
+
+	channel := m.Template.Channel
+	if channel == "" {
+		channel = ChannelStable
+	}
+	target, err := BundleVersion(channel)
+	if err != nil || m.Template.Version == "" || m.Template.Version == target {
+		return tmplPath, string(data), err
+	}
+	changesets, err := loadChangesets(changesetFS)
+	if err != nil {
+		return "", "", err
+	}
+	chain, err := changesetChain(changesets, channel, m.Template.Version, target)
+	if err != nil {
+		return "", "", err
+	}
+	content, present, err := templatesAt(templates, chain)
+	if err != nil {
+		return "", "", err
+	}
+	if _, touched := present[tmplPath]; !touched {
+		return tmplPath, string(data), nil
+	}
+	if !present[tmplPath] {
+		return "", "", fmt.Errorf("%s did not exist in template bundle %s", rel, m.Template.Version)
+	}
+	return tmplPath, content[tmplPath], nil
+}
@@ -0,0 +1,72 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/FACorreiaa/goforge/internal/manifest"
+)
+
+func TestRegen(t *testing.T) {
+	projectDir := generateProject(t, Options{})
+	makefile := filepath.Join(projectDir, "Makefile")
+	original, err := os.ReadFile(makefile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result, err := Regen(projectDir, "Makefile"); err != nil || !result.Unchanged {
+		t.Fatalf("Regen() of a pristine file = %+v, %v; want unchanged", result, err)
+	}
+
+	if err := os.WriteFile(makefile, []byte("broken\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	result, err := Regen(projectDir, "./Makefile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Path != "Makefile" || result.Backup != "Makefile"+backupSuffix {
+		t.Errorf("Regen() = %+v", result)
+	}
+	if data, _ := os.ReadFile(makefile); string(data) != string(original) {
+		t.Error("Regen() did not restore the Makefile")
+	}
+	if data, _ := os.ReadFile(makefile + backupSuffix); string(data) != "broken\n" {
+		t.Errorf("backup = %q, want the replaced content", data)
+	}
+
+	// A second broken copy must not overwrite the first backup
+	os.WriteFile(makefile, []byte("broken again\n"), 0644)
+	if _, err := Regen(projectDir, "Makefile"); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("Regen() with a backup present error = %v", err)
+	}
+
+	// Deleted files come back and the manifest follows
+	routes := filepath.Join(projectDir, "internal", "server", "routes.go")
+	os.Remove(routes)
+	if result, err := Regen(projectDir, "internal/server/routes.go"); err != nil || result.Backup != "" {
+		t.Fatalf("Regen() of a deleted file = %+v, %v", result, err)
+	}
+	m, err := manifest.Load(projectDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(routes)
+	if m.Files["internal/server/routes.go"] != manifest.Checksum(data) {
+		t.Error("manifest checksum not updated for the regenerated file")
+	}
+
+	for file, want := range map[string]string{
+		"internal/database/database.go": "not generated with",
+		"main.go":                       "not a scaffold file",
+		"../outside":                    "inside the project",
+		manifest.FileName:               "not rendered from a template",
+	} {
+		if _, err := Regen(projectDir, file); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Regen(%q) error = %v, want %q", file, err, want)
+		}
+	}
+}
@@ -0,0 +1,362 @@
+package generator
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultRegistryURL is the curated index of community template bundles,
+// maintained in registry/index.json of the goforge repository
+const DefaultRegistryURL = "https://raw.githubusercontent.com/FACorreiaa/goforge/main/registry/index.json"
+
+// maxBundleSize bounds a downloaded bundle archive
+const maxBundleSize = 32 << 20
+
+// RegistryIndex is the JSON document a registry serves
+type RegistryIndex struct {
+	Bundles []RegistryBundle `json:"bundles"`
+}
+
+// RegistryBundle is a template bundle listed in a registry
+type RegistryBundle struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Repository  string            `json:"repository,omitempty"`
+	Versions    []RegistryRelease `json:"versions"`
+}
+
+// RegistryRelease is one version of a bundle: a .tar.gz archive, its
+// SHA-256 checksum and, for signed bundles, the URL of its minisign signature
+type RegistryRelease struct {
+	Version   string `json:"version"`
+	URL       string `json:"url"`
+	SHA256    string `json:"sha256"`
+	Signature string `json:"signature,omitempty"`
+}
+
+// Registry fetches a registry index and the bundles it lists, over HTTPS
+// only. Trust decides which bundles Add installs (an empty policy when nil);
+// CI refuses unverified bundles unless the policy allows them.
+type Registry struct {
+	URL    string
+	Client *http.Client
+	Trust  *TrustPolicy
+	CI     bool
+}
+
+// NewRegistry returns a client for the index at rawURL (DefaultRegistryURL
+// when empty)
+func NewRegistry(rawURL string) *Registry {
+	if rawURL == "" {
+		rawURL = DefaultRegistryURL
+	}
+	return &Registry{URL: rawURL, Client: &http.Client{Timeout: 30 * time.Second}, CI: InCI()}
+}
+
+// Index downloads and decodes the registry index
+func (r *Registry) Index() (*RegistryIndex, error) {
+	data, err := r.get(r.URL, 8<<20)
+	if err != nil {
+		return nil, fmt.Errorf("registry index: %w", err)
+	}
+	var index RegistryIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("registry index %s: %w", r.URL, err)
+	}
+	return &index, nil
+}
+
+// Search lists the bundles whose name or description contains query, case
+// insensitively, by name; an empty query lists them all
+func (r *Registry) Search(query string) ([]RegistryBundle, error) {
+	index, err := r.Index()
+	if err != nil {
+		return nil, err
+	}
+	query = strings.ToLower(query)
+	var found []RegistryBundle
+	for _, b := range index.Bundles {
+		if strings.Contains(strings.ToLower(b.Name), query) || strings.Contains(strings.ToLower(b.Description), query) {
+			found = append(found, b)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].Name < found[j].Name })
+	return found, nil
+}
+
+// Latest is the bundle's highest version
+func (b RegistryBundle) Latest() (RegistryRelease, bool) {
+	if len(b.Versions) == 0 {
+		return RegistryRelease{}, false
+	}
+	latest := b.Versions[0]
+	for _, v := range b.Versions[1:] {
+		if compareVersions(v.Version, latest.Version) > 0 {
+			latest = v
+		}
+	}
+	return latest, true
+}
+
+// InstalledBundle is a bundle `goforge templates add` put on disk
+type InstalledBundle struct {
+	Name, Version, Path string
+	Verification        *Verification
+}
+
+// Add installs a bundle from the registry into root/<name>@<version>. spec is
+// a name, installing the latest version, or name@version to pin one. The
+// archive must match the checksum in the index, pass the trust policy and
+// hold a valid bundle; nothing is installed otherwise.
+func (r *Registry) Add(spec, root string) (*InstalledBundle, error) {
+	name, version, _ := strings.Cut(spec, "@")
+	index, err := r.Index()
+	if err != nil {
+		return nil, err
+	}
+	var bundle *RegistryBundle
+	for i := range index.Bundles {
+		if index.Bundles[i].Name == name {
+			bundle = &index.Bundles[i]
+		}
+	}
+	if bundle == nil {
+		return nil, fmt.Errorf("no bundle %q in %s (try goforge templates search)", name, r.URL)
+	}
+	release, ok := bundle.Latest()
+	if version != "" {
+		ok = false
+		for _, v := range bundle.Versions {
+			if v.Version == version {
+				release, ok = v, true
+			}
+		}
+	}
+	if !ok {
+		return nil, fmt.Errorf("bundle %s has no version %q", name, version)
+	}
+	if !isSafeName(name) || !isSafeName(release.Version) {
+		return nil, fmt.Errorf("bundle %s@%s: unusable name or version", name, release.Version)
+	}
+
+	dest := filepath.Join(root, name+"@"+release.Version)
+	if _, err := os.Stat(dest); err == nil {
+		return nil, fmt.Errorf("%s@%s is already installed in %s", name, release.Version, dest)
+	}
+	archive, err := r.get(release.URL, maxBundleSize)
+	if err != nil {
+		return nil, fmt.Errorf("bundle %s@%s: %w", name, release.Version, err)
+	}
+	sum := sha256.Sum256(archive)
+	if got := hex.EncodeToString(sum[:]); !strings.EqualFold(got, release.SHA256) {
+		return nil, fmt.Errorf("bundle %s@%s: checksum mismatch (index %s, download %s): not installed", name, release.Version, release.SHA256, got)
+	}
+	signature := ""
+	if release.Signature != "" {
+		data, err := r.get(release.Signature, 64<<10)
+		if err != nil {
+			return nil, fmt.Errorf("bundle %s@%s signature: %w", name, release.Version, err)
+		}
+		signature = string(data)
+	}
+	trust := r.Trust
+	if trust == nil {
+		trust = &TrustPolicy{}
+	}
+	verification, err := trust.Verify(archive, signature, r.CI)
+	if err != nil {
+		return nil, fmt.Errorf("bundle %s@%s: %w: not installed", name, release.Version, err)
+	}
+
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return nil, err
+	}
+	tmp, err := os.MkdirTemp(root, ".download-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+	if err := extractTarGz(archive, tmp); err != nil {
+		return nil, fmt.Errorf("bundle %s@%s: %w", name, release.Version, err)
+	}
+	if _, err := LoadBundle(tmp); err != nil {
+		return nil, fmt.Errorf("bundle %s@%s is not a valid template bundle: %w", name, release.Version, err)
+	}
+	if err := os.Rename(tmp, dest); err != nil {
+		return nil, err
+	}
+	return &InstalledBundle{Name: name, Version: release.Version, Path: dest, Verification: verification}, nil
+}
+
+// TemplatesDir is where `goforge templates add` installs bundles
+func TemplatesDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "goforge", "templates"), nil
+}
+
+// get downloads an https URL, refusing anything else and bodies over limit
+func (r *Registry) get(rawURL string, limit int64) ([]byte, error) {
+	return httpsGet(r.Client, rawURL, limit)
+}
+
+// httpsGet downloads an https URL with client, refusing anything else and
+// bodies over limit
+func httpsGet(client *http.Client, rawURL string, limit int64) ([]byte, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "https" {
+		return nil, fmt.Errorf("%s: only https URLs are allowed", rawURL)
+	}
+	resp, err := client.Get(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", rawURL, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("%s: larger than %d bytes", rawURL, limit)
+	}
+	return data, nil
+}
+
+// extractTarGz unpacks regular files and directories into dir. A single
+// top-level directory, as GitHub release archives have, is stripped; paths
+// escaping dir are rejected.
+func extractTarGz(archive []byte, dir string) error {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return err
+	}
+	type entry struct {
+		name string
+		dir  bool
+		data []byte
+	}
+	var entries []entry
+	var total int64
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		name := path.Clean(strings.TrimPrefix(hdr.Name, "./"))
+		if name == "." {
+			continue
+		}
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return fmt.Errorf("archive entry %q leaves the bundle directory", hdr.Name)
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			entries = append(entries, entry{name: name, dir: true})
+		case tar.TypeReg:
+			data, err := io.ReadAll(io.LimitReader(tr, maxBundleSize-total+1))
+			if err != nil {
+				return err
+			}
+			if total += int64(len(data)); total > maxBundleSize {
+				return fmt.Errorf("archive unpacks to more than %d bytes", maxBundleSize)
+			}
+			entries = append(entries, entry{name: name, data: data})
+		default:
+			return fmt.Errorf("archive entry %q: only files and directories are allowed", hdr.Name)
+		}
+	}
+
+	top := ""
+	if len(entries) > 0 {
+		top, _, _ = strings.Cut(entries[0].name, "/")
+		for _, e := range entries {
+			if !(e.name == top && e.dir) && !strings.HasPrefix(e.name, top+"/") {
+				top = ""
+				break
+			}
+		}
+	}
+	for _, e := range entries {
+		rel := e.name
+		if top != "" {
+			if rel == top {
+				continue
+			}
+			rel = strings.TrimPrefix(rel, top+"/")
+		}
+		target := filepath.Join(dir, filepath.FromSlash(rel))
+		if e.dir {
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(target, e.data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isSafeName reports whether s can be used in a directory name
+func isSafeName(s string) bool {
+	return s != "" && !strings.ContainsAny(s, `/\`) && s != "." && s != ".."
+}
+
+// compareVersions orders dotted numeric versions, with an optional v prefix
+// (1.10.0 > 1.9.2); non-numeric parts compare as strings
+func compareVersions(a, b string) int {
+	pa := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	pb := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var x, y string
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		nx, errX := strconv.Atoi(x)
+		ny, errY := strconv.Atoi(y)
+		switch {
+		case errX == nil && errY == nil && nx != ny:
+			if nx < ny {
+				return -1
+			}
+			return 1
+		case (errX != nil || errY != nil) && x != y:
+			return strings.Compare(x, y)
+		}
+	}
+	return 0
+}
@@ -0,0 +1,166 @@
+package generator
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// bundleArchive packs the bundle in dir as a release archive under prefix
+func bundleArchive(t *testing.T, dir, prefix string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		hdr := &tar.Header{Name: prefix + filepath.ToSlash(rel), Mode: 0644, Size: int64(len(data)), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		_, err = tw.Write(data)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tw.Close()
+	gz.Close()
+	return buf.Bytes()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestRegistry(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "api-stack")
+	if _, err := NewBundle(src, "api-stack", "JSON API stack"); err != nil {
+		t.Fatal(err)
+	}
+	v1 := bundleArchive(t, src, "api-stack-1.0.0/")
+	v2 := bundleArchive(t, src, "")
+
+	mux := http.NewServeMux()
+	srv := httptest.NewTLSServer(mux)
+	defer srv.Close()
+	index := RegistryIndex{Bundles: []RegistryBundle{
+		{Name: "api-stack", Description: "JSON API with a worker", Versions: []RegistryRelease{
+			{Version: "1.0.0", URL: srv.URL + "/v1.tar.gz", SHA256: sha256Hex(v1)},
+			{Version: "1.10.0", URL: srv.URL + "/v2.tar.gz", SHA256: sha256Hex(v2)},
+			{Version: "1.9.0", URL: srv.URL + "/bad.tar.gz", SHA256: sha256Hex(v1)},
+		}},
+		{Name: "blog", Description: "Markdown blog"},
+	}}
+	mux.HandleFunc("/index.json", func(w http.ResponseWriter, r *http.Request) { json.NewEncoder(w).Encode(index) })
+	mux.HandleFunc("/v1.tar.gz", func(w http.ResponseWriter, r *http.Request) { w.Write(v1) })
+	mux.HandleFunc("/v2.tar.gz", func(w http.ResponseWriter, r *http.Request) { w.Write(v2) })
+	mux.HandleFunc("/bad.tar.gz", func(w http.ResponseWriter, r *http.Request) { w.Write(v2) })
+	registry := &Registry{URL: srv.URL + "/index.json", Client: srv.Client()}
+
+	found, err := registry.Search("API")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 1 || found[0].Name != "api-stack" {
+		t.Fatalf("Search(API) = %+v", found)
+	}
+	if latest, _ := found[0].Latest(); latest.Version != "1.10.0" {
+		t.Errorf("Latest() = %s, want 1.10.0", latest.Version)
+	}
+
+	root := t.TempDir()
+	for spec, want := range map[string]string{"api-stack": "1.10.0", "api-stack@1.0.0": "1.0.0"} {
+		installed, err := registry.Add(spec, root)
+		if err != nil {
+			t.Fatalf("Add(%s): %v", spec, err)
+		}
+		if installed.Version != want {
+			t.Errorf("Add(%s) installed %s, want %s", spec, installed.Version, want)
+		}
+		if _, err := LoadBundle(installed.Path); err != nil {
+			t.Errorf("Add(%s) did not install a bundle: %v", spec, err)
+		}
+	}
+
+	if _, err := registry.Add("api-stack@1.9.0", root); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("Add() with a wrong checksum: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "api-stack@1.9.0")); !os.IsNotExist(err) {
+		t.Error("a bundle failing its checksum was installed")
+	}
+	for _, spec := range []string{"api-stack", "api-stack@2.0.0", "missing"} {
+		if _, err := registry.Add(spec, root); err == nil {
+			t.Errorf("Add(%s) succeeded", spec)
+		}
+	}
+
+	plain := &Registry{URL: "http://" + strings.TrimPrefix(srv.URL, "https://") + "/index.json", Client: srv.Client()}
+	if _, err := plain.Index(); err == nil || !strings.Contains(err.Error(), "only https") {
+		t.Errorf("Index() over http: %v", err)
+	}
+}
+
+func TestExtractTarGzRejectsEscapes(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	tw.WriteHeader(&tar.Header{Name: "../evil", Mode: 0644, Size: 1, Typeflag: tar.TypeReg})
+	tw.Write([]byte("x"))
+	tw.Close()
+	gz.Close()
+	if err := extractTarGz(buf.Bytes(), t.TempDir()); err == nil {
+		t.Error("extractTarGz() accepted a path outside the directory")
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	for _, tc := range []struct {
+		a, b string
+		want int
+	}{
+		{"1.10.0", "1.9.2", 1},
+		{"v1.2.0", "1.2.0", 0},
+		{"1.2", "1.2.1", -1},
+		{"1.0.0-beta", "1.0.0-alpha", 1},
+	} {
+		if got := compareVersions(tc.a, tc.b); got != tc.want {
+			t.Errorf("compareVersions(%s, %s) = %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestRegistryIndexFile(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "..", "registry", "index.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var index RegistryIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		t.Fatalf("registry/index.json: %v", err)
+	}
+	for _, b := range index.Bundles {
+		for _, v := range b.Versions {
+			if !strings.HasPrefix(v.URL, "https://") || len(v.SHA256) != 64 {
+				t.Errorf("%s@%s: needs an https URL and a SHA-256 checksum", b.Name, v.Version)
+			}
+		}
+	}
+}
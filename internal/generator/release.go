@@ -0,0 +1,240 @@
+package generator
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// goforgeModule is the module goforge releases are published as
+const goforgeModule = "github.com/FACorreiaa/goforge"
+
+// defaultModuleProxy serves goforge releases when GOPROXY names no proxy
+const defaultModuleProxy = "https://proxy.golang.org"
+
+// maxReleaseSize bounds a downloaded goforge release
+const maxReleaseSize = 64 << 20
+
+// templateSourceRelease is the manifest template source for templates taken
+// from a goforge release, followed by the release tag
+const templateSourceRelease = "release"
+
+var (
+	// bundleVersionsRe is the bundleVersions map in a release's channel.go
+	bundleVersionsRe = regexp.MustCompile(`(?s)bundleVersions = map\[string\]string\{(.*?)\n\}`)
+	// bundleVersionRe is one channel's entry in it
+	bundleVersionRe = regexp.MustCompile(`Channel(\w+):\s*"([^"]+)"`)
+)
+
+// Releases fetches the templates of published goforge releases from a Go
+// module proxy, over HTTPS only
+type Releases struct {
+	Proxy  string
+	Client *http.Client
+}
+
+// NewReleases returns a client for the first proxy GOPROXY lists, or
+// proxy.golang.org
+func NewReleases() *Releases {
+	proxy := defaultModuleProxy
+	for _, p := range strings.FieldsFunc(os.Getenv("GOPROXY"), func(r rune) bool { return r == ',' || r == '|' }) {
+		if strings.HasPrefix(p, "https://") {
+			proxy = strings.TrimRight(p, "/")
+			break
+		}
+	}
+	return &Releases{Proxy: proxy, Client: &http.Client{Timeout: 2 * time.Minute}}
+}
+
+// goforgeReleases is where `goforge new --at` looks for bundles the embedded
+// changesets cannot rebuild
+var goforgeReleases = NewReleases()
+
+// Templates finds the goforge release that shipped version of a channel's
+// template bundle and returns its templates, rooted at the template tree,
+// with the release tag. Bundle versions only grow from one release to the
+// next, so the releases are binary searched.
+func (r *Releases) Templates(channel, version string) (fs.FS, string, error) {
+	list, err := r.get(r.moduleURL("@v/list"), 1<<20)
+	if err != nil {
+		return nil, "", fmt.Errorf("goforge releases: %w", err)
+	}
+	var tags []string
+	for _, tag := range strings.Fields(string(list)) {
+		if strings.HasPrefix(tag, "v") && !strings.Contains(tag, "-") {
+			tags = append(tags, tag)
+		}
+	}
+	sort.Slice(tags, func(i, j int) bool { return compareVersions(tags[i], tags[j]) < 0 })
+
+	zips := map[string]*zip.Reader{}
+	var searchErr error
+	i := sort.Search(len(tags), func(i int) bool {
+		if searchErr != nil {
+			return true
+		}
+		zr, err := r.release(tags[i])
+		if err != nil {
+			searchErr = err
+			return true
+		}
+		zips[tags[i]] = zr
+		return compareVersions(releaseBundleVersion(zr, tags[i], channel), version) >= 0
+	})
+	if searchErr != nil {
+		return nil, "", searchErr
+	}
+	if i == len(tags) || releaseBundleVersion(zips[tags[i]], tags[i], channel) != version {
+		return nil, "", fmt.Errorf("no goforge release ships the %s template bundle %s", channel, version)
+	}
+
+	tag := tags[i]
+	prefix := goforgeModule + "@" + tag + "/internal/generator/templates/"
+	tree := memFS{}
+	for _, f := range zips[tag].File {
+		rel, ok := strings.CutPrefix(f.Name, prefix)
+		if !ok || rel == "" || strings.HasSuffix(rel, "/") {
+			continue
+		}
+		data, err := readZipFile(f)
+		if err != nil {
+			return nil, "", fmt.Errorf("goforge %s: %s: %w", tag, f.Name, err)
+		}
+		tree[rel] = data
+	}
+	if len(tree) == 0 {
+		return nil, "", fmt.Errorf("goforge %s has no templates", tag)
+	}
+	return tree, tag, nil
+}
+
+// release downloads the module zip of a goforge release
+func (r *Releases) release(tag string) (*zip.Reader, error) {
+	data, err := r.get(r.moduleURL("@v/"+tag+".zip"), maxReleaseSize)
+	if err != nil {
+		return nil, fmt.Errorf("goforge %s: %w", tag, err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("goforge %s: %w", tag, err)
+	}
+	return zr, nil
+}
+
+// moduleURL is where the proxy serves rel for the goforge module; the proxy
+// protocol writes upper-case letters as ! and the lower-case letter
+func (r *Releases) moduleURL(rel string) string {
+	var escaped strings.Builder
+	for _, c := range goforgeModule {
+		if unicode.IsUpper(c) {
+			escaped.WriteByte('!')
+			c = unicode.ToLower(c)
+		}
+		escaped.WriteRune(c)
+	}
+	return r.Proxy + "/" + escaped.String() + "/" + rel
+}
+
+// get downloads an https URL, refusing anything else and bodies over limit
+func (r *Releases) get(rawURL string, limit int64) ([]byte, error) {
+	return httpsGet(r.Client, rawURL, limit)
+}
+
+// releaseBundleVersion reads the bundle version a release ships for a
+// channel from its channel.go; "" for releases older than the channel or
+// than versioned templates
+func releaseBundleVersion(zr *zip.Reader, tag, channel string) string {
+	name := goforgeModule + "@" + tag + "/internal/generator/channel.go"
+	for _, f := range zr.File {
+		if f.Name != name {
+			continue
+		}
+		data, err := readZipFile(f)
+		if err != nil {
+			return ""
+		}
+		block := bundleVersionsRe.FindSubmatch(data)
+		if block == nil {
+			return ""
+		}
+		for _, m := range bundleVersionRe.FindAllSubmatch(block[1], -1) {
+			if strings.EqualFold(string(m[1]), channel) {
+				return string(m[2])
+			}
+		}
+	}
+	return ""
+}
+
+func readZipFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(io.LimitReader(rc, maxReleaseSize))
+}
+
+// templatesFor returns the templates to generate opts with: the embedded
+// ones, or for an older opts.At the embedded ones with the changesets since
+// undone, or those of the goforge release that shipped it. It also returns
+// the bundle version and the manifest template source.
+func templatesFor(opts Options, changesets []Changeset, releases *Releases) (fs.FS, string, string, error) {
+	current, err := BundleVersion(opts.Channel)
+	if err != nil {
+		return nil, "", "", err
+	}
+	if opts.At == "" || opts.At == current {
+		tree, err := templatesOf(opts)
+		return tree, current, templateSourceEmbedded, err
+	}
+	if compareVersions(opts.At, current) > 0 {
+		return nil, "", "", fmt.Errorf("template bundle %s is newer than the %s bundle of this goforge (%s): update goforge", opts.At, opts.Channel, current)
+	}
+
+	if chain, err := changesetChain(changesets, opts.Channel, opts.At, current); err == nil {
+		tree, err := templatesBefore(Templates(), chain)
+		if err != nil {
+			return nil, "", "", err
+		}
+		return tree, opts.At, templateSourceEmbedded, nil
+	}
+	tree, tag, err := releases.Templates(opts.Channel, opts.At)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("template bundle %s: the embedded changesets do not reach it, and %w", opts.At, err)
+	}
+	return tree, opts.At, templateSourceRelease + " " + tag, nil
+}
+
+// templatesBefore is the whole template tree as it was before a chain of
+// changesets
+func templatesBefore(current fs.FS, chain []Changeset) (fs.FS, error) {
+	files, err := templateFiles(current)
+	if err != nil {
+		return nil, err
+	}
+	content, present, err := templatesAt(current, chain)
+	if err != nil {
+		return nil, err
+	}
+	tree := memFS{}
+	for p, data := range files {
+		tree[p] = []byte(data)
+	}
+	for p, data := range content {
+		delete(tree, p)
+		if present[p] {
+			tree[p] = []byte(data)
+		}
+	}
+	return tree, nil
+}
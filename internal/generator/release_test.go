@@ -0,0 +1,174 @@
+package generator
+
+import (
+	"archive/zip"
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/FACorreiaa/goforge/internal/manifest"
+)
+
+// generateAt generates a project with the templates --at resolves to
+func generateAt(t *testing.T, at string, changesets []Changeset, releases *Releases) (string, error) {
+	t.Helper()
+	opts, err := prepareOptions(Options{
+		ProjectName:    filepath.Join(t.TempDir(), "app"),
+		ModulePath:     "github.com/test/app",
+		Frontend:       FrontendHTMX,
+		CSSFramework:   CSSFrameworkDaisyUI,
+		DeployProvider: DeployNone,
+		IncludeDB:      true,
+		At:             at,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	templates, version, source, err := templatesFor(opts, changesets, releases)
+	if err != nil {
+		return "", err
+	}
+	if err := generate(opts, templates, version, source); err != nil {
+		t.Fatalf("generate() error: %v", err)
+	}
+	return opts.ProjectName, nil
+}
+
+func TestGenerateAtChangesets(t *testing.T) {
+	current := embeddedTemplates(t)
+	old := templateRelease(t, current, map[string]*string{
+		"Makefile":       str(strings.Replace(string(current["Makefile"].Data), "env-check:", "old-env-check:", 1)),
+		"docs/LEGACY.md": str("# Legacy <!-- PROJECT_NAME -->\n"),
+	})
+	cs, err := NewChangeset(old, current, ChannelStable, "0.9.0", bundleVersions[ChannelStable])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	projectDir, err := generateAt(t, "0.9.0", []Changeset{*cs}, &Releases{})
+	if err != nil {
+		t.Fatalf("templatesFor() error: %v", err)
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, "Makefile"), "old-env-check:") {
+		t.Error("Makefile not generated from bundle 0.9.0")
+	}
+	if got := readProjectFile(t, projectDir, "docs/LEGACY.md"); got != "# Legacy app\n" {
+		t.Errorf("docs/LEGACY.md = %q", got)
+	}
+	m, err := manifest.Load(projectDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Template.Version != "0.9.0" || m.Template.Source != templateSourceEmbedded {
+		t.Errorf("manifest template = %+v, want embedded 0.9.0", m.Template)
+	}
+
+	// Upgrading it then reaches the current bundle
+	report, err := upgrade(projectDir, Templates(), []Changeset{*cs}, bundleVersions, false)
+	if err != nil {
+		t.Fatalf("upgrade() error: %v", err)
+	}
+	if len(report.Steps) != 1 || !strings.Contains(readProjectFile(t, projectDir, "Makefile"), "\nenv-check:") {
+		t.Errorf("upgrade from 0.9.0: %+v", report)
+	}
+	assertFilesMissing(t, projectDir, "docs/LEGACY.md")
+}
+
+// releaseZip packs templates and a channel.go shipping bundles as the module
+// zip of goforge tag
+func releaseZip(t *testing.T, tag string, templates fstest.MapFS, bundles map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	write := func(name, content string) {
+		w, err := zw.Create(goforgeModule + "@" + tag + "/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(content))
+	}
+	channelGo := "package generator\n"
+	if bundles != nil {
+		channelGo += "\nvar bundleVersions = map[string]string{\n"
+		for channel, version := range bundles {
+			channelGo += "\tChannel" + strings.ToUpper(channel[:1]) + channel[1:] + ": \"" + version + "\",\n"
+		}
+		channelGo += "}\n"
+	}
+	write("internal/generator/channel.go", channelGo)
+	paths := make([]string, 0, len(templates))
+	for p := range templates {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		write("internal/generator/templates/"+p, string(templates[p].Data))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestGenerateAtRelease(t *testing.T) {
+	current := embeddedTemplates(t)
+	old := templateRelease(t, current, map[string]*string{
+		"README.md": str(string(current["README.md"].Data) + "\nFrom goforge v0.2.0.\n"),
+	})
+	zips := map[string][]byte{
+		"v0.1.0":  releaseZip(t, "v0.1.0", current, nil),
+		"v0.2.0":  releaseZip(t, "v0.2.0", old, map[string]string{ChannelStable: "0.8.0"}),
+		"v0.3.0":  releaseZip(t, "v0.3.0", current, map[string]string{ChannelStable: "0.9.0", ChannelEdge: "0.9.0-edge.1"}),
+		"v0.10.0": releaseZip(t, "v0.10.0", current, map[string]string{ChannelStable: bundleVersions[ChannelStable]}),
+	}
+	var fetched []string
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		base := "/github.com/!f!a!correiaa/goforge/@v/"
+		name, ok := strings.CutPrefix(r.URL.Path, base)
+		switch {
+		case !ok:
+			http.NotFound(w, r)
+		case name == "list":
+			w.Write([]byte("v0.3.0\nv0.1.0\nv0.10.0\nv0.2.0\nv0.4.0-rc.1\n"))
+		case zips[strings.TrimSuffix(name, ".zip")] != nil:
+			fetched = append(fetched, name)
+			w.Write(zips[strings.TrimSuffix(name, ".zip")])
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	releases := &Releases{Proxy: srv.URL, Client: srv.Client()}
+
+	projectDir, err := generateAt(t, "0.8.0", nil, releases)
+	if err != nil {
+		t.Fatalf("templatesFor() error: %v", err)
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, "README.md"), "From goforge v0.2.0.") {
+		t.Error("README.md not generated from the v0.2.0 templates")
+	}
+	m, err := manifest.Load(projectDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Template.Version != "0.8.0" || m.Template.Source != "release v0.2.0" {
+		t.Errorf("manifest template = %+v, want release v0.2.0 at 0.8.0", m.Template)
+	}
+	if len(fetched) > 3 {
+		t.Errorf("fetched %v: the releases should be binary searched", fetched)
+	}
+
+	for at, want := range map[string]string{
+		"0.8.5": "no goforge release ships the stable template bundle 0.8.5",
+		"9.0.0": "newer than the stable bundle of this goforge",
+	} {
+		if _, err := generateAt(t, at, nil, releases); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("--at %s: error = %v, want %q", at, err, want)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package generator
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/FACorreiaa/goforge/internal/manifest"
+)
+
+// renameSkipDirs are never rewritten: VCS data, dependencies and build output
+var renameSkipDirs = map[string]bool{
+	".git": true, "node_modules": true, "vendor": true, "dist": true, "tmp": true, "bin": true,
+}
+
+// replaceModulePath replaces oldModule and its package paths with newModule.
+// Longer paths that merely share the prefix (github.com/x/app-tools) are left
+// alone.
+func replaceModulePath(content, oldModule, newModule string) string {
+	var b strings.Builder
+	for {
+		idx := strings.Index(content, oldModule)
+		if idx < 0 {
+			b.WriteString(content)
+			return b.String()
+		}
+		end := idx + len(oldModule)
+		before, after := byte(' '), byte(' ')
+		if idx > 0 {
+			before = content[idx-1]
+		}
+		if end < len(content) {
+			after = content[end]
+		}
+		// A slash may precede (https://github.com/...) or follow (package paths)
+		if (before != '/' && isPathChar(before)) || (after != '/' && isPathChar(after)) {
+			b.WriteString(content[:end])
+		} else {
+			b.WriteString(content[:idx] + newModule)
+		}
+		content = content[end:]
+	}
+}
+
+func isPathChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == '.' || c == '-' || c == '_' || c == '~' || c == '/'
+}
+
+// validModulePath rejects module paths go would not accept as a module
+func validModulePath(path string) error {
+	if path == "" || strings.HasPrefix(path, "/") || strings.HasSuffix(path, "/") ||
+		strings.Contains(path, "//") || strings.Contains(path, "..") {
+		return fmt.Errorf("invalid module path %q", path)
+	}
+	for i := 0; i < len(path); i++ {
+		if !isPathChar(path[i]) {
+			return fmt.Errorf("invalid module path %q: unexpected %q", path, path[i])
+		}
+	}
+	return nil
+}
+
+// RenameModule rewrites the module path of a generated project in go.mod,
+// imports, Templ files and config, with the same replacement generation
+// uses. It returns the rewritten files, relative to projectDir.
+func RenameModule(projectDir, newModule string) ([]string, error) {
+	if err := validModulePath(newModule); err != nil {
+		return nil, err
+	}
+	oldModule, err := projectModule(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	if oldModule == newModule {
+		return nil, fmt.Errorf("module is already %s", newModule)
+	}
+	mf, err := requireManifest(projectDir)
+	if err != nil {
+		return nil, err
+	}
+
+	// Files still as generated stay so under the new module path; edited
+	// ones keep their checksums
+	var changed, generated []string
+	err = filepath.WalkDir(projectDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != projectDir && renameSkipDirs[d.Name()] {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || isBinaryFile(path) || path == manifest.Path(projectDir) {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		content := string(data)
+		if !strings.Contains(content, oldModule) {
+			return nil
+		}
+		updated := replaceModulePath(content, oldModule, newModule)
+		if updated == content {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, []byte(updated), info.Mode().Perm()); err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(projectDir, path)
+		changed = append(changed, rel)
+		if mf.Files[filepath.ToSlash(rel)] == manifest.Checksum(data) {
+			generated = append(generated, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	mf.Project.Module = newModule
+	if err := recordChanges(projectDir, mf, generated); err != nil {
+		return nil, err
+	}
+	return changed, nil
+}
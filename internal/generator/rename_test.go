@@ -0,0 +1,67 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReplaceModulePath(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"module line", "module github.com/x/app\n", "module github.com/y/web\n"},
+		{"package import", `"github.com/x/app/internal/server"`, `"github.com/y/web/internal/server"`},
+		{"repository URL", "https://github.com/x/app", "https://github.com/y/web"},
+		{"longer module", `"github.com/x/app-tools/cli"`, `"github.com/x/app-tools/cli"`},
+		{"suffix of another path", "example.org/github.com/x/apps", "example.org/github.com/x/apps"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := replaceModulePath(tt.in, "github.com/x/app", "github.com/y/web"); got != tt.want {
+				t.Errorf("replaceModulePath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenameModule(t *testing.T) {
+	projectDir := generateProject(t, Options{IncludeDB: true, ModulePath: "github.com/test/app"})
+	// A dependency sharing the prefix must survive the rename
+	mainPath := filepath.Join(projectDir, "cmd", "server", "main.go")
+	data, _ := os.ReadFile(mainPath)
+	if err := os.WriteFile(mainPath, append(data, []byte("\n// see github.com/test/app-tools\n")...), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	changed, err := RenameModule(projectDir, "github.com/acme/shop")
+	if err != nil {
+		t.Fatalf("RenameModule() error: %v", err)
+	}
+	if len(changed) == 0 {
+		t.Fatal("RenameModule() changed no files")
+	}
+
+	if module, _ := projectModule(projectDir); module != "github.com/acme/shop" {
+		t.Errorf("go.mod module = %q, want github.com/acme/shop", module)
+	}
+	main := readProjectFile(t, projectDir, "cmd/server/main.go")
+	if !strings.Contains(main, `"github.com/acme/shop/internal/server"`) {
+		t.Error("main.go imports not rewritten")
+	}
+	if !strings.Contains(main, "github.com/test/app-tools") {
+		t.Error("a longer module path sharing the prefix was rewritten")
+	}
+	if strings.Contains(readProjectFile(t, projectDir, "views/pages/index.templ"), "github.com/test/app/") {
+		t.Error("templ imports not rewritten")
+	}
+
+	if _, err := RenameModule(projectDir, "github.com/acme/shop"); err == nil {
+		t.Error("RenameModule() to the current module succeeded")
+	}
+	if _, err := RenameModule(projectDir, "not a module"); err == nil {
+		t.Error("RenameModule() accepted an invalid module path")
+	}
+}
@@ -0,0 +1,381 @@
+package generator
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/FACorreiaa/goforge/internal/manifest"
+)
+
+// ReportPath is where `goforge new` writes the generation report
+var ReportPath = filepath.Join(".goforge", "report.md")
+
+// Report summarizes a generated project for review: what was chosen and what
+// the scaffold contains
+type Report struct {
+	Manifest  *manifest.Manifest
+	Routes    []Route
+	EnvVars   []EnvVar
+	Services  []Service
+	Sizes     []DirSize
+	NextSteps []string
+}
+
+// Route is an HTTP route registered in internal/server/routes.go
+type Route struct {
+	Method, Path, Handler string
+}
+
+// EnvVar is a variable from .env.example. Optional variables are the ones
+// shipped commented out.
+type EnvVar struct {
+	Name, Default, Section string
+	Optional               bool
+}
+
+// Service is a docker-compose service
+type Service struct {
+	Name, Description, Image string
+}
+
+// DirSize is what the scaffold wrote under a top-level directory; "." holds
+// the files at the project root
+type DirSize struct {
+	Dir   string
+	Files int
+	Bytes int64
+}
+
+// BuildReport reads a generated project and its manifest into a Report
+func BuildReport(projectDir string) (*Report, error) {
+	m, err := manifest.Load(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	opts, _ := optionsFromManifest(m)
+
+	r := &Report{Manifest: m, NextSteps: nextSteps(opts)}
+	if r.Routes, err = readRoutes(filepath.Join(projectDir, "internal", "server", "routes.go")); err != nil {
+		return nil, err
+	}
+	if r.EnvVars, err = readEnvExample(filepath.Join(projectDir, ".env.example")); err != nil {
+		return nil, err
+	}
+	if r.Services, err = readComposeServices(filepath.Join(projectDir, "docker-compose.yml")); err != nil {
+		return nil, err
+	}
+	if r.Sizes, err = readSizes(projectDir, m.Files); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// nextSteps are the commands to run after generation, with a short comment
+func nextSteps(opts Options) []string {
+	steps := []string{
+		"make setup    # Install tools (Air, Templ, Goose, Tailwind)",
+		"make dev      # Start development server with live reload",
+		"make dev-templ # Start with Templ proxy (auto browser refresh)",
+	}
+	if opts.Vite {
+		steps = append(steps, "make vite-dev  # Start the Vite dev server (TypeScript islands)")
+	}
+	if opts.Preview {
+		steps = append(steps, "make preview   # Browse components in the preview gallery")
+	}
+	return steps
+}
+
+var (
+	routeRe      = regexp.MustCompile(`\br\.(Get|Post|Put|Patch|Delete|Handle|HandleFunc|Mount)\("([^"]+)",\s*(.+)\)$`)
+	routeGroupRe = regexp.MustCompile(`\br\.Route\("([^"]+)"`)
+	registryRe   = regexp.MustCompile(`\{Name: "[^"]+", Method: (?:http\.Method(\w+)|router\.AnyMethod), Path: "([^"]+)", Handler: (.+)\},?$`)
+)
+
+// readRoutes lists the routes registered in routes.go: route registry
+// entries and chi calls, resolving r.Route groups. Missing files yield no
+// routes.
+func readRoutes(path string) ([]Route, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	type group struct {
+		prefix string
+		indent string
+	}
+	var groups []group
+	var routes []Route
+	seen := map[string]bool{}
+	for _, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimSpace(line)
+		indent := line[:len(line)-len(strings.TrimLeft(line, "\t "))]
+		if len(groups) > 0 && trimmed == "})" && indent == groups[len(groups)-1].indent {
+			groups = groups[:len(groups)-1]
+			continue
+		}
+		if strings.HasPrefix(trimmed, "//") {
+			continue
+		}
+		prefix := ""
+		for _, g := range groups {
+			prefix += g.prefix
+		}
+		if m := routeGroupRe.FindStringSubmatch(trimmed); m != nil {
+			groups = append(groups, group{prefix: m[1], indent: indent})
+			continue
+		}
+		m := routeRe.FindStringSubmatch(trimmed)
+		if m == nil {
+			if m = registryRe.FindStringSubmatch(trimmed); m == nil {
+				continue
+			}
+		}
+		method := strings.ToUpper(m[1])
+		if method != "GET" && method != "POST" && method != "PUT" && method != "PATCH" && method != "DELETE" {
+			method = "*"
+		}
+		route := Route{Method: method, Path: prefix + m[2], Handler: strings.TrimSpace(m[3])}
+		if key := route.Method + " " + route.Path; !seen[key] {
+			seen[key] = true
+			routes = append(routes, route)
+		}
+	}
+	return routes, nil
+}
+
+// readSizes totals the generated files by top-level directory, largest
+// first. Files deleted since generation are not counted.
+func readSizes(projectDir string, files map[string]string) ([]DirSize, error) {
+	byDir := map[string]*DirSize{}
+	for rel := range files {
+		info, err := os.Stat(filepath.Join(projectDir, filepath.FromSlash(rel)))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		dir, _, found := strings.Cut(rel, "/")
+		if !found {
+			dir = "."
+		}
+		if byDir[dir] == nil {
+			byDir[dir] = &DirSize{Dir: dir}
+		}
+		byDir[dir].Files++
+		byDir[dir].Bytes += info.Size()
+	}
+	sizes := make([]DirSize, 0, len(byDir))
+	for _, s := range byDir {
+		sizes = append(sizes, *s)
+	}
+	sort.Slice(sizes, func(i, j int) bool {
+		if sizes[i].Bytes != sizes[j].Bytes {
+			return sizes[i].Bytes > sizes[j].Bytes
+		}
+		return sizes[i].Dir < sizes[j].Dir
+	})
+	return sizes, nil
+}
+
+// totalSize sums the directory sizes
+func totalSize(sizes []DirSize) (files int, bytes int64) {
+	for _, s := range sizes {
+		files += s.Files
+		bytes += s.Bytes
+	}
+	return files, bytes
+}
+
+// formatBytes renders a size in B, KB or MB
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", n)
+}
+
+var envLineRe = regexp.MustCompile(`^(#\s*)?([A-Z][A-Z0-9_]*)=(.*)$`)
+
+// readEnvExample lists the variables in .env.example under their comment
+// headings
+func readEnvExample(path string) ([]EnvVar, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var vars []EnvVar
+	section := ""
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if m := envLineRe.FindStringSubmatch(line); m != nil {
+			vars = append(vars, EnvVar{Name: m[2], Default: m[3], Section: section, Optional: m[1] != ""})
+			continue
+		}
+		if heading, ok := strings.CutPrefix(line, "#"); ok {
+			section = strings.TrimSpace(heading)
+		}
+	}
+	return vars, scanner.Err()
+}
+
+// readComposeServices lists the active services of docker-compose.yml, with
+// the comment above each as its description
+func readComposeServices(path string) ([]Service, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var services []Service
+	inServices := false
+	comment := ""
+	for _, line := range strings.Split(string(data), "\n") {
+		switch {
+		case line == "services:":
+			inServices = true
+		case !inServices || strings.TrimSpace(line) == "":
+		case !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "#"):
+			inServices = false
+		case strings.HasPrefix(line, "  # "):
+			comment = strings.TrimPrefix(line, "  # ")
+		case strings.HasPrefix(line, "  ") && !strings.HasPrefix(line, "   ") && strings.HasSuffix(line, ":"):
+			services = append(services, Service{Name: strings.TrimSuffix(strings.TrimSpace(line), ":"), Description: comment})
+			comment = ""
+		case len(services) > 0 && strings.HasPrefix(line, "    image:"):
+			services[len(services)-1].Image = strings.TrimSpace(strings.TrimPrefix(line, "    image:"))
+		case len(services) > 0 && strings.HasPrefix(line, "    build:") && services[len(services)-1].Image == "":
+			services[len(services)-1].Image = "built from Dockerfile"
+		}
+	}
+	return services, nil
+}
+
+// Markdown renders the full report
+func (r *Report) Markdown() string {
+	m := r.Manifest
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Generation report: %s\n\n", m.Project.Name)
+	fmt.Fprintf(&b, "Generated by goforge %s from the %s templates (bundle %s) as module `%s`.\n",
+		m.Generator.Version, m.Template.Channel, m.Template.Version, m.Project.Module)
+	b.WriteString("The machine-readable record is `.goforge.yaml`; this file is for reviewing the scaffold.\n")
+
+	b.WriteString("\n## Options\n\n| Option | Value |\n| --- | --- |\n")
+	names := make([]string, 0, len(m.Options))
+	for name := range m.Options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(&b, "| `--%s` | %s |\n", name, m.Options[name])
+	}
+
+	b.WriteString("\n## Features\n\n")
+	if len(m.Features) == 0 {
+		b.WriteString("Base stack only.\n")
+	}
+	for _, f := range m.Features {
+		fmt.Fprintf(&b, "- %s\n", f)
+	}
+
+	b.WriteString("\n## Routes\n\n| Method | Path | Handler |\n| --- | --- | --- |\n")
+	for _, route := range r.Routes {
+		fmt.Fprintf(&b, "| %s | `%s` | `%s` |\n", route.Method, route.Path, route.Handler)
+	}
+
+	b.WriteString("\n## Environment variables\n\nCopy `.env.example` to `.env`. Optional variables ship commented out.\n\n")
+	b.WriteString("| Variable | Default | Required | Section |\n| --- | --- | --- | --- |\n")
+	for _, v := range r.EnvVars {
+		required := "yes"
+		if v.Optional {
+			required = "no"
+		}
+		fmt.Fprintf(&b, "| `%s` | `%s` | %s | %s |\n", v.Name, v.Default, required, v.Section)
+	}
+
+	b.WriteString("\n## Docker Compose services\n\n| Service | Image | Description |\n| --- | --- | --- |\n")
+	for _, s := range r.Services {
+		fmt.Fprintf(&b, "| %s | %s | %s |\n", s.Name, s.Image, s.Description)
+	}
+
+	b.WriteString("\n## Size\n\nGenerated files by top-level directory (`.` is the project root).")
+	if m.Options["lean"] != "true" {
+		b.WriteString(" `goforge new --lean` trims the scaffold for microservices.")
+	}
+	b.WriteString("\n\n| Directory | Files | Size |\n| --- | ---: | ---: |\n")
+	for _, s := range r.Sizes {
+		fmt.Fprintf(&b, "| `%s` | %d | %s |\n", s.Dir, s.Files, formatBytes(s.Bytes))
+	}
+	files, bytes := totalSize(r.Sizes)
+	fmt.Fprintf(&b, "| **Total** | %d | %s |\n", files, formatBytes(bytes))
+
+	b.WriteString("\n## Next steps\n\n```bash\n")
+	fmt.Fprintf(&b, "cd %s\n", m.Project.Name)
+	for _, step := range r.NextSteps {
+		b.WriteString(step + "\n")
+	}
+	b.WriteString("```\n")
+	return b.String()
+}
+
+// Summary renders the condensed report printed after `goforge new`
+func (r *Report) Summary() string {
+	required := 0
+	for _, v := range r.EnvVars {
+		if !v.Optional {
+			required++
+		}
+	}
+	services := make([]string, len(r.Services))
+	for i, s := range r.Services {
+		services[i] = s.Name
+	}
+	features := "base stack only"
+	if len(r.Manifest.Features) > 0 {
+		features = strings.Join(r.Manifest.Features, ", ")
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "   Features: %s\n", features)
+	fmt.Fprintf(&b, "   Routes:   %d\n", len(r.Routes))
+	fmt.Fprintf(&b, "   Env vars: %d required, %d optional\n", required, len(r.EnvVars)-required)
+	fmt.Fprintf(&b, "   Services: %s\n", strings.Join(services, ", "))
+	files, bytes := totalSize(r.Sizes)
+	fmt.Fprintf(&b, "   Size:     %d files, %s\n", files, formatBytes(bytes))
+	return b.String()
+}
+
+// writeReport writes the generation report into a freshly generated project
+func writeReport(projectDir string) error {
+	r, err := BuildReport(projectDir)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(projectDir, ReportPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(r.Markdown()), 0644)
+}
@@ -0,0 +1,122 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGenerateWritesReport(t *testing.T) {
+	projectDir := generateProject(t, Options{IncludeDB: true, Search: SearchMeilisearch, GDPR: true, Vite: true})
+
+	data, err := os.ReadFile(filepath.Join(projectDir, ReportPath))
+	if err != nil {
+		t.Fatalf("report not written: %v", err)
+	}
+	report := string(data)
+	for _, want := range []string{
+		"| `--search` | meilisearch |",
+		"- search-meili",
+		"| GET | `/api/hello` | `s.handleAPIHello` |",
+		"| POST | `/consent` | `s.handleConsent` |",
+		"| `DATABASE_URL` |",
+		"| meilisearch | getmeili/meilisearch",
+		"make vite-dev",
+		"## Size",
+		"| **Total** |",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report missing %q", want)
+		}
+	}
+
+	r, err := BuildReport(projectDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report != r.Markdown() {
+		t.Error("written report differs from BuildReport().Markdown()")
+	}
+	summary := r.Summary()
+	if !strings.Contains(summary, "Services: app, db, db-backup, meilisearch") || !strings.Contains(summary, "Size:") || !strings.Contains(summary, "search-meili") {
+		t.Errorf("Summary() = %q", summary)
+	}
+}
+
+func TestReadRoutes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "routes.go")
+	src := `package server
+
+func (s *Server) routes(r chi.Router) {
+	if dev {
+		r.Handle("/assets/*", http.StripPrefix("/assets", fs))
+	} else {
+		r.Handle("/assets/*", http.StripPrefix("/assets", fs))
+	}
+	// r.Get("/disabled", s.handleDisabled)
+	r.Get("/", s.handleHome)
+	r.Route("/api", func(r chi.Router) {
+		r.Route("/v1", func(r chi.Router) {
+			r.Post("/users", s.handleCreateUser)
+		})
+		r.Get("/hello", s.handleAPIHello)
+	})
+	r.Delete("/session", s.handleLogout)
+}
+
+func (s *Server) Routes() []router.Route {
+	return []router.Route{
+		{Name: "blog.post", Method: http.MethodGet, Path: "/blog/{slug}", Handler: s.handleBlogPost},
+		{Name: "proxy", Method: router.AnyMethod, Path: "/proxy/*", Handler: proxy.ServeHTTP},
+	}
+}
+`
+	if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	routes, err := readRoutes(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Route{
+		{"*", "/assets/*", `http.StripPrefix("/assets", fs)`},
+		{"GET", "/", "s.handleHome"},
+		{"POST", "/api/v1/users", "s.handleCreateUser"},
+		{"GET", "/api/hello", "s.handleAPIHello"},
+		{"DELETE", "/session", "s.handleLogout"},
+		{"GET", "/blog/{slug}", "s.handleBlogPost"},
+		{"*", "/proxy/*", "proxy.ServeHTTP"},
+	}
+	if !reflect.DeepEqual(routes, want) {
+		t.Errorf("readRoutes() =\n%v\nwant\n%v", routes, want)
+	}
+}
+
+func TestReadSizes(t *testing.T) {
+	dir := t.TempDir()
+	for name, size := range map[string]int{"go.mod": 10, "Makefile": 5, "cmd/server/main.go": 100, "internal/a.go": 40, "internal/b/c.go": 60} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, make([]byte, size), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files := map[string]string{"go.mod": "", "Makefile": "", "cmd/server/main.go": "", "internal/a.go": "", "internal/b/c.go": "", "deleted.go": ""}
+
+	sizes, err := readSizes(dir, files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []DirSize{{"cmd", 1, 100}, {"internal", 2, 100}, {".", 2, 15}}
+	if !reflect.DeepEqual(sizes, want) {
+		t.Errorf("readSizes() = %+v, want %+v", sizes, want)
+	}
+	if got := formatBytes(1536); got != "1.5 KB" {
+		t.Errorf("formatBytes(1536) = %q", got)
+	}
+}
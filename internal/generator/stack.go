@@ -0,0 +1,189 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/FACorreiaa/goforge/internal/manifest"
+)
+
+// StackPortBase is the host port of a stack's first service; the others
+// follow in config order
+const StackPortBase = 8080
+
+// serviceNameRe restricts service names to what works as a directory, a
+// compose service and (with - as _) a Postgres database
+var serviceNameRe = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// Stack is a system of services generated by one `goforge new --config`
+type Stack struct {
+	// Name is the root directory; each service is generated into Name/<service>
+	Name string
+	// Module is the module path prefix; each service is Module/<service>
+	Module string
+	// Services are generated in config order
+	Services []StackService
+}
+
+// StackService is one project of a stack
+type StackService struct {
+	Name    string
+	Options Options
+}
+
+// LoadStack reads a stack config:
+//
+//	name: shop
+//	module: github.com/acme/shop
+//	shared:
+//	  lint: standard
+//	services:
+//	  web:
+//	    example-resource: note
+//	  api:
+//	    lean: true
+//	  worker:
+//	    binaries: server,worker
+//
+// Options use the flag names of `goforge new`.
+// Shared options apply to every service and service options override them.
+func LoadStack(path string) (*Stack, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	stack, err := ParseStack(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return stack, nil
+}
+
+// ParseStack decodes a stack config (see LoadStack)
+func ParseStack(src string) (*Stack, error) {
+	doc, err := manifest.ParseDocument(src)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range doc.Keys() {
+		if !containsString([]string{"name", "module", "shared", "services"}, key) {
+			return nil, fmt.Errorf("unknown key %q", key)
+		}
+	}
+
+	stack := &Stack{}
+	if stack.Name, err = doc.String("name"); err != nil {
+		return nil, err
+	}
+	if stack.Module, err = doc.String("module"); err != nil {
+		return nil, err
+	}
+	if !strings.Contains(stack.Module, "/") {
+		return nil, fmt.Errorf("module must be a module path such as github.com/acme/shop, got %q", stack.Module)
+	}
+	shared, err := doc.Strings("shared")
+	if err != nil {
+		return nil, err
+	}
+	services, err := doc.Document("services")
+	if err != nil {
+		return nil, err
+	}
+	if len(services.Keys()) == 0 {
+		return nil, fmt.Errorf("services must list at least one service")
+	}
+
+	for _, name := range services.Keys() {
+		if !serviceNameRe.MatchString(name) {
+			return nil, fmt.Errorf("service %q: use lowercase letters, digits and dashes", name)
+		}
+		if name == "db" {
+			return nil, fmt.Errorf("service %q: the name is taken by the stack's Postgres service", name)
+		}
+		values, err := services.Strings(name)
+		if err != nil {
+			return nil, err
+		}
+		opts := Options{
+			Frontend:       FrontendHTMX,
+			CSSFramework:   CSSFrameworkDaisyUI,
+			DeployProvider: DeployNone,
+			IncludeDB:      true,
+		}
+		errs := setOptions(&opts, shared, "shared.")
+		errs = append(errs, setOptions(&opts, values, "services."+name+".")...)
+		if len(errs) > 0 {
+			return nil, errs[0]
+		}
+		if databaseOf(opts) == DatabaseMySQL {
+			return nil, fmt.Errorf("services.%s.db: the stack runs one Postgres for its services; generate MySQL services on their own", name)
+		}
+		stack.Services = append(stack.Services, StackService{Name: name, Options: opts})
+	}
+	return stack, nil
+}
+
+// stackComposeService is a service as the root compose file sees it
+type stackComposeService struct {
+	Name     string
+	Port     int
+	Database string
+	Worker   bool
+}
+
+// GenerateStack generates each service of the stack into its subdirectory
+// as a project of its own, then writes a root docker-compose.yml running them
+// together against one Postgres with a database per service
+func GenerateStack(stack *Stack) error {
+	if stack.Name == "" {
+		return fmt.Errorf("the stack needs a name (the root directory)")
+	}
+	if _, err := os.Stat(stack.Name); err == nil {
+		return fmt.Errorf("%s already exists", stack.Name)
+	}
+
+	data := struct {
+		Name     string
+		Services []stackComposeService
+		DB       bool
+	}{Name: filepath.Base(stack.Name)}
+	for i, svc := range stack.Services {
+		opts := svc.Options
+		opts.ProjectName = filepath.Join(stack.Name, svc.Name)
+		opts.ModulePath = stack.Module + "/" + svc.Name
+		fmt.Printf("\n📦 %s (%s)\n", svc.Name, opts.ModulePath)
+		if err := GenerateWithOptions(opts); err != nil {
+			return fmt.Errorf("service %s: %w", svc.Name, err)
+		}
+
+		opts.Binaries, _ = ParseBinaries(opts.Binaries) // validated by GenerateWithOptions
+		compose := stackComposeService{
+			Name:   svc.Name,
+			Port:   StackPortBase + i,
+			Worker: hasBinary(opts, BinaryWorker),
+		}
+		if usesPostgres(opts) {
+			compose.Database = strings.ReplaceAll(svc.Name, "-", "_")
+			data.DB = true
+		}
+		data.Services = append(data.Services, compose)
+	}
+
+	files := map[string]string{"docker-compose.yml": "stack_compose.yml.tmpl"}
+	if data.DB {
+		files[filepath.Join("db", "init.sql")] = "stack_init.sql.tmpl"
+	}
+	for rel, snippet := range files {
+		content, err := renderSnippet(snippet, data)
+		if err != nil {
+			return err
+		}
+		if err := writeNewFile(filepath.Join(stack.Name, rel), content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
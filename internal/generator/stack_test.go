@@ -0,0 +1,108 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseStack(t *testing.T) {
+	stack, err := ParseStack(`name: shop
+module: github.com/acme/shop
+shared:
+  lint: standard
+services:
+  web:
+    example-resource: note
+  api:
+    lean: true
+    lint: minimal
+  jobs:
+    db: false
+    binaries: server,worker
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stack.Name != "shop" || stack.Module != "github.com/acme/shop" || len(stack.Services) != 3 {
+		t.Fatalf("ParseStack() = %+v", stack)
+	}
+	web, api, jobs := stack.Services[0].Options, stack.Services[1].Options, stack.Services[2].Options
+	if stack.Services[0].Name != "web" || web.ExampleResource != ExampleNote || web.Lint != LintStandard || !web.IncludeDB {
+		t.Errorf("web = %+v", web)
+	}
+	if !api.Lean || api.Lint != LintMinimal {
+		t.Errorf("api does not override the shared options: %+v", api)
+	}
+	if jobs.IncludeDB || jobs.Binaries != "server,worker" {
+		t.Errorf("jobs = %+v", jobs)
+	}
+
+	for _, tc := range []struct{ src, want string }{
+		{"module: github.com/acme/shop\nservices: {}\n", "at least one service"},
+		{"module: shop\nservices:\n  web: {}\n", "module must be a module path"},
+		{"module: github.com/acme/shop\nservices:\n  Web: {}\n", "lowercase"},
+		{"module: github.com/acme/shop\nservices:\n  db: {}\n", "taken"},
+		{"module: github.com/acme/shop\nservices:\n  web:\n    search: solr\n", "services.web.search"},
+		{"module: github.com/acme/shop\nservices:\n  web:\n    db: mysql\n", "generate MySQL services on their own"},
+		{"module: github.com/acme/shop\nshared:\n  colour: blue\nservices:\n  web: {}\n", "shared.colour is not a goforge option"},
+		{"module: github.com/acme/shop\nports: 80\nservices:\n  web: {}\n", `unknown key "ports"`},
+	} {
+		if _, err := ParseStack(tc.src); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("ParseStack(%q) error = %v, want %q", tc.src, err, tc.want)
+		}
+	}
+}
+
+func TestGenerateStack(t *testing.T) {
+	stack, err := ParseStack(`module: github.com/acme/shop
+services:
+  web: {}
+  billing-api:
+    lean: true
+    binaries: server,worker
+  static:
+    db: false
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stack.Name = filepath.Join(t.TempDir(), "shop")
+	if err := GenerateStack(stack); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, svc := range []string{"web", "billing-api", "static"} {
+		gomod := readProjectFile(t, filepath.Join(stack.Name, svc), "go.mod")
+		if !strings.Contains(gomod, "module github.com/acme/shop/"+svc+"\n") {
+			t.Errorf("%s/go.mod has the wrong module path:\n%s", svc, gomod)
+		}
+	}
+	compose := readProjectFile(t, stack.Name, "docker-compose.yml")
+	for _, want := range []string{
+		"  web:\n    build:\n      context: ./web",
+		`- "8081:8080"`,
+		"postgres@db:5432/billing_api?sslmode=disable",
+		"  billing-api-worker:",
+		"- ./db/init.sql:/docker-entrypoint-initdb.d/init.sql:ro",
+	} {
+		if !strings.Contains(compose, want) {
+			t.Errorf("docker-compose.yml missing %q", want)
+		}
+	}
+	if strings.Contains(compose, "/static?") || strings.Contains(compose, "static-worker") {
+		t.Error("docker-compose.yml wires a database or worker the static service does not have")
+	}
+	initSQL := readProjectFile(t, stack.Name, "db/init.sql")
+	if !strings.Contains(initSQL, "CREATE DATABASE web;\nCREATE DATABASE billing_api;\n") || strings.Contains(initSQL, "static") {
+		t.Errorf("db/init.sql:\n%s", initSQL)
+	}
+
+	if err := GenerateStack(stack); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("regenerating over an existing stack: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(stack.Name, "web", ".goforge.yaml")); err != nil {
+		t.Error("services are not regular goforge projects")
+	}
+}
@@ -0,0 +1,338 @@
+package generator
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/FACorreiaa/goforge/internal/manifest"
+)
+
+// TelemetryFile is the telemetry setting's name in goforge's user config
+// directory; TelemetryQueueFile holds the events not sent yet, next to it
+const (
+	TelemetryFile      = "telemetry.yaml"
+	TelemetryQueueFile = "telemetry-queue.jsonl"
+)
+
+// DefaultTelemetryEndpoint receives the usage events of goforge users who
+// turned telemetry on
+const DefaultTelemetryEndpoint = "https://telemetry.goforge.dev/v1/usage"
+
+const (
+	// telemetryBatch is how many queued events make a send due
+	telemetryBatch = 10
+	// maxTelemetryQueue caps the queue offline; the oldest events are dropped
+	maxTelemetryQueue = 500
+	telemetryTimeout  = 3 * time.Second
+)
+
+// TelemetryStatement is what `goforge telemetry data` shows before the queued
+// events: what is collected, what is not, and how to turn it off
+const TelemetryStatement = `goforge telemetry is off unless you turn it on with 'goforge telemetry on'.
+
+When it is on, each project 'goforge new' generates queues one event on this
+machine, in telemetry-queue.jsonl under goforge's user config directory:
+
+  - the options chosen, by flag name, for options with a fixed set of
+    values (the frontend, css framework, database, search, deploy target,
+    and the on/off features)
+  - the template channel and bundle version, the goforge version
+  - the operating system and architecture
+  - the day (no time of day)
+
+It never holds the project name, module path, directory, base path, asset
+mirror, template variables, Go version, or anything about you or your
+machine beyond its OS: there is no user, machine or session id, so events
+cannot be linked to each other or to you.
+
+Queued events are sent in batches over HTTPS once there are enough of them
+or a day has passed; a send that fails (offline, firewalled) keeps them
+queued and goforge carries on as usual. DO_NOT_TRACK=1 or
+GOFORGE_TELEMETRY=off stops both queueing and sending whatever the setting.
+'goforge telemetry off' turns it off and deletes the queue.
+
+The counts only tell the maintainers which stacks and features are used,
+to decide what to work on and what to keep.`
+
+// UsageEvent is the anonymous record of one generated project
+type UsageEvent struct {
+	Day     string            `json:"day"`
+	Goforge string            `json:"goforge"`
+	Channel string            `json:"channel"`
+	Bundle  string            `json:"bundle"`
+	OS      string            `json:"os"`
+	Arch    string            `json:"arch"`
+	Options map[string]string `json:"options"`
+}
+
+// Telemetry queues usage events and sends them, when turned on
+type Telemetry struct {
+	// Dir holds the setting and the queue
+	Dir string
+	// Endpoint overrides the endpoint set in the setting file
+	Endpoint string
+	Client   *http.Client
+	// Now is the clock, for tests
+	Now func() time.Time
+}
+
+// NewTelemetry returns the telemetry of goforge's user config directory
+func NewTelemetry() (*Telemetry, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	return &Telemetry{
+		Dir:    filepath.Join(dir, "goforge"),
+		Client: &http.Client{Timeout: telemetryTimeout},
+		Now:    time.Now,
+	}, nil
+}
+
+// TelemetryBlocked reports whether the environment forbids telemetry:
+// DO_NOT_TRACK set to anything but false, or GOFORGE_TELEMETRY=off
+func TelemetryBlocked() bool {
+	if v := strings.ToLower(os.Getenv("DO_NOT_TRACK")); v != "" && v != "false" && v != "0" {
+		return true
+	}
+	return strings.EqualFold(os.Getenv("GOFORGE_TELEMETRY"), "off")
+}
+
+// telemetrySetting is the content of TelemetryFile
+type telemetrySetting struct {
+	Enabled  bool
+	Endpoint string
+}
+
+func (t *Telemetry) setting() (telemetrySetting, error) {
+	path := filepath.Join(t.Dir, TelemetryFile)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return telemetrySetting{}, nil
+	}
+	if err != nil {
+		return telemetrySetting{}, err
+	}
+	doc, err := manifest.ParseDocument(string(data))
+	if err != nil {
+		return telemetrySetting{}, fmt.Errorf("%s: %w", path, err)
+	}
+	var s telemetrySetting
+	for _, key := range doc.Keys() {
+		if key != "enabled" && key != "endpoint" {
+			return s, fmt.Errorf("%s: unknown key %q", path, key)
+		}
+	}
+	enabled, err := doc.String("enabled")
+	if err != nil {
+		return s, fmt.Errorf("%s: %w", path, err)
+	}
+	if enabled != "" {
+		if s.Enabled, err = strconv.ParseBool(enabled); err != nil {
+			return s, fmt.Errorf("%s: enabled must be true or false, got %q", path, enabled)
+		}
+	}
+	if s.Endpoint, err = doc.String("endpoint"); err != nil {
+		return s, fmt.Errorf("%s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Enabled reports whether telemetry is turned on and not blocked by the
+// environment
+func (t *Telemetry) Enabled() (bool, error) {
+	s, err := t.setting()
+	if err != nil {
+		return false, err
+	}
+	return s.Enabled && !TelemetryBlocked(), nil
+}
+
+// SetEnabled turns telemetry on or off, keeping a custom endpoint. Turning
+// it off deletes the queue.
+func (t *Telemetry) SetEnabled(on bool) error {
+	s, err := t.setting()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(t.Dir, 0755); err != nil {
+		return err
+	}
+	content := "# goforge telemetry: run 'goforge telemetry data' for what is collected\n"
+	content += fmt.Sprintf("enabled: %t\n", on)
+	if s.Endpoint != "" {
+		content += fmt.Sprintf("endpoint: %q\n", s.Endpoint)
+	}
+	if err := os.WriteFile(filepath.Join(t.Dir, TelemetryFile), []byte(content), 0644); err != nil {
+		return err
+	}
+	if !on {
+		if err := os.Remove(filepath.Join(t.Dir, TelemetryQueueFile)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// usageEvent is the event for a project generated with opts
+func (t *Telemetry) usageEvent(opts Options) UsageEvent {
+	channel := opts.Channel
+	if channel == "" {
+		channel = ChannelStable
+	}
+	bundle := opts.At
+	if bundle == "" {
+		bundle = bundleVersions[channel]
+	}
+	values := optionsToManifest(opts)
+	options := map[string]string{}
+	for _, spec := range manifestOptions {
+		// Free-form options could name the project or its owner
+		if spec.check == nil {
+			options[spec.name] = values[spec.name]
+		}
+	}
+	return UsageEvent{
+		Day:     t.Now().UTC().Format(time.DateOnly),
+		Goforge: Version,
+		Channel: channel,
+		Bundle:  bundle,
+		OS:      runtime.GOOS,
+		Arch:    runtime.GOARCH,
+		Options: options,
+	}
+}
+
+// Record queues the usage event of each generated project when telemetry
+// is on. It never touches the network.
+func (t *Telemetry) Record(projects ...Options) error {
+	if on, err := t.Enabled(); err != nil || !on {
+		return err
+	}
+	events, err := t.Pending()
+	if err != nil {
+		return err
+	}
+	for _, opts := range projects {
+		events = append(events, t.usageEvent(opts))
+	}
+	if len(events) > maxTelemetryQueue {
+		events = events[len(events)-maxTelemetryQueue:]
+	}
+	return t.writeQueue(events)
+}
+
+// Pending returns the queued events, oldest first
+func (t *Telemetry) Pending() ([]UsageEvent, error) {
+	f, err := os.Open(filepath.Join(t.Dir, TelemetryQueueFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var events []UsageEvent
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e UsageEvent
+		// A line cut short by a crash is dropped rather than blocking the queue
+		if json.Unmarshal(scanner.Bytes(), &e) == nil {
+			events = append(events, e)
+		}
+	}
+	return events, scanner.Err()
+}
+
+func (t *Telemetry) writeQueue(events []UsageEvent) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, e := range events {
+		if err := enc.Encode(e); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(t.Dir, 0755); err != nil {
+		return err
+	}
+	path := filepath.Join(t.Dir, TelemetryQueueFile)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Due reports whether the queue should be sent: it holds a batch, or an
+// event from an earlier day
+func (t *Telemetry) Due() bool {
+	events, err := t.Pending()
+	if err != nil || len(events) == 0 {
+		return false
+	}
+	return len(events) >= telemetryBatch || events[0].Day != t.Now().UTC().Format(time.DateOnly)
+}
+
+// Flush sends the queued events and empties the queue, returning how many
+// were sent. A failed send leaves the queue as it was.
+func (t *Telemetry) Flush() (int, error) {
+	on, err := t.Enabled()
+	if err != nil || !on {
+		return 0, err
+	}
+	events, err := t.Pending()
+	if err != nil || len(events) == 0 {
+		return 0, err
+	}
+	s, err := t.setting()
+	if err != nil {
+		return 0, err
+	}
+	endpoint := DefaultTelemetryEndpoint
+	if s.Endpoint != "" {
+		endpoint = s.Endpoint
+	}
+	if t.Endpoint != "" {
+		endpoint = t.Endpoint
+	}
+	if u, err := url.Parse(endpoint); err != nil || u.Scheme != "https" {
+		return 0, fmt.Errorf("telemetry endpoint %s: only https URLs are allowed", endpoint)
+	}
+
+	body, err := json.Marshal(struct {
+		Events []UsageEvent `json:"events"`
+	}{events})
+	if err != nil {
+		return 0, err
+	}
+	resp, err := t.Client.Post(endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return 0, fmt.Errorf("%s: %s", endpoint, resp.Status)
+	}
+
+	// Keep events queued while sending, by another goforge run
+	after, err := t.Pending()
+	if err != nil {
+		return len(events), err
+	}
+	if len(after) >= len(events) {
+		after = after[len(events):]
+	} else {
+		after = nil
+	}
+	return len(events), t.writeQueue(after)
+}
@@ -0,0 +1,147 @@
+package generator
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestTelemetry(t *testing.T) *Telemetry {
+	t.Helper()
+	t.Setenv("DO_NOT_TRACK", "")
+	t.Setenv("GOFORGE_TELEMETRY", "")
+	day := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	return &Telemetry{Dir: t.TempDir(), Client: http.DefaultClient, Now: func() time.Time { return day }}
+}
+
+func TestTelemetryOptIn(t *testing.T) {
+	tel := newTestTelemetry(t)
+	project := Options{
+		ProjectName: "secret-project", ModulePath: "github.com/acme/secret",
+		Frontend: FrontendHTMX, IncludeDB: true, Billing: true,
+		BasePath: "/acme", AssetMirror: "https://artifacts.acme.internal/cdn",
+	}
+
+	// Off until turned on
+	if err := tel.Record(project); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(tel.Dir, TelemetryQueueFile)); !os.IsNotExist(err) {
+		t.Fatal("queued an event with telemetry off")
+	}
+
+	if err := tel.SetEnabled(true); err != nil {
+		t.Fatal(err)
+	}
+	if err := tel.Record(project); err != nil {
+		t.Fatal(err)
+	}
+	queue, err := os.ReadFile(filepath.Join(tel.Dir, TelemetryQueueFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, private := range []string{"secret", "acme"} {
+		if strings.Contains(string(queue), private) {
+			t.Errorf("the event holds %q:\n%s", private, queue)
+		}
+	}
+	events, err := tel.Pending()
+	if err != nil || len(events) != 1 {
+		t.Fatalf("Pending() = %v, %v", events, err)
+	}
+	e := events[0]
+	if e.Day != "2026-10-16" || e.Channel != ChannelStable || e.Bundle != bundleVersions[ChannelStable] ||
+		e.Options["billing"] != "true" || e.Options["frontend"] != FrontendHTMX || e.Options["search"] != SearchNone {
+		t.Errorf("event = %+v", e)
+	}
+	if _, ok := e.Options["base-path"]; ok {
+		t.Error("the event records a free-form option")
+	}
+
+	// The environment wins over the setting
+	t.Setenv("DO_NOT_TRACK", "1")
+	if on, _ := tel.Enabled(); on {
+		t.Error("telemetry on with DO_NOT_TRACK=1")
+	}
+	t.Setenv("DO_NOT_TRACK", "")
+
+	// The queue is capped offline
+	many := make([]Options, maxTelemetryQueue)
+	for i := range many {
+		many[i] = project
+	}
+	if err := tel.Record(many...); err != nil {
+		t.Fatal(err)
+	}
+	if events, _ := tel.Pending(); len(events) != maxTelemetryQueue {
+		t.Errorf("queued %d events, want the cap of %d", len(events), maxTelemetryQueue)
+	}
+
+	if err := tel.SetEnabled(false); err != nil {
+		t.Fatal(err)
+	}
+	if events, _ := tel.Pending(); len(events) != 0 {
+		t.Error("turning telemetry off kept the queue")
+	}
+}
+
+func TestTelemetryFlush(t *testing.T) {
+	tel := newTestTelemetry(t)
+	if err := tel.SetEnabled(true); err != nil {
+		t.Fatal(err)
+	}
+	if err := tel.Record(Options{Frontend: FrontendHTMX}); err != nil {
+		t.Fatal(err)
+	}
+	if tel.Due() {
+		t.Error("one event of today is due")
+	}
+
+	// Offline: the events stay queued
+	tel.Endpoint = "https://127.0.0.1:1/usage"
+	if _, err := tel.Flush(); err == nil {
+		t.Fatal("Flush() to an unreachable endpoint succeeded")
+	}
+	if events, _ := tel.Pending(); len(events) != 1 {
+		t.Fatalf("a failed send left %d events, want 1", len(events))
+	}
+
+	// A new day makes the queue due
+	tel.Now = func() time.Time { return time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC) }
+	if !tel.Due() {
+		t.Error("yesterday's event is not due")
+	}
+
+	var received struct{ Events []UsageEvent }
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &received); err != nil {
+			t.Errorf("body %s: %v", body, err)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+	tel.Endpoint, tel.Client = srv.URL, srv.Client()
+	sent, err := tel.Flush()
+	if err != nil || sent != 1 {
+		t.Fatalf("Flush() = %d, %v", sent, err)
+	}
+	if len(received.Events) != 1 || received.Events[0].Options["frontend"] != FrontendHTMX {
+		t.Errorf("received %+v", received)
+	}
+	if events, _ := tel.Pending(); len(events) != 0 {
+		t.Errorf("%d events still queued after sending", len(events))
+	}
+
+	tel.Endpoint = "http://example.com/usage"
+	tel.Record(Options{})
+	if _, err := tel.Flush(); err == nil || !strings.Contains(err.Error(), "only https") {
+		t.Errorf("Flush() over http: %v", err)
+	}
+}
@@ -1,8 +1,8 @@
 // Service Worker for GoForge PWA
 const CACHE_NAME = 'goforge-v1';
 const urlsToCache = [
-  '/',
-  '/assets/dist/styles.css',
+  '<!-- BASE_PATH -->/',
+  '<!-- BASE_PATH -->/assets/dist/styles.css',
 ];
 
 // Install event - cache static assets
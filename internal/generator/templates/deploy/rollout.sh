@@ -0,0 +1,79 @@
+#!/bin/bash
+# =========================================================================
+# Replace the app container without downtime
+# =========================================================================
+# Usage: make rollout (APP_ENV=staging make rollout)
+#
+# Runs against the production stack (docker-compose.yml plus
+# docker-compose.prod.yml), where only the proxy publishes ports:
+#   1. build the new image
+#   2. start a second app container next to the running one
+#   3. wait until its health check (/ready) passes; give up and remove it
+#      after ROLLOUT_TIMEOUT seconds, leaving the old container serving
+#   4. stop the old container: SIGTERM fails its /ready, it keeps serving
+#      for DRAIN_DELAY, then finishes in-flight requests and exits
+# The proxy sends traffic to every healthy app container while both run.
+# =========================================================================
+
+set -euo pipefail
+
+SERVICE="app"
+TIMEOUT="${ROLLOUT_TIMEOUT:-120}"
+export APP_ENV="${APP_ENV:-production}"
+COMPOSE=(docker compose -f docker-compose.yml -f docker-compose.prod.yml)
+
+cd "$(dirname "$0")/.."
+
+old="$("${COMPOSE[@]}" ps -q "$SERVICE")"
+if [ -z "$old" ]; then
+    echo "ℹ️  $SERVICE is not running; starting the stack"
+    "${COMPOSE[@]}" up -d --build
+    exit 0
+fi
+if [ "$(wc -l <<<"$old")" -ne 1 ]; then
+    echo "❌ Error: more than one $SERVICE container is running; finish or clean up the last rollout first"
+    exit 1
+fi
+
+# reload_proxy makes the proxy pick up the current set of app containers
+reload_proxy() {
+<!-- IF PROXY_NGINX -->    # nginx resolves app once per (re)load
+    "${COMPOSE[@]}" exec -T proxy nginx -s reload
+<!-- /IF PROXY_NGINX --><!-- IF NOT PROXY_NGINX -->    # The proxy resolves app on every new connection; nothing to reload
+    :
+<!-- /IF NOT PROXY_NGINX -->}
+
+# Step 1: Build
+echo "🔨 Building $SERVICE..."
+"${COMPOSE[@]}" build "$SERVICE"
+
+# Step 2: Start the new container next to the old one
+echo "🚀 Starting a new $SERVICE container..."
+"${COMPOSE[@]}" up -d --no-deps --no-recreate --scale "$SERVICE=2" "$SERVICE"
+new="$("${COMPOSE[@]}" ps -q "$SERVICE" | grep -v "$old")"
+
+# Step 3: Wait for it to become healthy
+echo "⏳ Waiting up to ${TIMEOUT}s for ${new:0:12} to become healthy..."
+status="starting"
+for _ in $(seq "$TIMEOUT"); do
+    status="$(docker inspect -f '{{.State.Health.Status}}' "$new")"
+    [ "$status" = "healthy" ] || [ "$status" = "unhealthy" ] && break
+    sleep 1
+done
+if [ "$status" != "healthy" ]; then
+    echo "❌ New container is $status; rolling back"
+    docker logs --tail 30 "$new"
+    docker rm -f "$new" >/dev/null
+    "${COMPOSE[@]}" up -d --no-deps --no-recreate --scale "$SERVICE=1" "$SERVICE"
+    exit 1
+fi
+reload_proxy
+
+# Step 4: Drain and remove the old container
+echo "🛑 Draining ${old:0:12}..."
+docker stop "$old" >/dev/null
+docker rm "$old" >/dev/null
+"${COMPOSE[@]}" up -d --no-deps --no-recreate --scale "$SERVICE=1" "$SERVICE"
+reload_proxy
+
+echo "✅ $SERVICE rolled out"
@@ -0,0 +1,84 @@
+#!/bin/bash
+# =========================================================================
+# Install or update the application as a systemd service
+# =========================================================================
+# Usage (on the server, as root): bash <release-dir>/systemd/install.sh
+#
+# `make deploy-ssh` uploads a release (server binary, assets, this
+# directory) and runs this script. It is safe to run repeatedly:
+#   - the first run creates the service user, directories and unit
+#   - every run replaces the binary and assets and restarts the service,
+#     without refusing connections (see myapp.socket)
+#   - the environment file is only replaced by one uploaded with
+#     `make deploy-env`, never by the template
+# =========================================================================
+
+set -euo pipefail
+
+SERVICE_NAME="myapp"
+INSTALL_DIR="/opt/$SERVICE_NAME"
+CONFIG_DIR="/etc/$SERVICE_NAME"
+ENV_FILE="$CONFIG_DIR/$SERVICE_NAME.env"
+RELEASE_DIR="$(cd "$(dirname "$0")/.." && pwd)"
+
+if [ "$(id -u)" -ne 0 ]; then
+    echo "❌ Error: run as root (sudo bash $0)"
+    exit 1
+fi
+
+# Step 1: Create the service user (no login, no home)
+if ! id "$SERVICE_NAME" >/dev/null 2>&1; then
+    echo "👤 Creating user $SERVICE_NAME..."
+    useradd --system --no-create-home --home-dir "$INSTALL_DIR" --shell /usr/sbin/nologin "$SERVICE_NAME"
+fi
+
+# Step 2: Create directories (code is root-owned and read-only to the service)
+install -d -m 0755 -o root -g root "$INSTALL_DIR"
+install -d -m 0750 -o root -g "$SERVICE_NAME" "$CONFIG_DIR"
+
+# Step 3: Install the environment file
+if [ -f "$RELEASE_DIR/app.env" ]; then
+    echo "🔑 Installing uploaded environment file..."
+    install -m 0640 -o root -g "$SERVICE_NAME" "$RELEASE_DIR/app.env" "$ENV_FILE"
+    rm -f "$RELEASE_DIR/app.env"
+elif [ ! -f "$ENV_FILE" ]; then
+    echo "🔑 Creating $ENV_FILE from the template..."
+    install -m 0640 -o root -g "$SERVICE_NAME" "$RELEASE_DIR/systemd/app.env.example" "$ENV_FILE"
+    echo "⚠️  Edit $ENV_FILE (or run make deploy-env), then: systemctl restart $SERVICE_NAME"
+fi
+
+if [ ! -f "$RELEASE_DIR/server" ]; then
+    echo "ℹ️  No binary in $RELEASE_DIR yet; run make deploy-ssh to install the application"
+    exit 0
+fi
+
+# Step 4: Install binary and assets (swap the binary atomically)
+echo "📦 Installing binary and assets..."
+install -m 0755 -o root -g root "$RELEASE_DIR/server" "$INSTALL_DIR/server.new"
+mv -f "$INSTALL_DIR/server.new" "$INSTALL_DIR/server"
+rm -rf "$INSTALL_DIR/assets"
+cp -R "$RELEASE_DIR/assets" "$INSTALL_DIR/assets"
+chown -R root:root "$INSTALL_DIR/assets"
+
+# Step 5: Install the units and restart. The socket stays open across the
+# restart, so clients wait for the new process instead of being refused.
+echo "⚙️  Installing $SERVICE_NAME.socket and $SERVICE_NAME.service..."
+install -m 0644 -o root -g root "$RELEASE_DIR/systemd/app.socket" "/etc/systemd/system/$SERVICE_NAME.socket"
+install -m 0644 -o root -g root "$RELEASE_DIR/systemd/app.service" "/etc/systemd/system/$SERVICE_NAME.service"
+systemctl daemon-reload
+systemctl enable "$SERVICE_NAME.socket" "$SERVICE_NAME" >/dev/null 2>&1
+if ! systemctl is-active --quiet "$SERVICE_NAME.socket"; then
+    # First install, or an older service that bound the port itself
+    systemctl stop "$SERVICE_NAME"
+    systemctl start "$SERVICE_NAME.socket"
+fi
+systemctl restart "$SERVICE_NAME"
+
+# Step 6: Verify
+sleep 2
+if ! systemctl is-active --quiet "$SERVICE_NAME"; then
+    echo "❌ $SERVICE_NAME failed to start:"
+    journalctl -u "$SERVICE_NAME" -n 30 --no-pager
+    exit 1
+fi
+echo "✅ $SERVICE_NAME is running"
@@ -0,0 +1,30 @@
+import { defineConfig, devices } from '@playwright/test';
+
+// Tests run against E2E_BASE_URL. Without it, Playwright builds and starts
+// the server itself (make run) and reuses one that is already running locally.
+const baseURL = process.env.E2E_BASE_URL || 'http://localhost:8080';
+
+export default defineConfig({
+  testDir: './tests',
+  fullyParallel: true,
+  forbidOnly: !!process.env.CI,
+  retries: process.env.CI ? 2 : 0,
+  reporter: process.env.CI ? [['github'], ['html', { open: 'never' }]] : 'list',
+  use: {
+    baseURL,
+    trace: 'on-first-retry',
+    screenshot: 'only-on-failure',
+  },
+  projects: [
+    { name: 'chromium', use: { ...devices['Desktop Chrome'] } },
+    { name: 'mobile', use: { ...devices['Pixel 7'] } },
+  ],
+  webServer: process.env.E2E_BASE_URL
+    ? undefined
+    : {
+        command: 'make -C .. run',
+        url: `${baseURL}/health`,
+        reuseExistingServer: !process.env.CI,
+        timeout: 120_000,
+      },
+});
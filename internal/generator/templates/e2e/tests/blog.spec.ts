@@ -0,0 +1,20 @@
+import { test, expect } from '@playwright/test';
+
+<!-- IF GDPR -->// Decide cookie consent up front so the banner doesn't cover the page
+test.beforeEach(async ({ context, baseURL }) => {
+  await context.addCookies([{ name: 'cookie_consent', value: 'necessary', url: baseURL! }]);
+});
+
+<!-- /IF GDPR -->test('blog index links to posts', async ({ page }) => {
+  await page.goto('<!-- BASE_PATH -->/blog');
+
+  await expect(page.getByRole('heading', { level: 1 })).toHaveText('Blog');
+  await page.getByRole('link', { name: 'Hello, World' }).click();
+  await expect(page).toHaveURL(/\/blog\/hello-world$/);
+});
+
+test('RSS feed is served', async ({ request }) => {
+  const response = await request.get('<!-- BASE_PATH -->/blog/feed.xml');
+  expect(response.ok()).toBeTruthy();
+  expect(await response.text()).toContain('<rss');
+});
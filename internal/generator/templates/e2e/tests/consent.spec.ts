@@ -0,0 +1,22 @@
+import { test, expect } from '@playwright/test';
+
+test('consent banner disappears once a choice is made and stays hidden', async ({ page }) => {
+  await page.goto('<!-- BASE_PATH -->/');
+
+  const banner = page.getByRole('region', { name: 'Cookie consent' });
+  await expect(banner).toBeVisible();
+
+  await banner.getByRole('button', { name: 'Necessary only' }).click();
+  await expect(banner).toBeHidden();
+
+  await page.reload();
+  await expect(page.getByRole('region', { name: 'Cookie consent' })).toBeHidden();
+});
+
+test('privacy and terms pages are linked from the footer', async ({ page, context, baseURL }) => {
+  await context.addCookies([{ name: 'cookie_consent', value: 'necessary', url: baseURL! }]);
+  await page.goto('<!-- BASE_PATH -->/');
+
+  await page.getByRole('navigation', { name: 'Legal' }).getByRole('link', { name: 'Privacy' }).click();
+  await expect(page.getByRole('heading', { level: 1 })).toHaveText('Privacy Policy');
+});
@@ -0,0 +1,44 @@
+import { test, expect } from '@playwright/test';
+
+<!-- IF GDPR -->// Decide cookie consent up front so the banner doesn't cover the page
+test.beforeEach(async ({ context, baseURL }) => {
+  await context.addCookies([{ name: 'cookie_consent', value: 'necessary', url: baseURL! }]);
+});
+
+<!-- /IF GDPR -->test.describe('home page', () => {
+  test('renders the hero and features', async ({ page }) => {
+    await page.goto('<!-- BASE_PATH -->/');
+
+    await expect(page).toHaveTitle(/Home/);
+    await expect(page.getByRole('heading', { level: 1 })).toContainText('GoForge');
+    await expect(page.getByRole('heading', { name: 'Stack Features' })).toBeVisible();
+  });
+
+  test('skip link moves focus to the main content', async ({ page }) => {
+    await page.goto('<!-- BASE_PATH -->/');
+
+    await page.keyboard.press('Tab');
+    const skipLink = page.getByRole('link', { name: 'Skip to main content' });
+    await expect(skipLink).toBeFocused();
+    await skipLink.press('Enter');
+    await expect(page).toHaveURL(/#main-content$/);
+  });
+
+  test('HTMX button swaps in the API response', async ({ page }) => {
+    await page.goto('<!-- BASE_PATH -->/');
+
+    const result = page.locator('#api-result');
+    await expect(result).toContainText('Click the button above');
+
+    const response = page.waitForResponse('**/api/hello');
+    await page.getByRole('button', { name: 'Fetch Message' }).click();
+    expect((await response).ok()).toBeTruthy();
+
+    await expect(result).toContainText('Hello from GoForge!');
+  });
+});
+
+test('health endpoint reports ok', async ({ request }) => {
+  const response = await request.get('/health');
+  expect(response.ok()).toBeTruthy();
+});
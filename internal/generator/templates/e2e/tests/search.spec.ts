@@ -0,0 +1,23 @@
+import { test, expect } from '@playwright/test';
+
+<!-- IF GDPR -->// Decide cookie consent up front so the banner doesn't cover the page
+test.beforeEach(async ({ context, baseURL }) => {
+  await context.addCookies([{ name: 'cookie_consent', value: 'necessary', url: baseURL! }]);
+});
+
+<!-- /IF GDPR -->test('live search shows results while typing', async ({ page }) => {
+  await page.goto('<!-- BASE_PATH -->/search');
+
+  const results = page.locator('#search-results');
+  const response = page.waitForResponse((r) => r.url().includes('<!-- BASE_PATH -->/search/results'));
+  await page.getByRole('searchbox', { name: 'Search' }).pressSequentially('Home', { delay: 50 });
+  await response;
+
+  await expect(results.getByRole('link', { name: 'Home' })).toBeVisible();
+});
+
+test('search without JavaScript falls back to the full page', async ({ page }) => {
+  await page.goto('<!-- BASE_PATH -->/search?q=health');
+
+  await expect(page.locator('#search-results').getByRole('link', { name: 'Health' })).toBeVisible();
+});
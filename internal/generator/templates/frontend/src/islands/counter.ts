@@ -0,0 +1,20 @@
+// Example island: <div data-island="counter" data-start="0"></div>
+export function mountCounter(el: HTMLElement): void {
+  let count = Number(el.dataset.start ?? 0);
+
+  const button = document.createElement("button");
+  button.type = "button";
+  button.className = "btn btn-primary";
+
+  const render = () => {
+    button.textContent = `Clicked ${count} times`;
+  };
+
+  button.addEventListener("click", () => {
+    count++;
+    render();
+  });
+
+  render();
+  el.replaceChildren(button);
+}
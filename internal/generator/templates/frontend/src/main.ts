@@ -0,0 +1,30 @@
+// Entry point for TypeScript islands.
+//
+// An island is a small piece of client-side behaviour attached to server
+// rendered HTML. Mark an element with data-island="<name>" and register the
+// matching mount function below; islands are (re)mounted after every HTMX swap.
+import { mountCounter } from "./islands/counter";
+
+type MountFn = (el: HTMLElement) => void;
+
+const islands: Record<string, MountFn> = {
+  counter: mountCounter,
+};
+
+function mountIslands(root: ParentNode = document): void {
+  root.querySelectorAll<HTMLElement>("[data-island]").forEach((el) => {
+    if (el.dataset.islandMounted) {
+      return;
+    }
+    const mount = islands[el.dataset.island ?? ""];
+    if (mount) {
+      mount(el);
+      el.dataset.islandMounted = "true";
+    }
+  });
+}
+
+document.addEventListener("DOMContentLoaded", () => mountIslands());
+document.body.addEventListener("htmx:afterSwap", (evt) => {
+  mountIslands(evt.target as ParentNode);
+});
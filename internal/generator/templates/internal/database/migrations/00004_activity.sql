@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS activities (
+    id BIGSERIAL PRIMARY KEY,
+    actor TEXT NOT NULL DEFAULT '',
+    verb VARCHAR(64) NOT NULL,
+    subject_type VARCHAR(64) NOT NULL,
+    subject_id TEXT NOT NULL DEFAULT '',
+    summary TEXT NOT NULL,
+    url TEXT NOT NULL DEFAULT '',
+    metadata JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_activities_subject ON activities (subject_type, subject_id, id DESC);
+CREATE INDEX idx_activities_actor ON activities (actor, id DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS activities;
+-- +goose StatementEnd
@@ -0,0 +1,45 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS plans (
+    id VARCHAR(32) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    price_cents INTEGER NOT NULL DEFAULT 0,
+    currency CHAR(3) NOT NULL DEFAULT 'usd',
+    stripe_price_id VARCHAR(255) UNIQUE,
+    features TEXT[] NOT NULL DEFAULT '{}',
+    position INTEGER NOT NULL DEFAULT 0
+);
+
+INSERT INTO plans (id, name, description, price_cents, features, position) VALUES
+    ('free', 'Free', 'For trying things out', 0, '{}', 0),
+    ('pro', 'Pro', 'For professionals', 1200, '{exports,api}', 1),
+    ('business', 'Business', 'For growing companies', 4900, '{exports,api,priority_support}', 2)
+ON CONFLICT (id) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS subscriptions (
+    user_id UUID PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    stripe_customer_id VARCHAR(255) UNIQUE NOT NULL,
+    stripe_subscription_id VARCHAR(255) UNIQUE NOT NULL,
+    plan_id VARCHAR(32) NOT NULL REFERENCES plans (id),
+    status VARCHAR(32) NOT NULL,
+    current_period_end TIMESTAMP WITH TIME ZONE,
+    cancel_at_period_end BOOLEAN NOT NULL DEFAULT FALSE,
+    event_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Stripe delivers webhooks at least once: processed events are skipped
+CREATE TABLE IF NOT EXISTS billing_events (
+    id VARCHAR(255) PRIMARY KEY,
+    type VARCHAR(100) NOT NULL,
+    received_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS billing_events;
+DROP TABLE IF EXISTS subscriptions;
+DROP TABLE IF EXISTS plans;
+-- +goose StatementEnd
@@ -0,0 +1,54 @@
+-- +goose Up
+-- +goose StatementBegin
+<!-- IF EXAMPLE_NOTE -->ALTER TABLE notes ADD COLUMN IF NOT EXISTS search_vector tsvector;
+
+-- Keeps search_vector in step with the text it indexes. Titles weigh more
+-- than bodies in the ranking (A > B). The text search configuration
+-- ('english') must match the one the repository queries with.
+CREATE OR REPLACE FUNCTION notes_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('english', coalesce(NEW.title, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(NEW.body, '')), 'B');
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER notes_search_vector_update
+    BEFORE INSERT OR UPDATE OF title, body ON notes
+    FOR EACH ROW EXECUTE FUNCTION notes_search_vector_update();
+
+-- Fills search_vector for the rows that exist already, through the trigger
+UPDATE notes SET title = title;
+
+CREATE INDEX IF NOT EXISTS idx_notes_search_vector ON notes USING GIN (search_vector);
+<!-- /IF EXAMPLE_NOTE --><!-- IF EXAMPLE_TODO -->ALTER TABLE todos ADD COLUMN IF NOT EXISTS search_vector tsvector;
+
+-- Keeps search_vector in step with the title it indexes. The text search
+-- configuration ('english') must match the one the repository queries with.
+CREATE OR REPLACE FUNCTION todos_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector := to_tsvector('english', coalesce(NEW.title, ''));
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER todos_search_vector_update
+    BEFORE INSERT OR UPDATE OF title ON todos
+    FOR EACH ROW EXECUTE FUNCTION todos_search_vector_update();
+
+-- Fills search_vector for the rows that exist already, through the trigger
+UPDATE todos SET title = title;
+
+CREATE INDEX IF NOT EXISTS idx_todos_search_vector ON todos USING GIN (search_vector);
+<!-- /IF EXAMPLE_TODO -->-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+<!-- IF EXAMPLE_NOTE -->DROP TRIGGER IF EXISTS notes_search_vector_update ON notes;
+DROP FUNCTION IF EXISTS notes_search_vector_update();
+ALTER TABLE notes DROP COLUMN IF EXISTS search_vector;
+<!-- /IF EXAMPLE_NOTE --><!-- IF EXAMPLE_TODO -->DROP TRIGGER IF EXISTS todos_search_vector_update ON todos;
+DROP FUNCTION IF EXISTS todos_search_vector_update();
+ALTER TABLE todos DROP COLUMN IF EXISTS search_vector;
+<!-- /IF EXAMPLE_TODO -->-- +goose StatementEnd
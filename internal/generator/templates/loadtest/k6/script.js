@@ -0,0 +1,43 @@
+// k6 load test for the example routes.
+// Run: make loadtest (LOADTEST_URL=http://localhost:8080 by default)
+// Docs: https://grafana.com/docs/k6/latest/
+import http from 'k6/http';
+import { check, group, sleep } from 'k6';
+
+const BASE_URL = __ENV.BASE_URL || 'http://localhost:8080<!-- BASE_PATH -->';
+
+export const options = {
+  stages: [
+    { duration: '10s', target: 20 }, // ramp up
+    { duration: '30s', target: 20 }, // steady load
+    { duration: '10s', target: 0 },  // ramp down
+  ],
+  // Failing thresholds make k6 exit non-zero (fails CI)
+  thresholds: {
+    http_req_failed: ['rate<0.01'],
+    http_req_duration: ['p(95)<300', 'p(99)<800'],
+    'http_req_duration{name:home}': ['p(95)<250'],
+  },
+};
+
+export default function () {
+  group('pages', () => {
+    const res = http.get(`${BASE_URL}/`, { tags: { name: 'home' } });
+    check(res, {
+      'home: status 200': (r) => r.status === 200,
+      'home: renders html': (r) => (r.body || '').includes('<html'),
+    });
+  });
+
+  group('api', () => {
+    const res = http.get(`${BASE_URL}/api/hello`, { tags: { name: 'api_hello' } });
+    check(res, { 'api: status 200': (r) => r.status === 200 });
+  });
+
+  group('health', () => {
+    const res = http.get(`${BASE_URL}/health`, { tags: { name: 'health' } });
+    check(res, { 'health: status 200': (r) => r.status === 200 });
+  });
+
+  sleep(1);
+}
@@ -0,0 +1,23 @@
+#!/bin/sh
+# Fails when a vegeta JSON report breaks the thresholds.
+# Usage: vegeta report -type=json results.bin | loadtest/vegeta/check.sh
+set -eu
+
+MAX_P95_MS="${MAX_P95_MS:-300}"
+MIN_SUCCESS="${MIN_SUCCESS:-0.99}"
+
+report=$(cat)
+p95_ms=$(echo "$report" | jq '.latencies["95th"] / 1000000')
+success=$(echo "$report" | jq '.success')
+
+echo "p95: ${p95_ms}ms (max ${MAX_P95_MS}ms), success: ${success} (min ${MIN_SUCCESS})"
+
+if [ "$(echo "$p95_ms > $MAX_P95_MS" | bc -l)" = "1" ]; then
+	echo "❌ p95 latency threshold exceeded"
+	exit 1
+fi
+if [ "$(echo "$success < $MIN_SUCCESS" | bc -l)" = "1" ]; then
+	echo "❌ success rate threshold not met"
+	exit 1
+fi
+echo "✅ Thresholds passed"
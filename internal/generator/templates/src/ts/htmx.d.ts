@@ -0,0 +1,69 @@
+// Type declarations for the global htmx object and its DOM events.
+// See https://htmx.org/reference/#events
+
+interface HtmxRequestConfig {
+  verb: string;
+  path: string;
+  headers: Record<string, string>;
+  parameters: Record<string, unknown>;
+  triggeringEvent: Event | null;
+}
+
+interface HtmxEventDetail {
+  elt: HTMLElement;
+}
+
+interface HtmxRequestDetail extends HtmxEventDetail {
+  xhr: XMLHttpRequest;
+  target: HTMLElement;
+  requestConfig: HtmxRequestConfig;
+}
+
+interface HtmxAfterRequestDetail extends HtmxRequestDetail {
+  successful: boolean;
+  failed: boolean;
+}
+
+interface HtmxSwapDetail extends HtmxRequestDetail {
+  shouldSwap: boolean;
+  isError: boolean;
+  serverResponse: string;
+}
+
+interface HtmxConfigRequestDetail extends HtmxEventDetail {
+  parameters: Record<string, unknown>;
+  headers: Record<string, string>;
+  verb: string;
+  path: string;
+  target: HTMLElement;
+}
+
+interface HtmxEventMap {
+  "htmx:load": CustomEvent<HtmxEventDetail>;
+  "htmx:configRequest": CustomEvent<HtmxConfigRequestDetail>;
+  "htmx:beforeRequest": CustomEvent<HtmxRequestDetail>;
+  "htmx:afterRequest": CustomEvent<HtmxAfterRequestDetail>;
+  "htmx:beforeSwap": CustomEvent<HtmxSwapDetail>;
+  "htmx:afterSwap": CustomEvent<HtmxRequestDetail>;
+  "htmx:afterSettle": CustomEvent<HtmxRequestDetail>;
+  "htmx:responseError": CustomEvent<HtmxRequestDetail>;
+  "htmx:sendError": CustomEvent<HtmxRequestDetail>;
+}
+
+interface DocumentEventMap extends HtmxEventMap {}
+interface HTMLElementEventMap extends HtmxEventMap {}
+
+interface Htmx {
+  version: string;
+  config: Record<string, unknown>;
+  process(elt: Element): void;
+  find(selector: string): Element | null;
+  findAll(selector: string): NodeListOf<Element>;
+  trigger(elt: Element | string, name: string, detail?: unknown): void;
+  ajax(verb: string, path: string, context?: Element | string | object): Promise<void>;
+  on<K extends keyof HtmxEventMap>(name: K, handler: (evt: HtmxEventMap[K]) => void): void;
+  off<K extends keyof HtmxEventMap>(name: K, handler: (evt: HtmxEventMap[K]) => void): void;
+  swap(target: Element | string, content: string, swapSpec: { swapStyle: string }): void;
+}
+
+declare const htmx: Htmx;
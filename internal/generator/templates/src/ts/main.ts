@@ -0,0 +1,28 @@
+// Application TypeScript, bundled by esbuild into assets/js/app.js.
+//
+// Keep page behaviour server-driven with HTMX; use this file for the small
+// amount of client-side glue that doesn't fit in hx-* attributes.
+
+// Show a loading indicator on the element that triggered a request.
+document.body.addEventListener("htmx:beforeRequest", (evt) => {
+  evt.detail.elt.setAttribute("aria-busy", "true");
+});
+
+document.body.addEventListener("htmx:afterRequest", (evt) => {
+  evt.detail.elt.removeAttribute("aria-busy");
+});
+
+// Surface failed requests instead of failing silently.
+document.body.addEventListener("htmx:responseError", (evt) => {
+  const { xhr, requestConfig } = evt.detail;
+  console.error(`Request to ${requestConfig.path} failed with status ${xhr.status}`);
+});
+
+// Re-run initialisation for content swapped in by HTMX.
+document.body.addEventListener("htmx:load", (evt) => {
+  init(evt.detail.elt);
+});
+
+function init(root: Element): void {
+  root.querySelectorAll<HTMLElement>("[data-autofocus]").forEach((el) => el.focus());
+}
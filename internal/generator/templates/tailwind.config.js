@@ -11,5 +11,5 @@ module.exports = {
             },
         },
     },
-    plugins: [<!-- TAILWIND_PLUGIN -->]<!-- DAISYUI_CONFIG -->
+    plugins: []
 }
@@ -0,0 +1,28 @@
+import { defineConfig } from "vite";
+
+// Vite builds the TypeScript islands in frontend/ into assets/static/vite,
+// which the Go server serves (and embeds) alongside the other static assets.
+// In development the Go server proxies Vite requests to the dev server, so
+// pages keep being served from a single origin.
+export default defineConfig(({ command }) => ({
+  base: command === "build" ? "<!-- BASE_PATH -->/assets/static/vite/" : "<!-- BASE_PATH -->/",
+  publicDir: false,
+  server: {
+    port: 5173,
+    strictPort: true,
+    origin: "http://localhost:8080",
+    hmr: {
+      path: "/@vite/hmr",
+    },
+  },
+  build: {
+    outDir: "assets/static/vite",
+    emptyOutDir: true,
+    manifest: "manifest.json",
+    rollupOptions: {
+      input: {
+        main: "frontend/src/main.ts",
+      },
+    },
+  },
+}));
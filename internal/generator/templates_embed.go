@@ -0,0 +1,31 @@
+//go:build !packed_templates
+
+package generator
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed all:templates
+var templateFS embed.FS
+
+// Templates returns the embedded templates, rooted at the template tree
+func Templates() fs.FS {
+	sub, err := fs.Sub(templateFS, "templates")
+	if err != nil {
+		panic(err) // the directory is embedded, so this cannot fail
+	}
+	return sub
+}
+
+// groupTemplates returns the templates of groups. This build embeds the
+// template tree as it is, so every group is in it.
+func groupTemplates(groups []string) (fs.FS, error) {
+	return Templates(), nil
+}
+
+// availableGroup reports whether this build has a template group
+func availableGroup(name string) bool {
+	return true
+}
@@ -0,0 +1,65 @@
+//go:build packed_templates
+
+package generator
+
+import (
+	"fmt"
+	"io/fs"
+	"sync"
+)
+
+// packedGroups holds the compressed template groups compiled in, registered
+// by the zz_packed_*.go files go generate writes
+var packedGroups = map[string][]byte{}
+
+var (
+	unpackMu sync.Mutex
+	// unpacked caches the groups unpacked so far
+	unpacked = map[string]memFS{}
+)
+
+// Templates returns the templates of every group compiled in, rooted at
+// the template tree
+func Templates() fs.FS {
+	var groups []string
+	for name := range packedGroups {
+		groups = append(groups, name)
+	}
+	tree, err := groupTemplates(groups)
+	if err != nil {
+		panic(err) // the archives are embedded, so this cannot fail
+	}
+	return tree
+}
+
+// groupTemplates unpacks groups, each only the first time it is needed,
+// into one template tree
+func groupTemplates(groups []string) (fs.FS, error) {
+	unpackMu.Lock()
+	defer unpackMu.Unlock()
+	tree := memFS{}
+	for _, name := range groups {
+		files, ok := unpacked[name]
+		if !ok {
+			archive, ok := packedGroups[name]
+			if !ok {
+				return nil, fmt.Errorf("this goforge was built without the %s templates", name)
+			}
+			files = memFS{}
+			if err := unpackGroup(archive, files); err != nil {
+				return nil, fmt.Errorf("%s templates: %w", name, err)
+			}
+			unpacked[name] = files
+		}
+		for p, f := range files {
+			tree[p] = f
+		}
+	}
+	return tree, nil
+}
+
+// availableGroup reports whether this build has a template group
+func availableGroup(name string) bool {
+	_, ok := packedGroups[name]
+	return ok
+}
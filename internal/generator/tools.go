@@ -0,0 +1,343 @@
+package generator
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ToolsLockFile records the development tools `goforge tools install` put in
+// ToolsBinDir, so every checkout installs the same versions
+const ToolsLockFile = "tools.lock"
+
+// ToolsBinDir is where project tools are installed, relative to the project;
+// the generated Makefile puts it first on PATH
+const ToolsBinDir = "bin"
+
+// tailwindDownload is the Tailwind CSS standalone CLI release asset URL:
+// version, then asset name. Each release also has a sha256sums.txt.
+const tailwindDownload = "https://github.com/tailwindlabs/tailwindcss/releases/download/%s/%s"
+
+// ToolPin is a tool at a fixed version: a Go package to go install, or a
+// release binary verified against per-platform SHA-256 checksums
+type ToolPin struct {
+	Name    string            `json:"name"`
+	Version string            `json:"version"`
+	Package string            `json:"package,omitempty"`
+	SHA256  map[string]string `json:"sha256,omitempty"`
+}
+
+// ToolsLock is the tools.lock document
+type ToolsLock struct {
+	Tools []ToolPin `json:"tools"`
+}
+
+// defaultToolPins are the versions goforge pins for a new tools.lock. templ
+// follows the project's go.mod instead, so the CLI matches the runtime.
+var defaultToolPins = map[string]ToolPin{
+	"templ":       {Name: "templ", Version: "v0.3.819", Package: "github.com/a-h/templ/cmd/templ"},
+	"air":         {Name: "air", Version: "v1.61.7", Package: "github.com/air-verse/air"},
+	"goose":       {Name: "goose", Version: "v3.24.1", Package: "github.com/pressly/goose/v3/cmd/goose"},
+	"sqlc":        {Name: "sqlc", Version: "v1.28.0", Package: "github.com/sqlc-dev/sqlc/cmd/sqlc"},
+	"tailwindcss": {Name: "tailwindcss", Version: "v4.1.4"},
+}
+
+// ToolNames lists the tools goforge can install
+func ToolNames() []string {
+	names := make([]string, 0, len(defaultToolPins))
+	for name := range defaultToolPins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Tools installs a project's pinned development tools
+type Tools struct {
+	Dir          string
+	Client       *http.Client
+	GOOS, GOARCH string
+	// TailwindURL formats a Tailwind CSS release asset URL from the version
+	// and asset name
+	TailwindURL string
+	// Mirror is a base URL downloads go through, as ParseAssetMirror returns
+	// (Go tools come through GOPROXY instead)
+	Mirror string
+	// GoInstall runs `go install pkg@version` with GOBIN set to bin
+	GoInstall func(dir, bin, pkgVersion string) error
+}
+
+// InstalledTool is a tool Install put (or found) in ToolsBinDir
+type InstalledTool struct {
+	ToolPin
+	Path string
+	// Current is set when the pinned version was already installed
+	Current bool
+}
+
+// NewTools returns an installer for the project in dir, for this platform
+func NewTools(dir string) *Tools {
+	return &Tools{
+		Dir:         dir,
+		Client:      &http.Client{Timeout: 5 * time.Minute},
+		GOOS:        runtime.GOOS,
+		GOARCH:      runtime.GOARCH,
+		TailwindURL: tailwindDownload,
+		GoInstall:   goInstall,
+	}
+}
+
+// Install installs the named tools, or the project's default set (templ,
+// air and tailwindcss, goose with a database, and everything already in
+// tools.lock), at the versions in tools.lock. Tools missing from the lock
+// get goforge's pins and are added to it. Tools whose pinned version is
+// already installed are left alone.
+func (t *Tools) Install(names []string) ([]InstalledTool, error) {
+	m, err := requireManifest(t.Dir)
+	if err != nil {
+		return nil, err
+	}
+	opts, _ := optionsFromManifest(m)
+	lock, err := LoadToolsLock(t.Dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		names = []string{"templ", "air", "tailwindcss"}
+		if hasDatabase(opts) {
+			names = append(names, "goose")
+		}
+		for _, pin := range lock.Tools {
+			if !containsString(names, pin.Name) {
+				names = append(names, pin.Name)
+			}
+		}
+	}
+
+	bin := filepath.Join(t.Dir, ToolsBinDir)
+	if err := os.MkdirAll(bin, 0755); err != nil {
+		return nil, err
+	}
+	var installed []InstalledTool
+	for _, name := range names {
+		pin, locked := lock.pin(name)
+		if !locked {
+			var ok bool
+			if pin, ok = defaultToolPins[name]; !ok {
+				return installed, fmt.Errorf("unknown tool %q (available: %s)", name, strings.Join(ToolNames(), ", "))
+			}
+			if name == "templ" {
+				if v := moduleVersion(t.Dir, "github.com/a-h/templ"); v != "" {
+					pin.Version = v
+				}
+			}
+		}
+		tool, err := t.install(pin, locked)
+		if err != nil {
+			return installed, fmt.Errorf("%s %s: %w", name, pin.Version, err)
+		}
+		lock.set(tool.ToolPin)
+		if err := lock.Save(t.Dir); err != nil {
+			return installed, err
+		}
+		installed = append(installed, tool)
+	}
+	return installed, nil
+}
+
+// install puts pin in ToolsBinDir. A binary already there is current when
+// the pin came from tools.lock, which records what was installed.
+func (t *Tools) install(pin ToolPin, locked bool) (InstalledTool, error) {
+	exe := ""
+	if t.GOOS == "windows" {
+		exe = ".exe"
+	}
+	path := filepath.Join(t.Dir, ToolsBinDir, pin.Name+exe)
+	tool := InstalledTool{ToolPin: pin, Path: path}
+	if _, err := os.Stat(path); err == nil && locked {
+		tool.Current = true
+		return tool, nil
+	}
+	if pin.Package != "" {
+		bin, err := filepath.Abs(filepath.Dir(path))
+		if err != nil {
+			return tool, err
+		}
+		return tool, t.GoInstall(t.Dir, bin, pin.Package+"@"+pin.Version)
+	}
+	if pin.Name != "tailwindcss" {
+		return tool, fmt.Errorf("no package or download for %s in %s", pin.Name, ToolsLockFile)
+	}
+	sum, err := t.downloadTailwind(pin, path)
+	if err != nil {
+		return tool, err
+	}
+	tool.SHA256 = map[string]string{}
+	for platform, s := range pin.SHA256 {
+		tool.SHA256[platform] = s
+	}
+	tool.SHA256[t.GOOS+"/"+t.GOARCH] = sum
+	return tool, nil
+}
+
+// downloadTailwind fetches the standalone CLI for this platform to path. The
+// binary must match the checksum in tools.lock when it has one for the
+// platform, and the release's sha256sums.txt otherwise.
+func (t *Tools) downloadTailwind(pin ToolPin, path string) (string, error) {
+	asset, err := tailwindAsset(t.GOOS, t.GOARCH)
+	if err != nil {
+		return "", err
+	}
+	want := pin.SHA256[t.GOOS+"/"+t.GOARCH]
+	if want == "" {
+		sums, err := t.get(mirrorURL(t.Mirror, fmt.Sprintf(t.TailwindURL, pin.Version, "sha256sums.txt")))
+		if err != nil {
+			return "", err
+		}
+		if want = checksumFor(sums, asset); want == "" {
+			return "", fmt.Errorf("sha256sums.txt has no checksum for %s", asset)
+		}
+	}
+	data, err := t.get(mirrorURL(t.Mirror, fmt.Sprintf(t.TailwindURL, pin.Version, asset)))
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	got := hex.EncodeToString(sum[:])
+	if !strings.EqualFold(got, want) {
+		return "", fmt.Errorf("%s: checksum mismatch (expected %s, downloaded %s)", asset, want, got)
+	}
+	return got, os.WriteFile(path, data, 0755)
+}
+
+func (t *Tools) get(url string) ([]byte, error) {
+	return fetch(t.Client, url)
+}
+
+// fetch downloads url, up to 256 MiB
+func fetch(client *http.Client, url string) ([]byte, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", url, resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, 256<<20))
+}
+
+// tailwindAsset names the standalone CLI release asset for a platform
+func tailwindAsset(goos, goarch string) (string, error) {
+	arch := map[string]string{"amd64": "x64", "arm64": "arm64"}[goarch]
+	osName := map[string]string{"linux": "linux", "darwin": "macos", "windows": "windows"}[goos]
+	if arch == "" || osName == "" {
+		return "", fmt.Errorf("no Tailwind CSS standalone build for %s/%s", goos, goarch)
+	}
+	asset := "tailwindcss-" + osName + "-" + arch
+	if goos == "windows" {
+		asset += ".exe"
+	}
+	return asset, nil
+}
+
+// checksumFor finds name in sha256sum output ("<hex>  [*./]name" lines)
+func checksumFor(sums []byte, name string) string {
+	scanner := bufio.NewScanner(bytes.NewReader(sums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimLeft(fields[1], "*./") == name {
+			return fields[0]
+		}
+	}
+	return ""
+}
+
+func goInstall(dir, bin, pkgVersion string) error {
+	cmd := exec.Command("go", "install", pkgVersion)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOBIN="+bin)
+	cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+	return cmd.Run()
+}
+
+// moduleVersion is the version go.mod in dir requires of module, or ""
+func moduleVersion(dir, module string) string {
+	data, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return ""
+	}
+	re := regexp.MustCompile(`(?m)^\s*(?:require\s+)?` + regexp.QuoteMeta(module) + `\s+(v\S+)`)
+	if m := re.FindSubmatch(data); m != nil {
+		return string(m[1])
+	}
+	return ""
+}
+
+// LoadToolsLock reads tools.lock in dir; a missing file is an empty lock
+func LoadToolsLock(dir string) (*ToolsLock, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ToolsLockFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return &ToolsLock{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var lock ToolsLock
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("%s: %w", ToolsLockFile, err)
+	}
+	return &lock, nil
+}
+
+// Save writes the lock to dir, tools sorted by name
+func (l *ToolsLock) Save(dir string) error {
+	sort.Slice(l.Tools, func(i, j int) bool { return l.Tools[i].Name < l.Tools[j].Name })
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, ToolsLockFile), append(data, '\n'), 0644)
+}
+
+func (l *ToolsLock) pin(name string) (ToolPin, bool) {
+	for _, p := range l.Tools {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return ToolPin{}, false
+}
+
+func (l *ToolsLock) set(pin ToolPin) {
+	for i := range l.Tools {
+		if l.Tools[i].Name == pin.Name {
+			l.Tools[i] = pin
+			return
+		}
+	}
+	l.Tools = append(l.Tools, pin)
+}
+
+// dockerTailwindRun is the Dockerfile RUN fetching the pinned Tailwind CSS
+// standalone CLI for the Alpine builder, through $ASSET_MIRROR when set
+func dockerTailwindRun() string {
+	asset := `tailwindcss-linux-${arch}-musl`
+	return "RUN arch=$(uname -m | sed 's/x86_64/x64/;s/aarch64/arm64/') && \\\n" +
+		"    curl -sfL -o tailwindcss " + dockerAssetURL(fmt.Sprintf(tailwindDownload, defaultToolPins["tailwindcss"].Version, asset)) + " && \\\n" +
+		"    chmod +x tailwindcss"
+}
@@ -0,0 +1,96 @@
+package generator
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInstallTools(t *testing.T) {
+	projectDir := generateProject(t, Options{IncludeDB: true})
+	binary := []byte("tailwind binary")
+
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	mux.HandleFunc("/v4.1.4/sha256sums.txt", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s  ./tailwindcss-linux-x64\n%s  ./tailwindcss-macos-arm64\n", sha256Hex(binary), sha256Hex([]byte("other")))
+	})
+	mux.HandleFunc("/v4.1.4/tailwindcss-linux-x64", func(w http.ResponseWriter, r *http.Request) { w.Write(binary) })
+
+	var goInstalls []string
+	tools := &Tools{
+		Dir: projectDir, Client: srv.Client(), GOOS: "linux", GOARCH: "amd64",
+		TailwindURL: srv.URL + "/%s/%s",
+		GoInstall: func(dir, bin, pkgVersion string) error {
+			goInstalls = append(goInstalls, pkgVersion)
+			name := filepath.Base(strings.Split(pkgVersion, "@")[0])
+			return os.WriteFile(filepath.Join(bin, name), nil, 0755)
+		},
+	}
+
+	installed, err := tools.Install(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(installed) != 4 {
+		t.Errorf("Install() = %+v, want templ, air, tailwindcss and goose", installed)
+	}
+	templVersion := moduleVersion(projectDir, "github.com/a-h/templ")
+	if templVersion == "" || !containsString(goInstalls, "github.com/a-h/templ/cmd/templ@"+templVersion) {
+		t.Errorf("templ not pinned to go.mod's %q: %v", templVersion, goInstalls)
+	}
+	assertFilesExist(t, projectDir, "bin/tailwindcss", "bin/air", "bin/goose", "bin/templ")
+
+	lock, err := LoadToolsLock(projectDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lock.Tools) != 4 || lock.Tools[0].Name != "air" {
+		t.Errorf("tools.lock = %+v", lock.Tools)
+	}
+	if pin, _ := lock.pin("tailwindcss"); pin.SHA256["linux/amd64"] != sha256Hex(binary) {
+		t.Errorf("tools.lock tailwindcss checksums = %v", pin.SHA256)
+	}
+
+	// A second run installs nothing; a changed download fails its locked checksum
+	goInstalls = nil
+	if installed, err = tools.Install(nil); err != nil {
+		t.Fatal(err)
+	}
+	for _, tool := range installed {
+		if !tool.Current {
+			t.Errorf("%s reinstalled", tool.Name)
+		}
+	}
+	os.Remove(filepath.Join(projectDir, "bin", "tailwindcss"))
+	binary = []byte("tampered")
+	if _, err := tools.Install([]string{"tailwindcss"}); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("Install() of a changed download: %v", err)
+	}
+
+	if _, err := tools.Install([]string{"make"}); err == nil {
+		t.Error("Install() of an unknown tool succeeded")
+	}
+
+	// Through a mirror, the upstream URL's host and path follow the mirror base
+	mux.HandleFunc("/mirror/github.com/tailwindlabs/tailwindcss/releases/download/v4.1.4/tailwindcss-linux-x64", func(w http.ResponseWriter, r *http.Request) { w.Write([]byte("tampered")) })
+	tools.TailwindURL, tools.Mirror = tailwindDownload, srv.URL+"/mirror"
+	lock.set(ToolPin{Name: "tailwindcss", Version: "v4.1.4", SHA256: map[string]string{"linux/amd64": sha256Hex([]byte("tampered"))}})
+	if err := lock.Save(projectDir); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tools.Install([]string{"tailwindcss"}); err != nil {
+		t.Errorf("Install() through a mirror: %v", err)
+	}
+
+	os.Remove(filepath.Join(projectDir, "bin", "tailwindcss"))
+	tools.GOOS = "plan9"
+	if _, err := tools.Install([]string{"tailwindcss"}); err == nil {
+		t.Error("Install() of tailwindcss on an unsupported platform succeeded")
+	}
+}
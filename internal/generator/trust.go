@@ -0,0 +1,155 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/FACorreiaa/goforge/internal/manifest"
+	"github.com/FACorreiaa/goforge/internal/minisign"
+)
+
+// TrustPolicyFile is the trust policy's name in goforge's user config
+// directory
+const TrustPolicyFile = "trust.yaml"
+
+// Unsigned bundle policies. With neither set, unsigned bundles are refused
+// in CI and installed with a warning elsewhere.
+const (
+	UnsignedAllow  = "allow"
+	UnsignedRefuse = "refuse"
+)
+
+// TrustPolicy decides which template bundles goforge installs: bundles
+// signed by one of Keys always are, bundles whose signature does not verify
+// never are, and Unsigned covers the rest (unsigned, or signed by a key not
+// in Keys)
+type TrustPolicy struct {
+	// Keys maps a name for each trusted signer to their minisign public key
+	Keys     map[string]*minisign.PublicKey
+	Unsigned string
+}
+
+// Verification is the outcome of checking a bundle against a TrustPolicy
+type Verification struct {
+	// Signer names the trusted key that signed the bundle; empty when it is
+	// unsigned or signed by an untrusted key
+	Signer         string
+	KeyID          string
+	TrustedComment string
+	// Warning explains why an unverified bundle was let through
+	Warning string
+}
+
+// TrustPolicyPath is where the trust policy lives
+func TrustPolicyPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "goforge", TrustPolicyFile), nil
+}
+
+// LoadTrustPolicy reads a trust policy; a missing file is an empty policy
+func LoadTrustPolicy(path string) (*TrustPolicy, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &TrustPolicy{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	policy, err := parseTrustPolicy(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return policy, nil
+}
+
+func parseTrustPolicy(src string) (*TrustPolicy, error) {
+	doc, err := manifest.ParseDocument(src)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range doc.Keys() {
+		if key != "keys" && key != "unsigned" {
+			return nil, fmt.Errorf("unknown key %q", key)
+		}
+	}
+	policy := &TrustPolicy{Keys: map[string]*minisign.PublicKey{}}
+	if policy.Unsigned, err = doc.String("unsigned"); err != nil {
+		return nil, err
+	}
+	if policy.Unsigned != "" && policy.Unsigned != UnsignedAllow && policy.Unsigned != UnsignedRefuse {
+		return nil, fmt.Errorf("unsigned must be %s or %s, not %q", UnsignedAllow, UnsignedRefuse, policy.Unsigned)
+	}
+	keys, err := doc.Strings("keys")
+	if err != nil {
+		return nil, err
+	}
+	for name, text := range keys {
+		key, err := minisign.ParsePublicKey(text)
+		if err != nil {
+			return nil, fmt.Errorf("keys.%s: %w", name, err)
+		}
+		policy.Keys[name] = key
+	}
+	return policy, nil
+}
+
+// InCI reports whether goforge runs in CI, going by the CI variable that
+// GitHub Actions, GitLab CI and most other providers set
+func InCI() bool {
+	v := strings.ToLower(os.Getenv("CI"))
+	return v != "" && v != "false" && v != "0"
+}
+
+// Verify checks archive against its minisign signature (empty when the
+// bundle is unsigned). An error means the bundle must not be installed.
+func (p *TrustPolicy) Verify(archive []byte, signature string, ci bool) (*Verification, error) {
+	if signature == "" {
+		return p.untrusted(ci, "bundle is unsigned")
+	}
+	sig, err := minisign.ParseSignature(signature)
+	if err != nil {
+		return nil, fmt.Errorf("signature: %w", err)
+	}
+	for _, name := range p.keyNames() {
+		key := p.Keys[name]
+		if key.ID != sig.KeyID {
+			continue
+		}
+		if err := key.Verify(archive, sig); err != nil {
+			return nil, fmt.Errorf("signature by %s (%s) does not verify: %w", name, key.ID, err)
+		}
+		return &Verification{Signer: name, KeyID: key.ID, TrustedComment: sig.TrustedComment}, nil
+	}
+	v, err := p.untrusted(ci, "bundle is signed by key "+sig.KeyID+", which the trust policy does not list")
+	if v != nil {
+		v.KeyID = sig.KeyID
+	}
+	return v, err
+}
+
+// untrusted applies the policy for bundles no trusted key vouches for
+func (p *TrustPolicy) untrusted(ci bool, reason string) (*Verification, error) {
+	refuse := p.Unsigned == UnsignedRefuse || (p.Unsigned == "" && ci)
+	if refuse {
+		if p.Unsigned == "" {
+			return nil, fmt.Errorf("%s; unverified bundles are refused in CI (set unsigned: allow in %s to install them)", reason, TrustPolicyFile)
+		}
+		return nil, fmt.Errorf("%s; the trust policy refuses unverified bundles", reason)
+	}
+	return &Verification{Warning: reason}, nil
+}
+
+func (p *TrustPolicy) keyNames() []string {
+	names := make([]string, 0, len(p.Keys))
+	for name := range p.Keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
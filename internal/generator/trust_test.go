@@ -0,0 +1,123 @@
+package generator
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// signer is a minisign key pair for tests; it signs with the legacy "Ed"
+// algorithm, which minisign still verifies
+type signer struct {
+	priv ed25519.PrivateKey
+	id   [8]byte
+	pub  string
+}
+
+func newSigner(t *testing.T, id byte) signer {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := signer{priv: priv, id: [8]byte{id}}
+	s.pub = base64.StdEncoding.EncodeToString(append(append([]byte("Ed"), s.id[:]...), pub...))
+	return s
+}
+
+func (s signer) sign(data []byte) string {
+	sig := ed25519.Sign(s.priv, data)
+	comment := "timestamp:1700000000 file:bundle.tar.gz"
+	global := ed25519.Sign(s.priv, append(append([]byte{}, sig...), comment...))
+	return "untrusted comment: signature from minisign secret key\n" +
+		base64.StdEncoding.EncodeToString(append(append([]byte("Ed"), s.id[:]...), sig...)) + "\n" +
+		"trusted comment: " + comment + "\n" +
+		base64.StdEncoding.EncodeToString(global) + "\n"
+}
+
+func TestTrustPolicy(t *testing.T) {
+	alice, mallory := newSigner(t, 1), newSigner(t, 2)
+	policy, err := parseTrustPolicy("keys:\n  alice: " + alice.pub + "\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	archive := []byte("bundle archive")
+
+	v, err := policy.Verify(archive, alice.sign(archive), true)
+	if err != nil || v.Signer != "alice" || v.Warning != "" {
+		t.Errorf("Verify() trusted signature = %+v, %v", v, err)
+	}
+	if _, err := policy.Verify([]byte("tampered"), alice.sign(archive), false); err == nil {
+		t.Error("Verify() accepted a bad signature by a trusted key outside CI")
+	}
+
+	for _, tc := range []struct {
+		name, unsigned, signature string
+		ci, refused               bool
+	}{
+		{"unsigned locally", "", "", false, false},
+		{"unsigned in CI", "", "", true, true},
+		{"untrusted key in CI", "", mallory.sign(archive), true, true},
+		{"untrusted key locally", "", mallory.sign(archive), false, false},
+		{"unsigned, refuse", UnsignedRefuse, "", false, true},
+		{"unsigned in CI, allow", UnsignedAllow, "", true, false},
+	} {
+		policy.Unsigned = tc.unsigned
+		v, err := policy.Verify(archive, tc.signature, tc.ci)
+		if tc.refused != (err != nil) {
+			t.Errorf("%s: Verify() = %+v, %v", tc.name, v, err)
+		}
+		if err == nil && v.Warning == "" {
+			t.Errorf("%s: unverified bundle let through without a warning", tc.name)
+		}
+	}
+
+	for _, bad := range []string{"unsigned: sometimes\n", "keys:\n  bob: nope\n", "trusted: yes\n"} {
+		if _, err := parseTrustPolicy(bad); err == nil {
+			t.Errorf("parseTrustPolicy(%q) succeeded", bad)
+		}
+	}
+	if p, err := LoadTrustPolicy(filepath.Join(t.TempDir(), TrustPolicyFile)); err != nil || len(p.Keys) != 0 {
+		t.Errorf("LoadTrustPolicy() without a file = %+v, %v", p, err)
+	}
+}
+
+func TestRegistryAddVerifiesSignatures(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "api-stack")
+	if _, err := NewBundle(src, "api-stack", "JSON API stack"); err != nil {
+		t.Fatal(err)
+	}
+	archive := bundleArchive(t, src, "")
+	alice := newSigner(t, 1)
+
+	mux := http.NewServeMux()
+	srv := httptest.NewTLSServer(mux)
+	defer srv.Close()
+	index := `{"bundles": [{"name": "api-stack", "versions": [
+		{"version": "1.0.0", "url": "` + srv.URL + `/b.tar.gz", "sha256": "` + sha256Hex(archive) + `", "signature": "` + srv.URL + `/b.tar.gz.minisig"},
+		{"version": "0.9.0", "url": "` + srv.URL + `/b.tar.gz", "sha256": "` + sha256Hex(archive) + `"}]}]}`
+	mux.HandleFunc("/index.json", func(w http.ResponseWriter, r *http.Request) { w.Write([]byte(index)) })
+	mux.HandleFunc("/b.tar.gz", func(w http.ResponseWriter, r *http.Request) { w.Write(archive) })
+	mux.HandleFunc("/b.tar.gz.minisig", func(w http.ResponseWriter, r *http.Request) { w.Write([]byte(alice.sign(archive))) })
+
+	policy, err := parseTrustPolicy("keys:\n  alice: " + alice.pub + "\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry := &Registry{URL: srv.URL + "/index.json", Client: srv.Client(), Trust: policy, CI: true}
+	root := t.TempDir()
+	installed, err := registry.Add("api-stack@1.0.0", root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if installed.Verification.Signer != "alice" {
+		t.Errorf("Add() verification = %+v", installed.Verification)
+	}
+	if _, err := registry.Add("api-stack@0.9.0", root); err == nil || !strings.Contains(err.Error(), "unsigned") {
+		t.Errorf("Add() of an unsigned bundle in CI: %v", err)
+	}
+}
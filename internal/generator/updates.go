@@ -0,0 +1,409 @@
+package generator
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// npmRegistry is where check-updates looks up the latest frontend library
+// and Tailwind CSS releases
+const npmRegistry = "https://registry.npmjs.org"
+
+// Kinds of pinned dependency check-updates looks at
+const (
+	UpdateFrontend  = "frontend"
+	UpdateTool      = "tool"
+	UpdateGo        = "go"
+	UpdateTemplates = "templates"
+)
+
+// UpdateKinds lists the dependency kinds in report order
+func UpdateKinds() []string {
+	return []string{UpdateFrontend, UpdateTool, UpdateGo, UpdateTemplates}
+}
+
+// releaseNPMPackages maps the GitHub projects whose release downloads goforge
+// pins to the npm package they publish the same versions as
+var releaseNPMPackages = map[string]string{
+	"saadeghi/daisyui":         "daisyui",
+	"tailwindlabs/tailwindcss": "tailwindcss",
+}
+
+// pinnedVersionRe finds the version in an asset URL: after @ on a CDN, or
+// the tag of a GitHub release download
+var pinnedVersionRe = regexp.MustCompile(`(@|/releases/download/v)([0-9][^/]*)`)
+
+// tailwindPinRe finds the Tailwind CSS release the Dockerfile downloads
+var tailwindPinRe = regexp.MustCompile(`tailwindcss/releases/download/(v[0-9][^/\s"]*)/`)
+
+// pinFileNames are the project files that pin frontend and tool versions
+var pinFileNames = []string{"Makefile", "Dockerfile", AssetChecksumsFile}
+
+// DependencyUpdate is a pinned dependency with a newer release
+type DependencyUpdate struct {
+	Kind    string
+	Name    string
+	Current string
+	Latest  string
+}
+
+// UpdateReport is what `goforge check-updates` found and, when applying,
+// changed
+type UpdateReport struct {
+	Updates []DependencyUpdate
+	// Failed maps the kinds that could not be checked to the reason
+	Failed map[string]error
+	// Changed lists the files rewritten, relative to the project
+	Changed []string
+}
+
+// UpdateChecker looks for newer versions of a project's pinned frontend
+// libraries, Tailwind CSS, Go modules and template bundle
+type UpdateChecker struct {
+	Dir    string
+	Client *http.Client
+	// Registry is the npm registry base URL
+	Registry string
+	// Mirror is a base URL new asset versions are downloaded through to
+	// checksum them, as ParseAssetMirror returns
+	Mirror string
+	// GoList runs `go list -m -u -json all` in dir
+	GoList func(dir string) ([]byte, error)
+	// GoGet runs `go get` with module@version arguments in dir, then
+	// `go mod tidy`
+	GoGet func(dir string, modules []string) error
+}
+
+// NewUpdateChecker returns a checker for the project in dir
+func NewUpdateChecker(dir string) *UpdateChecker {
+	return &UpdateChecker{
+		Dir:      dir,
+		Client:   &http.Client{Timeout: time.Minute},
+		Registry: npmRegistry,
+		GoList:   goListUpdates,
+		GoGet:    goGet,
+	}
+}
+
+// Check reports the pinned dependencies that have a newer release. With
+// apply it also bumps them: the Makefile and Dockerfile download URLs and
+// assets.sha256 (with the new files' checksums), tools.lock and go.mod. A
+// newer template bundle is only reported; `goforge upgrade` applies it. The
+// rewritten files keep their manifest checksums, so an upgrade treats them
+// as edited instead of overwriting the new pins.
+func (c *UpdateChecker) Check(apply bool) (*UpdateReport, error) {
+	m, err := requireManifest(c.Dir)
+	if err != nil {
+		return nil, err
+	}
+	opts, _ := optionsFromManifest(m)
+	files, err := loadPinFiles(c.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &UpdateReport{Failed: map[string]error{}}
+	for _, check := range []struct {
+		kind string
+		run  func() error
+	}{
+		{UpdateFrontend, func() error { return c.frontend(opts, files, report, apply) }},
+		{UpdateTool, func() error { return c.tailwind(files, report, apply) }},
+		{UpdateGo, func() error { return c.goModules(report, apply) }},
+	} {
+		if err := check.run(); err != nil {
+			report.Failed[check.kind] = err
+		}
+	}
+
+	channel := m.Template.Channel
+	if channel == "" {
+		channel = ChannelStable
+	}
+	if latest, ok := bundleVersions[channel]; ok && m.Template.Version != "" && compareVersions(m.Template.Version, latest) < 0 {
+		report.Updates = append(report.Updates, DependencyUpdate{UpdateTemplates, "bundle (" + channel + ")", m.Template.Version, latest})
+	}
+
+	if apply {
+		changed, err := files.save()
+		if err != nil {
+			return report, err
+		}
+		report.Changed = append(report.Changed, changed...)
+		sort.Strings(report.Changed)
+	}
+	return report, nil
+}
+
+// projectPin is a frontend asset as the project pins it
+type projectPin struct {
+	asset frontendAsset
+	// prefix and suffix surround the version in the URL, without the scheme
+	prefix, suffix string
+	version        string
+}
+
+func (p projectPin) url(version string) string {
+	return "https://" + p.prefix + version + p.suffix
+}
+
+// frontend checks the libraries make setup downloads, as pinned in the
+// Makefile, against npm
+func (c *UpdateChecker) frontend(opts Options, files *pinFiles, report *UpdateReport, apply bool) error {
+	pins := map[string][]projectPin{}
+	var packages []string
+	for _, a := range projectAssets(opts) {
+		loc := pinnedVersionRe.FindStringSubmatchIndex(a.URL)
+		if a.floating() || loc == nil {
+			continue
+		}
+		pin := projectPin{asset: a, prefix: strings.TrimPrefix(a.URL[:loc[4]], "https://"), suffix: a.URL[loc[5]:]}
+		re := regexp.MustCompile(regexp.QuoteMeta(pin.prefix) + `([0-9][^/\s"]*)` + regexp.QuoteMeta(pin.suffix))
+		m := re.FindStringSubmatch(files.content["Makefile"])
+		if m == nil {
+			continue // Removed from the project
+		}
+		pin.version = m[1]
+		pkg := npmPackage(pin.prefix)
+		if pkg == "" {
+			continue
+		}
+		if _, ok := pins[pkg]; !ok {
+			packages = append(packages, pkg)
+		}
+		pins[pkg] = append(pins[pkg], pin)
+	}
+
+	for _, pkg := range packages {
+		current := pins[pkg][0].version
+		latest, err := c.latestNPM(pkg)
+		if err != nil {
+			return err
+		}
+		if compareVersions(current, latest) >= 0 {
+			continue
+		}
+		report.Updates = append(report.Updates, DependencyUpdate{UpdateFrontend, pkg, current, latest})
+		if !apply {
+			continue
+		}
+
+		// Download every file first, so a failure leaves the package untouched
+		sums := map[string]string{}
+		for _, pin := range pins[pkg] {
+			data, err := fetch(c.Client, mirrorURL(c.Mirror, pin.url(latest)))
+			if err != nil {
+				return err
+			}
+			sum := sha256.Sum256(data)
+			sums[pin.asset.Path] = hex.EncodeToString(sum[:])
+		}
+		for _, pin := range pins[pkg] {
+			files.replace(pin.prefix+pin.version+pin.suffix, pin.prefix+latest+pin.suffix)
+			if old := checksumFor([]byte(files.content[AssetChecksumsFile]), pin.asset.Path); old != "" {
+				// assets.sha256 and the Dockerfile's sha256sum -c lines
+				files.replace(old+"  "+pin.asset.Path, sums[pin.asset.Path]+"  "+pin.asset.Path)
+			}
+		}
+		if pkg == "daisyui" {
+			files.replace("DaisyUI "+current, "DaisyUI "+latest) // make setup's message
+		}
+	}
+	return nil
+}
+
+// npmPackage names the npm package of an asset URL prefix (the URL without
+// scheme, up to the version), or "" when it is not on npm
+func npmPackage(prefix string) string {
+	if repo, ok := strings.CutSuffix(prefix, "/releases/download/v"); ok {
+		return releaseNPMPackages[strings.TrimPrefix(repo, "github.com/")]
+	}
+	for _, cdn := range []string{"unpkg.com/", "cdn.jsdelivr.net/npm/"} {
+		if name, ok := strings.CutPrefix(prefix, cdn); ok {
+			return strings.TrimSuffix(name, "@")
+		}
+	}
+	return ""
+}
+
+// tailwind checks the Tailwind CSS standalone CLI pinned in tools.lock (or,
+// before `goforge tools install` ran, the Dockerfile)
+func (c *UpdateChecker) tailwind(files *pinFiles, report *UpdateReport, apply bool) error {
+	lock, err := LoadToolsLock(c.Dir)
+	if err != nil {
+		return err
+	}
+	pin, locked := lock.pin("tailwindcss")
+	docker := tailwindPinRe.FindStringSubmatch(files.content["Dockerfile"])
+	current := pin.Version
+	if !locked && docker != nil {
+		current = docker[1]
+	}
+	if current == "" {
+		return nil
+	}
+	latest, err := c.latestNPM("tailwindcss")
+	if err != nil {
+		return err
+	}
+	latest = "v" + latest
+	if compareVersions(current, latest) >= 0 {
+		return nil
+	}
+	report.Updates = append(report.Updates, DependencyUpdate{UpdateTool, "tailwindcss", current, latest})
+	if !apply {
+		return nil
+	}
+
+	if docker != nil {
+		files.replace(docker[0], "tailwindcss/releases/download/"+latest+"/")
+	}
+	if locked {
+		// tools install checks the new binary against the release's
+		// sha256sums.txt and records its checksums again
+		pin.Version, pin.SHA256 = latest, nil
+		lock.set(pin)
+		if err := lock.Save(c.Dir); err != nil {
+			return err
+		}
+		report.Changed = append(report.Changed, ToolsLockFile)
+	}
+	return nil
+}
+
+// goModules checks the modules go.mod requires directly
+func (c *UpdateChecker) goModules(report *UpdateReport, apply bool) error {
+	out, err := c.GoList(c.Dir)
+	if err != nil {
+		return err
+	}
+	var get []string
+	dec := json.NewDecoder(bytes.NewReader(out))
+	for {
+		var mod struct {
+			Path, Version  string
+			Main, Indirect bool
+			Update         *struct{ Version string }
+		}
+		if err := dec.Decode(&mod); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return fmt.Errorf("go list: %w", err)
+		}
+		if mod.Main || mod.Indirect || mod.Update == nil {
+			continue
+		}
+		report.Updates = append(report.Updates, DependencyUpdate{UpdateGo, mod.Path, mod.Version, mod.Update.Version})
+		get = append(get, mod.Path+"@"+mod.Update.Version)
+	}
+	if !apply || len(get) == 0 {
+		return nil
+	}
+	if err := c.GoGet(c.Dir, get); err != nil {
+		return err
+	}
+	report.Changed = append(report.Changed, "go.mod")
+	if _, err := os.Stat(filepath.Join(c.Dir, "go.sum")); err == nil {
+		report.Changed = append(report.Changed, "go.sum")
+	}
+	return nil
+}
+
+// latestNPM is the version npm's latest tag points at for pkg
+func (c *UpdateChecker) latestNPM(pkg string) (string, error) {
+	data, err := fetch(c.Client, c.Registry+"/"+strings.ReplaceAll(pkg, "/", "%2F")+"/latest")
+	if err != nil {
+		return "", err
+	}
+	var doc struct {
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil || doc.Version == "" {
+		return "", fmt.Errorf("npm registry: no latest version of %s", pkg)
+	}
+	return doc.Version, nil
+}
+
+func goListUpdates(dir string) ([]byte, error) {
+	cmd := exec.Command("go", "list", "-m", "-u", "-json", "all")
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("go list -m -u: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+func goGet(dir string, modules []string) error {
+	for _, args := range [][]string{append([]string{"get"}, modules...), {"mod", "tidy"}} {
+		cmd := exec.Command("go", args...)
+		cmd.Dir = dir
+		cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("go %s: %w", args[0], err)
+		}
+	}
+	return nil
+}
+
+// pinFiles holds the project files that pin versions while they are
+// rewritten; save writes back the ones that changed
+type pinFiles struct {
+	dir     string
+	content map[string]string
+	changed map[string]bool
+}
+
+func loadPinFiles(dir string) (*pinFiles, error) {
+	files := &pinFiles{dir: dir, content: map[string]string{}, changed: map[string]bool{}}
+	for _, name := range pinFileNames {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		files.content[name] = string(data)
+	}
+	return files, nil
+}
+
+// replace substitutes new for every old in every file
+func (f *pinFiles) replace(old, new string) {
+	for name, content := range f.content {
+		if strings.Contains(content, old) {
+			f.content[name] = strings.ReplaceAll(content, old, new)
+			f.changed[name] = true
+		}
+	}
+}
+
+func (f *pinFiles) save() ([]string, error) {
+	var saved []string
+	for _, name := range pinFileNames {
+		if !f.changed[name] {
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(f.dir, name), []byte(f.content[name]), 0644); err != nil {
+			return saved, err
+		}
+		saved = append(saved, name)
+	}
+	return saved, nil
+}
@@ -0,0 +1,136 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/FACorreiaa/goforge/internal/manifest"
+)
+
+func TestCheckUpdates(t *testing.T) {
+	projectDir := generateProject(t, Options{Frontend: FrontendHTMXAlpine})
+	m, err := manifest.Load(projectDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.Template.Version = "0.9.0"
+	if err := m.Save(projectDir); err != nil {
+		t.Fatal(err)
+	}
+	lock := &ToolsLock{Tools: []ToolPin{{Name: "tailwindcss", Version: "v4.1.4", SHA256: map[string]string{"linux/amd64": "old"}}}}
+	if err := lock.Save(projectDir); err != nil {
+		t.Fatal(err)
+	}
+
+	oldSum := sha256Hex([]byte("htmx 2.0.4"))
+	if err := os.WriteFile(filepath.Join(projectDir, AssetChecksumsFile), []byte(oldSum+"  assets/js/htmx.min.js\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	latest := map[string]string{"htmx.org": "2.0.6", "alpinejs": "3.14.8", "daisyui": "5.1.0", "tailwindcss": "4.1.11"}
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	mux.HandleFunc("/registry/", func(w http.ResponseWriter, r *http.Request) {
+		pkg := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/registry/"), "/latest")
+		json.NewEncoder(w).Encode(map[string]string{"version": latest[pkg]})
+	})
+	// The asset mirror serves the new versions
+	mux.HandleFunc("/mirror/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, strings.TrimPrefix(r.URL.Path, "/mirror/"))
+	})
+
+	var got []string
+	checker := &UpdateChecker{
+		Dir: projectDir, Client: srv.Client(), Registry: srv.URL + "/registry", Mirror: srv.URL + "/mirror",
+		GoList: func(dir string) ([]byte, error) {
+			return []byte(`{"Path": "github.com/test/app", "Main": true}
+{"Path": "github.com/go-chi/chi/v5", "Version": "v5.2.1", "Update": {"Version": "v5.2.2"}}
+{"Path": "golang.org/x/text", "Version": "v0.21.0", "Indirect": true, "Update": {"Version": "v0.22.0"}}
+{"Path": "github.com/a-h/templ", "Version": "v0.3.819"}
+`), nil
+		},
+		GoGet: func(dir string, modules []string) error {
+			got = modules
+			return nil
+		},
+	}
+
+	report, err := checker.Check(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []DependencyUpdate{
+		{UpdateFrontend, "daisyui", "5.0.43", "5.1.0"},
+		{UpdateFrontend, "htmx.org", "2.0.4", "2.0.6"},
+		{UpdateTool, "tailwindcss", "v4.1.4", "v4.1.11"},
+		{UpdateGo, "github.com/go-chi/chi/v5", "v5.2.1", "v5.2.2"},
+		{UpdateTemplates, "bundle (stable)", "0.9.0", "1.0.0"},
+	}
+	if fmt.Sprint(report.Updates) != fmt.Sprint(want) || len(report.Failed) != 0 {
+		t.Errorf("Check(false) = %v, failed %v\nwant %v", report.Updates, report.Failed, want)
+	}
+	if got != nil || len(report.Changed) != 0 || strings.Contains(readProjectFile(t, projectDir, "Makefile"), "htmx.org@2.0.6") {
+		t.Error("Check(false) changed the project")
+	}
+
+	report, err = checker.Check(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(report.Changed, ",") != "Dockerfile,Makefile,assets.sha256,go.mod,tools.lock" {
+		t.Errorf("Check(true) changed %v", report.Changed)
+	}
+	if fmt.Sprint(got) != "[github.com/go-chi/chi/v5@v5.2.2]" {
+		t.Errorf("go get %v", got)
+	}
+	makefile := readProjectFile(t, projectDir, "Makefile")
+	for _, want := range []string{"unpkg.com/htmx.org@2.0.6/dist/htmx.min.js", "daisyui/releases/download/v5.1.0/daisyui.mjs", "DaisyUI 5.1.0", "alpinejs@3.14.8"} {
+		if !strings.Contains(makefile, want) {
+			t.Errorf("Makefile missing %q", want)
+		}
+	}
+	htmxSum := sha256Hex([]byte("unpkg.com/htmx.org@2.0.6/dist/htmx.min.js"))
+	if !strings.Contains(readProjectFile(t, projectDir, AssetChecksumsFile), htmxSum+"  assets/js/htmx.min.js") {
+		t.Error("assets.sha256 does not have the new htmx checksum")
+	}
+	dockerfile := readProjectFile(t, projectDir, "Dockerfile")
+	for _, want := range []string{"tailwindcss/releases/download/v4.1.11/", "htmx.org@2.0.6"} {
+		if !strings.Contains(dockerfile, want) {
+			t.Errorf("Dockerfile missing %q", want)
+		}
+	}
+	if lock, _ := LoadToolsLock(projectDir); lock.Tools[0].Version != "v4.1.11" || lock.Tools[0].SHA256 != nil {
+		t.Errorf("tools.lock = %+v", lock.Tools)
+	}
+
+	// A registry that is down fails only the kinds that need it
+	checker.Registry = srv.URL + "/missing"
+	report, err = checker.Check(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Failed[UpdateFrontend] == nil || report.Failed[UpdateTool] == nil || report.Failed[UpdateGo] != nil {
+		t.Errorf("Failed = %v", report.Failed)
+	}
+}
+
+func TestNPMPackage(t *testing.T) {
+	for prefix, want := range map[string]string{
+		"unpkg.com/htmx.org@":                             "htmx.org",
+		"cdn.jsdelivr.net/npm/basecoat-css@":              "basecoat-css",
+		"github.com/saadeghi/daisyui/releases/download/v": "daisyui",
+		"github.com/someone/else/releases/download/v":     "",
+		"example.com/lib@":                                "",
+	} {
+		if got := npmPackage(prefix); got != want {
+			t.Errorf("npmPackage(%q) = %q, want %q", prefix, got, want)
+		}
+	}
+}
@@ -0,0 +1,261 @@
+package generator
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/FACorreiaa/goforge/internal/manifest"
+)
+
+// newFileSuffix is appended to files an upgrade could not apply because they
+// were edited, so the new version can be merged by hand
+const newFileSuffix = ".goforge-new"
+
+// UpgradeReport describes what `goforge upgrade` changed, relative to the
+// project directory
+type UpgradeReport struct {
+	From, To string
+	// Steps describes each changeset applied, oldest first
+	Steps   []string
+	Updated []string
+	Added   []string
+	Removed []string
+	// Skipped maps files left alone to the reason
+	Skipped map[string]string
+}
+
+// Upgrade brings a project to the template bundle version this goforge ships
+// for its channel by applying the changesets in between, one after another.
+// Files still as the old templates rendered them are updated, added or
+// removed; files edited since generation, by hand or by commands such as add
+// module, are skipped and the new version is written next to them with a
+// .goforge-new suffix. With dryRun nothing is written.
+func Upgrade(projectDir string, dryRun bool) (*UpgradeReport, error) {
+	changesets, err := loadChangesets(changesetFS)
+	if err != nil {
+		return nil, err
+	}
+	return upgrade(projectDir, Templates(), changesets, bundleVersions, dryRun)
+}
+
+func upgrade(projectDir string, templates fs.FS, changesets []Changeset, bundles map[string]string, dryRun bool) (*UpgradeReport, error) {
+	m, err := requireManifest(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	channel := m.Template.Channel
+	if channel == "" {
+		channel = ChannelStable
+	}
+	target, ok := bundles[channel]
+	if !ok {
+		return nil, fmt.Errorf("unknown template channel %q", channel)
+	}
+	if m.Template.Version == "" {
+		return nil, fmt.Errorf("%s does not record a template bundle version (generated before goforge versioned its templates): "+
+			"changesets cannot be applied", manifest.FileName)
+	}
+	if len(m.Options) == 0 {
+		return nil, fmt.Errorf("%s records no generation options (created by 'manifest init'): templates cannot be rendered", manifest.FileName)
+	}
+	opts, errs := optionsFromManifest(m)
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("%s: %w", manifest.FileName, errs[0])
+	}
+	if opts.Vars, err = readProjectVars(projectDir); err != nil {
+		return nil, err
+	}
+
+	report := &UpgradeReport{From: m.Template.Version, To: target, Skipped: map[string]string{}}
+	if m.Template.Version == target {
+		return report, nil
+	}
+	chain, err := changesetChain(changesets, channel, m.Template.Version, target)
+	if err != nil {
+		return nil, err
+	}
+	content, present, err := templatesAt(templates, chain)
+	if err != nil {
+		return nil, err
+	}
+
+	u := &upgrader{
+		dir:          projectDir,
+		manifest:     m,
+		opts:         opts,
+		replacements: getReplacements(opts),
+		dryRun:       dryRun,
+		report:       report,
+		touched:      map[string]string{},
+		written:      map[string]*string{},
+	}
+	for _, cs := range chain {
+		for _, c := range cs.Changes {
+			newContent, err := applyPatch(content[c.Path], c.Patch, false)
+			if err != nil {
+				return nil, fmt.Errorf("changeset %s→%s: %s: %w", cs.From, cs.To, c.Path, err)
+			}
+			newPresent := c.Op != ChangeRemove
+			if !skipPath(c.Path, opts) {
+				if err := u.apply(c.Path, content[c.Path], present[c.Path], newContent, newPresent); err != nil {
+					return nil, err
+				}
+			}
+			content[c.Path], present[c.Path] = newContent, newPresent
+		}
+		report.Steps = append(report.Steps, fmt.Sprintf("%s → %s (%d template changes)", cs.From, cs.To, len(cs.Changes)))
+	}
+
+	for rel, result := range u.touched {
+		switch result {
+		case ChangeAdd:
+			report.Added = append(report.Added, rel)
+		case ChangeRemove:
+			report.Removed = append(report.Removed, rel)
+		default:
+			report.Updated = append(report.Updated, rel)
+		}
+	}
+	sort.Strings(report.Updated)
+	sort.Strings(report.Added)
+	sort.Strings(report.Removed)
+
+	if dryRun {
+		return report, nil
+	}
+	m.Template.Version = target
+	if err := recordChanges(projectDir, m, nil); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// upgrader applies template changes to one project
+type upgrader struct {
+	dir          string
+	manifest     *manifest.Manifest
+	opts         Options
+	replacements map[string]string
+	dryRun       bool
+	report       *UpgradeReport
+	// touched records the net operation on each file written so far
+	touched map[string]string
+	// written holds the content of files written or removed (nil) so far, so
+	// later changesets see them even in a dry run
+	written map[string]*string
+}
+
+// apply moves one project file from the rendering of the old template to the
+// rendering of the new one, unless the user edited it
+func (u *upgrader) apply(templatePath, oldTmpl string, oldPresent bool, newTmpl string, newPresent bool) error {
+	rel := renderedPath(templatePath)
+	oldRendered, err := renderFile(templatePath, oldTmpl, u.opts, u.replacements)
+	if err != nil {
+		return err
+	}
+	newRendered, err := renderFile(templatePath, newTmpl, u.opts, u.replacements)
+	if err != nil {
+		return err
+	}
+	if oldPresent && newPresent && oldRendered == newRendered {
+		return nil // the change is in a block this project does not use
+	}
+
+	path := filepath.Join(u.dir, filepath.FromSlash(rel))
+	data, exists, err := u.read(path)
+	if err != nil {
+		return err
+	}
+	// Only a file byte-identical to the old rendering is replaced. The
+	// recorded checksum is not compared: it is kept for drift reporting only.
+	_, tracked := u.manifest.Files[rel]
+	pristine := exists && string(data) == oldRendered
+
+	switch {
+	case !newPresent && !exists:
+		delete(u.manifest.Files, rel)
+	case !newPresent && pristine:
+		if err := u.remove(path); err != nil {
+			return err
+		}
+		delete(u.manifest.Files, rel)
+		u.note(rel, ChangeRemove)
+	case !newPresent:
+		u.report.Skipped[rel] = "modified locally; remove it if it is no longer needed"
+	case exists && string(data) == newRendered:
+		u.manifest.Files[rel] = manifest.Checksum(data)
+	case !exists && (!oldPresent || !tracked):
+		if err := u.write(path, newRendered); err != nil {
+			return err
+		}
+		u.manifest.Files[rel] = manifest.Checksum([]byte(newRendered))
+		u.note(rel, ChangeAdd)
+	case !exists:
+		u.report.Skipped[rel] = "deleted locally"
+	case pristine:
+		if err := u.write(path, newRendered); err != nil {
+			return err
+		}
+		u.manifest.Files[rel] = manifest.Checksum([]byte(newRendered))
+		u.note(rel, ChangeModify)
+	default:
+		if err := u.write(path+newFileSuffix, newRendered); err != nil {
+			return err
+		}
+		u.report.Skipped[rel] = "modified locally; new version in " + rel + newFileSuffix
+	}
+	return nil
+}
+
+// note records the net effect of an upgrade on a file: adding then modifying
+// is still an add, and adding then removing cancels out
+func (u *upgrader) note(rel, op string) {
+	prev, seen := u.touched[rel]
+	switch {
+	case !seen:
+		u.touched[rel] = op
+	case prev == ChangeAdd && op == ChangeRemove:
+		delete(u.touched, rel)
+	case prev == ChangeAdd:
+	case prev == ChangeRemove && op == ChangeAdd:
+		u.touched[rel] = ChangeModify
+	default:
+		u.touched[rel] = op
+	}
+}
+
+func (u *upgrader) read(path string) ([]byte, bool, error) {
+	if content, ok := u.written[path]; ok {
+		if content == nil {
+			return nil, false, nil
+		}
+		return []byte(*content), true, nil
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	return data, err == nil, err
+}
+
+func (u *upgrader) write(path, content string) error {
+	u.written[path] = &content
+	if u.dryRun {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(content), 0644)
+}
+
+func (u *upgrader) remove(path string) error {
+	u.written[path] = nil
+	if u.dryRun {
+		return nil
+	}
+	return os.Remove(path)
+}
@@ -0,0 +1,257 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/FACorreiaa/goforge/internal/manifest"
+)
+
+// templateRelease copies a template tree and applies edits; nil removes a file
+func templateRelease(t *testing.T, base fstest.MapFS, edits map[string]*string) fstest.MapFS {
+	t.Helper()
+	next := fstest.MapFS{}
+	for p, f := range base {
+		next[p] = &fstest.MapFile{Data: f.Data, Mode: f.Mode}
+	}
+	for p, content := range edits {
+		if content == nil {
+			delete(next, p)
+			continue
+		}
+		next[p] = &fstest.MapFile{Data: []byte(*content), Mode: 0644}
+	}
+	return next
+}
+
+func embeddedTemplates(t *testing.T) fstest.MapFS {
+	t.Helper()
+	files, err := templateFiles(Templates())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree := fstest.MapFS{}
+	for p, content := range files {
+		tree[p] = &fstest.MapFile{Data: []byte(content), Mode: 0644}
+	}
+	return tree
+}
+
+func str(s string) *string { return &s }
+
+// upgradeFixture generates a project from the embedded templates (bundle
+// 1.0.0) and builds two later releases with their changesets
+func upgradeFixture(t *testing.T) (projectDir string, latest fstest.MapFS, chain []Changeset) {
+	t.Helper()
+	projectDir = generateProject(t, Options{IncludeDB: true})
+	v1 := embeddedTemplates(t)
+	file := func(tree fstest.MapFS, p string) string { return string(tree[p].Data) }
+
+	v2 := templateRelease(t, v1, map[string]*string{
+		"Makefile":                           str(file(v1, "Makefile") + "\nhello:\n\t@echo hello\n"),
+		"README.md":                          str(file(v1, "README.md") + "\nUpgraded.\n"),
+		"internal/database/database.go.tmpl": str(strings.Replace(file(v1, "internal/database/database.go.tmpl"), "package database", "// Package database wraps the pgx pool\npackage database", 1)),
+		"internal/jobs/jobs.go.tmpl":         str(file(v1, "internal/jobs/jobs.go.tmpl") + "\n// unused by this project\n"),
+		"docs/UPGRADING.md":                  str("# Upgrading <!-- PROJECT_NAME -->\n"),
+		".dockerignore":                      nil,
+	})
+	v3 := templateRelease(t, v2, map[string]*string{
+		"Makefile":          str(file(v2, "Makefile") + "\nbye:\n\t@echo bye\n"),
+		"docs/UPGRADING.md": str(file(v2, "docs/UPGRADING.md") + "\nRun goforge upgrade.\n"),
+	})
+
+	for _, r := range []struct {
+		old, new fstest.MapFS
+		from, to string
+	}{{v1, v2, "1.0.0", "1.0.1"}, {v2, v3, "1.0.1", "1.0.2"}} {
+		cs, err := NewChangeset(r.old, r.new, ChannelStable, r.from, r.to)
+		if err != nil {
+			t.Fatalf("NewChangeset() error: %v", err)
+		}
+		chain = append(chain, *cs)
+	}
+
+	// The user edits the README after generation
+	readme := filepath.Join(projectDir, "README.md")
+	data, _ := os.ReadFile(readme)
+	if err := os.WriteFile(readme, append(data, "\nOur notes.\n"...), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return projectDir, v3, chain
+}
+
+func TestUpgrade(t *testing.T) {
+	projectDir, latest, chain := upgradeFixture(t)
+
+	report, err := upgrade(projectDir, latest, chain, map[string]string{ChannelStable: "1.0.2"}, false)
+	if err != nil {
+		t.Fatalf("upgrade() error: %v", err)
+	}
+	if report.From != bundleVersions[ChannelStable] || report.To != "1.0.2" || len(report.Steps) != 2 {
+		t.Errorf("report = %+v", report)
+	}
+	if want := []string{"Makefile", "internal/database/database.go"}; !reflect.DeepEqual(report.Updated, want) {
+		t.Errorf("updated = %v, want %v", report.Updated, want)
+	}
+	if want := []string{"docs/UPGRADING.md"}; !reflect.DeepEqual(report.Added, want) {
+		t.Errorf("added = %v, want %v", report.Added, want)
+	}
+	if want := []string{".dockerignore"}; !reflect.DeepEqual(report.Removed, want) {
+		t.Errorf("removed = %v, want %v", report.Removed, want)
+	}
+
+	makefile := readProjectFile(t, projectDir, "Makefile")
+	if !strings.Contains(makefile, "hello:") || !strings.Contains(makefile, "bye:") {
+		t.Error("Makefile did not get both releases")
+	}
+	if got := readProjectFile(t, projectDir, "docs/UPGRADING.md"); got != "# Upgrading app\n\nRun goforge upgrade.\n" {
+		t.Errorf("added file rendered as %q", got)
+	}
+	if !strings.HasPrefix(readProjectFile(t, projectDir, "internal/database/database.go"), "// Package database") {
+		t.Error("database.go not updated")
+	}
+	assertFilesMissing(t, projectDir, ".dockerignore", "internal/jobs")
+
+	// The edited README is kept and the new version written next to it
+	if _, ok := report.Skipped["README.md"]; !ok {
+		t.Errorf("skipped = %v, want README.md", report.Skipped)
+	}
+	if readme := readProjectFile(t, projectDir, "README.md"); !strings.Contains(readme, "Our notes.") || strings.Contains(readme, "Upgraded.") {
+		t.Error("upgrade overwrote the edited README")
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, "README.md.goforge-new"), "Upgraded.") {
+		t.Error("README.md.goforge-new missing the new version")
+	}
+
+	m, errs := ValidateManifest(projectDir)
+	if len(errs) > 0 {
+		t.Fatalf("ValidateManifest() after upgrade = %v", errs)
+	}
+	if m.Template.Version != "1.0.2" {
+		t.Errorf("manifest template.version = %s, want 1.0.2", m.Template.Version)
+	}
+	modified, missing, _ := ManifestDrift(projectDir, m)
+	if !reflect.DeepEqual(modified, []string{"README.md"}) || len(missing) > 0 {
+		t.Errorf("drift after upgrade = %v, %v; want only the edited README", modified, missing)
+	}
+
+	// A second run has nothing to do
+	again, err := upgrade(projectDir, latest, chain, map[string]string{ChannelStable: "1.0.2"}, false)
+	if err != nil || again.From != again.To {
+		t.Errorf("second upgrade() = %+v, %v", again, err)
+	}
+}
+
+func TestUpgradeKeepsAddedCode(t *testing.T) {
+	projectDir := generateProject(t, Options{IncludeDB: true})
+	if _, err := AddModule(projectDir, "invoices"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The release changes the routes.go add module edited, and the Makefile
+	v1 := embeddedTemplates(t)
+	v2 := templateRelease(t, v1, map[string]*string{
+		"internal/server/routes.go.tmpl": str(string(v1["internal/server/routes.go.tmpl"].Data) + "\n// Upgraded.\n"),
+		"Makefile":                       str(string(v1["Makefile"].Data) + "\nhello:\n\t@echo hello\n"),
+	})
+	cs, err := NewChangeset(v1, v2, ChannelStable, bundleVersions[ChannelStable], "1.0.1")
+	if err != nil {
+		t.Fatalf("NewChangeset() error: %v", err)
+	}
+	report, err := upgrade(projectDir, v2, []Changeset{*cs}, map[string]string{ChannelStable: "1.0.1"}, false)
+	if err != nil {
+		t.Fatalf("upgrade() error: %v", err)
+	}
+
+	if _, ok := report.Skipped["internal/server/routes.go"]; !ok || !reflect.DeepEqual(report.Updated, []string{"Makefile"}) {
+		t.Errorf("report = %+v, want routes.go skipped and the Makefile updated", report)
+	}
+	routes := readProjectFile(t, projectDir, "internal/server/routes.go")
+	for _, want := range []string{`"github.com/test/app/internal/modules/invoices"`, "invoices.Routes(s.db)"} {
+		if !strings.Contains(routes, want) {
+			t.Errorf("upgrade dropped %q from routes.go", want)
+		}
+	}
+	if !strings.Contains(readProjectFile(t, projectDir, "internal/server/routes.go.goforge-new"), "// Upgraded.") {
+		t.Error("routes.go.goforge-new missing the new version")
+	}
+}
+
+func TestUpgradeDryRun(t *testing.T) {
+	projectDir, latest, chain := upgradeFixture(t)
+	before, _ := os.ReadFile(manifest.Path(projectDir))
+
+	report, err := upgrade(projectDir, latest, chain, map[string]string{ChannelStable: "1.0.2"}, true)
+	if err != nil {
+		t.Fatalf("upgrade() error: %v", err)
+	}
+	if len(report.Updated) != 2 || len(report.Added) != 1 || len(report.Removed) != 1 {
+		t.Errorf("dry run report = %+v", report)
+	}
+	assertFilesExist(t, projectDir, ".dockerignore")
+	assertFilesMissing(t, projectDir, "docs", "README.md.goforge-new")
+	if strings.Contains(readProjectFile(t, projectDir, "Makefile"), "hello:") {
+		t.Error("dry run wrote the Makefile")
+	}
+	if after, _ := os.ReadFile(manifest.Path(projectDir)); string(after) != string(before) {
+		t.Error("dry run wrote the manifest")
+	}
+}
+
+func TestUpgradeErrors(t *testing.T) {
+	projectDir, latest, chain := upgradeFixture(t)
+
+	_, err := upgrade(projectDir, latest, chain[1:], map[string]string{ChannelStable: "1.0.2"}, false)
+	if err == nil || !strings.Contains(err.Error(), "no stable changeset from template bundle 1.0.0") {
+		t.Errorf("upgrade() with a gap in the chain error = %v", err)
+	}
+
+	m, _ := manifest.Load(projectDir)
+	m.Template.Version = ""
+	if err := m.Save(projectDir); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := upgrade(projectDir, latest, chain, map[string]string{ChannelStable: "1.0.2"}, false); err == nil {
+		t.Error("upgrade() without a recorded bundle version succeeded")
+	}
+}
+
+// Every embedded changeset must undo cleanly on the current templates, or
+// upgrades from older bundles would fail
+func TestEmbeddedChangesets(t *testing.T) {
+	changesets, err := loadChangesets(changesetFS)
+	if err != nil {
+		t.Fatalf("loadChangesets() error: %v", err)
+	}
+	for _, channel := range Channels() {
+		targets := map[string]bool{}
+		for _, cs := range changesets {
+			if cs.Channel == channel {
+				targets[cs.To] = true
+			}
+		}
+		for _, cs := range changesets {
+			if cs.Channel != channel || targets[cs.From] {
+				continue
+			}
+			chain, err := changesetChain(changesets, channel, cs.From, bundleVersions[channel])
+			if err != nil {
+				t.Errorf("%s: %v", channel, err)
+				continue
+			}
+			if _, _, err := templatesAt(Templates(), chain); err != nil {
+				t.Errorf("%s: %v", channel, err)
+			}
+		}
+	}
+
+	projectDir := generateProject(t, Options{})
+	report, err := Upgrade(projectDir, false)
+	if err != nil || report.From != report.To {
+		t.Errorf("Upgrade() of a fresh project = %+v, %v; want up to date", report, err)
+	}
+}
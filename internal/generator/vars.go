@@ -0,0 +1,131 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// VarsPath is where `goforge new --vars` keeps the variables, so regen and
+// upgrade render templates with the same values
+var VarsPath = filepath.Join(".goforge", "vars.json")
+
+var (
+	// varRe matches a {{ var "name" }} directive
+	varRe = regexp.MustCompile(`\{\{\s*var\s+"([^"]+)"\s*\}\}`)
+	// varNameRe restricts variable names to JSON keys templates can spell
+	varNameRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+)
+
+// LoadVars reads template variables from a JSON object of names to strings,
+// numbers or booleans; numbers and booleans are used as written
+func LoadVars(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	vars, err := parseVars(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return vars, nil
+}
+
+func parseVars(data []byte) (map[string]string, error) {
+	var raw map[string]json.RawMessage
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("vars must be a JSON object: %w", err)
+	}
+	vars := make(map[string]string, len(raw))
+	for name, value := range raw {
+		if !varNameRe.MatchString(name) {
+			return nil, fmt.Errorf("var %q: names use letters, digits, _, . and -", name)
+		}
+		var v any
+		vdec := json.NewDecoder(bytes.NewReader(value))
+		vdec.UseNumber()
+		if err := vdec.Decode(&v); err != nil {
+			return nil, err
+		}
+		switch v := v.(type) {
+		case string:
+			vars[name] = v
+		case json.Number, bool:
+			vars[name] = fmt.Sprint(v)
+		default:
+			return nil, fmt.Errorf("var %q must be a string, number or boolean", name)
+		}
+	}
+	return vars, nil
+}
+
+// expandVars replaces each {{ var "name" }} directive with the variable's
+// value. A template using a variable that was not passed fails, since
+// goforge cannot guess what a custom template expects.
+func expandVars(content string, vars map[string]string) (string, error) {
+	if !strings.Contains(content, "var") {
+		return content, nil
+	}
+	var missing []string
+	content = varRe.ReplaceAllStringFunc(content, func(directive string) string {
+		name := varRe.FindStringSubmatch(directive)[1]
+		v, ok := vars[name]
+		if !ok {
+			missing = append(missing, name)
+		}
+		return v
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("template uses var %q, which was not set (pass it with --vars)", missing[0])
+	}
+	return content, nil
+}
+
+// unusedVars lists the variables no template or fragment refers to
+func unusedVars(vars map[string]string) []string {
+	var unused []string
+	for name := range vars {
+		if !bundleContains(`var "` + name + `"`) {
+			unused = append(unused, name)
+		}
+	}
+	sort.Strings(unused)
+	return unused
+}
+
+// writeProjectVars records the variables of a generated project
+func writeProjectVars(projectDir string, vars map[string]string) error {
+	data, err := json.MarshalIndent(vars, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(projectDir, VarsPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// readProjectVars reads the variables a project was generated with; a
+// project generated without --vars has none
+func readProjectVars(projectDir string) (map[string]string, error) {
+	data, err := os.ReadFile(filepath.Join(projectDir, VarsPath))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	vars, err := parseVars(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.ToSlash(VarsPath), err)
+	}
+	return vars, nil
+}
@@ -0,0 +1,59 @@
+package generator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseVars(t *testing.T) {
+	vars, err := parseVars([]byte(`{"company": "Acme", "seats": 25, "billing.enabled": true}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"company": "Acme", "seats": "25", "billing.enabled": "true"}
+	if !reflect.DeepEqual(vars, want) {
+		t.Errorf("parseVars() = %v, want %v", vars, want)
+	}
+
+	for _, src := range []string{`["a"]`, `{"nested": {"a": 1}}`, `{"list": [1]}`, `{"bad name": "x"}`} {
+		if _, err := parseVars([]byte(src)); err == nil {
+			t.Errorf("parseVars(%s) accepted invalid vars", src)
+		}
+	}
+}
+
+func TestExpandVars(t *testing.T) {
+	vars := map[string]string{"company": "Acme"}
+	got, err := expandVars(`// © {{ var "company" }}, {{var "company"}}`+"\n", vars)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "// © Acme, Acme\n" {
+		t.Errorf("expandVars() = %q", got)
+	}
+	if _, err := expandVars(`{{ var "region" }}`, vars); err == nil || !strings.Contains(err.Error(), `"region"`) {
+		t.Errorf("expandVars() with an unset var: %v", err)
+	}
+}
+
+func TestGenerateWithVars(t *testing.T) {
+	vars := map[string]string{"company": "Acme"}
+	projectDir := generateProject(t, Options{Vars: vars})
+
+	got, err := readProjectVars(projectDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, vars) {
+		t.Errorf("recorded vars = %v, want %v", got, vars)
+	}
+	if unused := unusedVars(vars); !reflect.DeepEqual(unused, []string{"company"}) {
+		t.Errorf("unusedVars() = %v", unused)
+	}
+
+	none := generateProject(t, Options{})
+	if got, err := readProjectVars(none); err != nil || got != nil {
+		t.Errorf("project without --vars: vars = %v, %v", got, err)
+	}
+}
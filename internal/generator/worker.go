@@ -0,0 +1,78 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// workersMarker is where `add worker` registers new handlers
+const workersMarker = "// goforge:workers"
+
+// AddWorker adds a job type, its handler and a test to a project generated
+// with --jobs, and registers the handler in internal/jobs/registry.go.
+// It returns the created and modified files, relative to projectDir.
+func AddWorker(projectDir, name string) ([]string, error) {
+	n, err := ParseName(name)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := projectModule(projectDir); err != nil {
+		return nil, err
+	}
+	mf, err := requireManifest(projectDir)
+	if err != nil {
+		return nil, err
+	}
+
+	registry := filepath.Join(projectDir, "internal", "jobs", "registry.go")
+	data, err := os.ReadFile(registry)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("no jobs subsystem in %s (internal/jobs/registry.go is missing): "+
+			"workers need a project generated with 'goforge new --jobs'", projectDir)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !strings.Contains(string(data), workersMarker) {
+		return nil, fmt.Errorf("%s has no %q marker: add it inside Register() so new workers can be registered",
+			registry, workersMarker)
+	}
+
+	vars := struct {
+		Type string
+		Kind string
+	}{Type: n.Pascal(), Kind: n.Snake()}
+
+	files := []struct{ path, snippet string }{
+		{filepath.Join("internal", "jobs", n.Snake()+".go"), "worker.go.tmpl"},
+		{filepath.Join("internal", "jobs", n.Snake()+"_test.go"), "worker_test.go.tmpl"},
+	}
+	for _, f := range files {
+		if _, err := os.Stat(filepath.Join(projectDir, f.path)); err == nil {
+			return nil, fmt.Errorf("worker %q already exists (%s)", n.Snake(), f.path)
+		}
+	}
+
+	var changed []string
+	for _, f := range files {
+		content, err := renderSnippet(f.snippet, vars)
+		if err != nil {
+			return nil, err
+		}
+		if err := writeNewFile(filepath.Join(projectDir, f.path), content); err != nil {
+			return nil, err
+		}
+		changed = append(changed, f.path)
+	}
+
+	line := fmt.Sprintf("Handle(r, %sKind, handle%s)", n.Pascal(), n.Pascal())
+	if err := insertAtMarker(registry, workersMarker, line); err != nil {
+		return nil, err
+	}
+	if err := recordChanges(projectDir, mf, changed); err != nil {
+		return nil, err
+	}
+	return append(changed, filepath.Join("internal", "jobs", "registry.go")), nil
+}
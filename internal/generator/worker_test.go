@@ -0,0 +1,48 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAddWorker(t *testing.T) {
+	projectDir := generateProject(t, Options{Jobs: true})
+
+	files, err := AddWorker(projectDir, "send-welcome-email")
+	if err != nil {
+		t.Fatalf("AddWorker() error: %v", err)
+	}
+	if len(files) != 3 {
+		t.Errorf("AddWorker() changed %v, want the worker, its test and the registry", files)
+	}
+
+	assertFilesExist(t, projectDir,
+		"internal/jobs/send_welcome_email.go",
+		"internal/jobs/send_welcome_email_test.go",
+	)
+	worker := readProjectFile(t, projectDir, "internal/jobs/send_welcome_email.go")
+	for _, want := range []string{`SendWelcomeEmailKind = "send_welcome_email"`, "func handleSendWelcomeEmail("} {
+		if !strings.Contains(worker, want) {
+			t.Errorf("worker missing %s", want)
+		}
+	}
+
+	registry := readProjectFile(t, projectDir, "internal/jobs/registry.go")
+	if !strings.Contains(registry, "\tHandle(r, SendWelcomeEmailKind, handleSendWelcomeEmail)\n\t"+workersMarker) {
+		t.Errorf("worker not registered above the marker:\n%s", registry)
+	}
+
+	if _, err := AddWorker(projectDir, "send_welcome_email"); err == nil {
+		t.Error("AddWorker() overwrote an existing worker")
+	}
+}
+
+func TestAddWorkerWithoutJobs(t *testing.T) {
+	projectDir := generateProject(t, Options{})
+
+	_, err := AddWorker(projectDir, "cleanup")
+	if err == nil || !strings.Contains(err.Error(), "--jobs") {
+		t.Fatalf("AddWorker() error = %v, want it to point at --jobs", err)
+	}
+	assertFilesMissing(t, projectDir, "internal/jobs")
+}
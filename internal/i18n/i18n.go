@@ -0,0 +1,126 @@
+// Package i18n holds the translations of goforge's user-facing output. Each
+// language is a flat JSON catalog in locales/, keyed by message id; English
+// is the reference every other catalog is checked against, and the fallback
+// for a message a catalog lacks.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+//go:embed locales/*.json
+var localeFS embed.FS
+
+// English is the source language and the fallback
+const English = "en"
+
+// Languages are the supported language codes, English first
+var Languages = []string{English, "de", "es", "pt"}
+
+var (
+	mu       sync.RWMutex
+	current  = English
+	catalogs = map[string]map[string]string{}
+)
+
+// Catalog returns the messages of lang by id
+func Catalog(lang string) (map[string]string, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	if c, ok := catalogs[lang]; ok {
+		return c, nil
+	}
+	data, err := localeFS.ReadFile("locales/" + lang + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("unsupported language %q (use %s)", lang, strings.Join(Languages, ", "))
+	}
+	var c map[string]string
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("locales/%s.json: %w", lang, err)
+	}
+	catalogs[lang] = c
+	return c, nil
+}
+
+// Set selects the language T translates to
+func Set(lang string) error {
+	if _, err := Catalog(lang); err != nil {
+		return err
+	}
+	mu.Lock()
+	current = lang
+	mu.Unlock()
+	return nil
+}
+
+// Lang returns the selected language
+func Lang() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// T returns the message id in the selected language, formatted with args
+// as by fmt.Sprintf. A message missing from the catalog falls back to
+// English, and an unknown id to the id itself.
+func T(id string, args ...any) string {
+	msg := lookup(Lang(), id)
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+func lookup(lang, id string) string {
+	for _, l := range []string{lang, English} {
+		if c, err := Catalog(l); err == nil {
+			if msg, ok := c[id]; ok {
+				return msg
+			}
+		}
+	}
+	return id
+}
+
+// Match maps a locale such as pt_BR.UTF-8, de-AT or es to a supported
+// language, or "" when there is none. C and POSIX are English.
+func Match(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if locale == "c" || locale == "posix" || strings.HasPrefix(locale, "c.") {
+		return English
+	}
+	lang, _, _ := strings.Cut(locale, ".")
+	lang, _, _ = strings.Cut(lang, "@")
+	lang, _, _ = strings.Cut(strings.ReplaceAll(lang, "-", "_"), "_")
+	for _, l := range Languages {
+		if l == lang {
+			return l
+		}
+	}
+	return ""
+}
+
+// Detect picks the language from the environment the way gettext does:
+// the first supported entry of LANGUAGE, then LC_ALL, LC_MESSAGES and LANG.
+// It returns English when none is set or supported.
+func Detect() string {
+	for _, locale := range strings.Split(os.Getenv("LANGUAGE"), ":") {
+		if lang := Match(locale); lang != "" {
+			return lang
+		}
+	}
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if v := os.Getenv(env); v != "" {
+			if lang := Match(v); lang != "" {
+				return lang
+			}
+			return English
+		}
+	}
+	return English
+}
@@ -0,0 +1,100 @@
+package i18n
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// verbRe matches the fmt verbs of a message
+var verbRe = regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z%]`)
+
+func TestCatalogsMatchEnglish(t *testing.T) {
+	en, err := Catalog(English)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, lang := range Languages[1:] {
+		c, err := Catalog(lang)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for id, msg := range en {
+			translated, ok := c[id]
+			if !ok {
+				t.Errorf("%s: missing %q", lang, id)
+				continue
+			}
+			want := strings.Join(verbRe.FindAllString(msg, -1), " ")
+			if got := strings.Join(verbRe.FindAllString(translated, -1), " "); got != want {
+				t.Errorf("%s: %q has verbs %q, want %q", lang, id, got, want)
+			}
+		}
+		var extra []string
+		for id := range c {
+			if _, ok := en[id]; !ok {
+				extra = append(extra, id)
+			}
+		}
+		sort.Strings(extra)
+		if len(extra) > 0 {
+			t.Errorf("%s: ids not in English: %v", lang, extra)
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	defer Set(English)
+	if err := Set("pt"); err != nil {
+		t.Fatal(err)
+	}
+	if got := T("error.directory_exists", "app"); got != "o diretório 'app' já existe" {
+		t.Errorf("T = %q", got)
+	}
+	if got := T("no.such.message"); got != "no.such.message" {
+		t.Errorf("unknown id = %q", got)
+	}
+	if err := Set("fr"); err == nil || Lang() != "pt" {
+		t.Errorf("Set(fr) = %v, language %s", err, Lang())
+	}
+}
+
+func TestMatch(t *testing.T) {
+	for locale, want := range map[string]string{
+		"pt_BR.UTF-8": "pt",
+		"de-AT":       "de",
+		"es":          "es",
+		"de_DE@euro":  "de",
+		"C.UTF-8":     "en",
+		"POSIX":       "en",
+		"fr_FR.UTF-8": "",
+		"":            "",
+		"en_GB.UTF-8": "en",
+	} {
+		if got := Match(locale); got != want {
+			t.Errorf("Match(%q) = %q, want %q", locale, got, want)
+		}
+	}
+}
+
+func TestDetect(t *testing.T) {
+	for _, tc := range []struct {
+		language, lcAll, lang string
+		want                  string
+	}{
+		{lang: "es_ES.UTF-8", want: "es"},
+		{lcAll: "de_DE.UTF-8", lang: "es_ES.UTF-8", want: "de"},
+		{language: "fr:pt", lang: "es_ES.UTF-8", want: "pt"},
+		{lang: "fr_FR.UTF-8", want: "en"},
+		{want: "en"},
+	} {
+		t.Setenv("LANGUAGE", tc.language)
+		t.Setenv("LC_ALL", tc.lcAll)
+		t.Setenv("LC_MESSAGES", "")
+		t.Setenv("LANG", tc.lang)
+		if got := Detect(); got != tc.want {
+			t.Errorf("Detect() with %+v = %q", tc, got)
+		}
+	}
+}
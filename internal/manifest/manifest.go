@@ -0,0 +1,310 @@
+// Package manifest reads, writes and validates .goforge.yaml, the record of
+// how a project was generated: generator version, template source, options,
+// enabled features and a checksum of every generated file. Post-generation
+// commands (add, generate, rename-module) require it, and upgrade/diff
+// workflows compare a project against it.
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FileName is the manifest's name at the project root
+const FileName = ".goforge.yaml"
+
+// SchemaVersion is the manifest format this goforge writes. Bumping it
+// requires a migration from the previous version (see migrate.go).
+const SchemaVersion = 3
+
+// ErrNotFound is returned by Load when the project has no manifest
+var ErrNotFound = errors.New("no " + FileName + " manifest")
+
+// Manifest describes a generated project
+type Manifest struct {
+	SchemaVersion int
+	Generator     Generator
+	Template      Template
+	Project       Project
+	// Options are the `goforge new` flags, by flag name
+	Options map[string]string
+	// Features are the ids of the enabled features ("db", "search-pgtrgm"), sorted
+	Features []string
+	// Files maps slash-separated paths to "sha256:<hex>" of the generated content
+	Files map[string]string
+
+	// migratedFrom is the schema version Load read, when it was migrated
+	migratedFrom int
+}
+
+// MigratedFrom returns the schema version the manifest was migrated from
+// when it was loaded, or 0 if the file is already current
+func (m *Manifest) MigratedFrom() int {
+	return m.migratedFrom
+}
+
+// Generator identifies the goforge build that wrote the manifest
+type Generator struct {
+	Version string
+}
+
+// Template identifies the templates the project was generated from
+type Template struct {
+	// Source is "embedded" for the templates built into goforge, or
+	// "release <tag>" for those of an earlier goforge release (new --at)
+	Source string
+	// Channel is the template channel (stable, edge)
+	Channel string
+	// Version is the template bundle version, when known
+	Version string
+}
+
+// Project holds the project identity at generation time
+type Project struct {
+	Name   string
+	Module string
+}
+
+// Checksum returns the manifest checksum of file content
+func Checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// Path returns the manifest path of a project
+func Path(projectDir string) string {
+	return filepath.Join(projectDir, FileName)
+}
+
+// Load reads and decodes the manifest of a project, migrating older schema
+// versions in memory. It does not validate it.
+func Load(projectDir string) (*Manifest, error) {
+	data, err := os.ReadFile(Path(projectDir))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%s: %w", projectDir, ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	m, _, err := Migrate(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", FileName, err)
+	}
+	return m, nil
+}
+
+// Save encodes the manifest into the project root
+func (m *Manifest) Save(projectDir string) error {
+	return os.WriteFile(Path(projectDir), m.Encode(), 0644)
+}
+
+// Encode renders the manifest as YAML with a stable key order
+func (m *Manifest) Encode() []byte {
+	root := newMapping()
+	root.set("schema_version", rawScalar(strconv.Itoa(m.SchemaVersion)))
+
+	gen := newMapping()
+	gen.set("version", m.Generator.Version)
+	root.set("generator", gen)
+
+	tmpl := newMapping()
+	tmpl.set("source", m.Template.Source)
+	if m.Template.Channel != "" {
+		tmpl.set("channel", m.Template.Channel)
+	}
+	if m.Template.Version != "" {
+		tmpl.set("version", m.Template.Version)
+	}
+	root.set("template", tmpl)
+
+	project := newMapping()
+	project.set("name", m.Project.Name)
+	project.set("module", m.Project.Module)
+	root.set("project", project)
+
+	root.set("options", sortedMapping(m.Options))
+	features := append([]string{}, m.Features...)
+	sort.Strings(features)
+	root.set("features", features)
+	root.set("files", sortedMapping(m.Files))
+
+	var b strings.Builder
+	b.WriteString("# Written by goforge: how this project was generated. Post-generation\n")
+	b.WriteString("# commands read and update it; validate with `goforge manifest validate`.\n")
+	writeYAML(&b, root, "")
+	return []byte(b.String())
+}
+
+func sortedMapping(values map[string]string) *mapping {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	m := newMapping()
+	for _, k := range keys {
+		m.set(k, values[k])
+	}
+	return m
+}
+
+// Decode parses a manifest in the current schema; use Migrate for older
+// ones. Unknown keys are rejected so typos do not silently drop data.
+func Decode(data []byte) (*Manifest, error) {
+	root, err := parseYAML(string(data))
+	if err != nil {
+		return nil, err
+	}
+	version, err := schemaVersion(root)
+	if err != nil {
+		return nil, err
+	}
+	if version != SchemaVersion {
+		return nil, fmt.Errorf("schema_version %d is not the current schema (%d)", version, SchemaVersion)
+	}
+	return decodeMapping(root)
+}
+
+func decodeMapping(root *mapping) (*Manifest, error) {
+	var err error
+	m := &Manifest{Options: map[string]string{}, Files: map[string]string{}}
+	for _, key := range root.keys {
+		value := root.values[key]
+		switch key {
+		case "schema_version":
+			s, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("schema_version must be a number")
+			}
+			if m.SchemaVersion, err = strconv.Atoi(s); err != nil {
+				return nil, fmt.Errorf("schema_version must be a number, got %q", s)
+			}
+		case "generator":
+			fields, err := stringFields(key, value, "version")
+			if err != nil {
+				return nil, err
+			}
+			m.Generator.Version = fields["version"]
+		case "template":
+			fields, err := stringFields(key, value, "source", "channel", "version")
+			if err != nil {
+				return nil, err
+			}
+			m.Template = Template{Source: fields["source"], Channel: fields["channel"], Version: fields["version"]}
+		case "project":
+			fields, err := stringFields(key, value, "name", "module")
+			if err != nil {
+				return nil, err
+			}
+			m.Project = Project{Name: fields["name"], Module: fields["module"]}
+		case "options":
+			if m.Options, err = stringFields(key, value); err != nil {
+				return nil, err
+			}
+		case "files":
+			if m.Files, err = stringFields(key, value); err != nil {
+				return nil, err
+			}
+		case "features":
+			list, ok := value.([]string)
+			if !ok {
+				return nil, fmt.Errorf("features must be a list")
+			}
+			m.Features = list
+		default:
+			return nil, fmt.Errorf("unknown key %q", key)
+		}
+	}
+	return m, nil
+}
+
+// stringFields reads a mapping of strings, restricted to allowed keys when given
+func stringFields(name string, value any, allowed ...string) (map[string]string, error) {
+	mm, ok := value.(*mapping)
+	if !ok {
+		if s, isString := value.(string); isString && s == "" {
+			return map[string]string{}, nil
+		}
+		return nil, fmt.Errorf("%s must be a mapping", name)
+	}
+	fields := make(map[string]string, len(mm.keys))
+	for _, k := range mm.keys {
+		if len(allowed) > 0 && !contains(allowed, k) {
+			return nil, fmt.Errorf("unknown key %s.%s", name, k)
+		}
+		s, ok := mm.values[k].(string)
+		if !ok {
+			return nil, fmt.Errorf("%s.%s must be a string", name, k)
+		}
+		fields[k] = s
+	}
+	return fields, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+var checksumRe = regexp.MustCompile(`^sha256:[0-9a-f]{64}$`)
+
+// Validate checks the manifest's structure: required fields, schema version,
+// feature list and file entries. Whether options and features are known to
+// this goforge is checked by the generator.
+func (m *Manifest) Validate() []error {
+	var errs []error
+	add := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	switch {
+	case m.SchemaVersion < 1:
+		add("schema_version is missing")
+	case m.SchemaVersion > SchemaVersion:
+		add("schema_version %d is newer than this goforge supports (%d): upgrade goforge", m.SchemaVersion, SchemaVersion)
+	case m.SchemaVersion < SchemaVersion:
+		add("schema_version %d is outdated (current %d): run `goforge manifest migrate`", m.SchemaVersion, SchemaVersion)
+	}
+	if m.Generator.Version == "" {
+		add("generator.version is missing")
+	}
+	if m.Template.Source == "" {
+		add("template.source is missing")
+	}
+	if m.Project.Module == "" {
+		add("project.module is missing")
+	}
+
+	seen := map[string]bool{}
+	for _, f := range m.Features {
+		if seen[f] {
+			add("feature %s is listed twice", f)
+		}
+		seen[f] = true
+	}
+
+	for p, sum := range m.Files {
+		if p == "" || path.IsAbs(p) || path.Clean(p) != p || strings.HasPrefix(p, "../") || strings.Contains(p, `\`) {
+			add("files: %q is not a clean relative path", p)
+		}
+		if !checksumRe.MatchString(sum) {
+			add("files: %s has an invalid checksum %q", p, sum)
+		}
+	}
+
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Error() < errs[j].Error() })
+	return errs
+}
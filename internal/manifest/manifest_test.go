@@ -0,0 +1,164 @@
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func sampleManifest() *Manifest {
+	return &Manifest{
+		SchemaVersion: SchemaVersion,
+		Generator:     Generator{Version: "0.1.0"},
+		Template:      Template{Source: "embedded", Channel: "stable", Version: "1.0.0"},
+		Project:       Project{Name: "shop", Module: "github.com/acme/shop"},
+		Options:       map[string]string{"db": "true", "frontend": "htmx", "search": "pg_trgm"},
+		Features:      []string{"db", "search", "search-pgtrgm"},
+		Files: map[string]string{
+			"go.mod":             Checksum([]byte("module github.com/acme/shop\n")),
+			"cmd/server/main.go": Checksum([]byte("package main\n")),
+		},
+	}
+}
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	want := sampleManifest()
+	got, err := Decode(want.Encode())
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip = %+v, want %+v", got, want)
+	}
+	if errs := got.Validate(); len(errs) > 0 {
+		t.Errorf("Validate() = %v, want no errors", errs)
+	}
+}
+
+func TestEncodeIsStable(t *testing.T) {
+	m := sampleManifest()
+	first := string(m.Encode())
+	for i := 0; i < 5; i++ {
+		if got := string(m.Encode()); got != first {
+			t.Fatalf("Encode() is not deterministic:\n%s\n---\n%s", first, got)
+		}
+	}
+	for _, want := range []string{fmt.Sprintf("schema_version: %d\n", SchemaVersion), "  module: github.com/acme/shop\n", "  - search-pgtrgm\n", "  cmd/server/main.go: sha256:"} {
+		if !strings.Contains(first, want) {
+			t.Errorf("Encode() missing %q:\n%s", want, first)
+		}
+	}
+}
+
+func TestEncodeQuotesImplicitTypes(t *testing.T) {
+	m := sampleManifest()
+	m.Options = map[string]string{}
+	quoted := []string{"true", "False", "yes", "on", "OFF", "y", "null", "~", "1", "1.0", "1.20", "0x1F", "0o17", "1e3", "190:20:30", "2024-01-02"}
+	plain := []string{"htmx", "pg_trgm", "1.0.0", "1.24.2", "none", "yesterday", "0x", "v1.2"}
+	for _, v := range append(quoted, plain...) {
+		m.Options["o"+v] = v
+	}
+	encoded := string(m.Encode())
+	for _, v := range quoted {
+		if !strings.Contains(encoded, ": "+strconv.Quote(v)+"\n") {
+			t.Errorf("Encode() does not quote %q:\n%s", v, encoded)
+		}
+	}
+	for _, v := range plain {
+		if !strings.Contains(encoded, ": "+v+"\n") {
+			t.Errorf("Encode() quotes %q:\n%s", v, encoded)
+		}
+	}
+	got, err := Decode([]byte(encoded))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if !reflect.DeepEqual(got.Options, m.Options) {
+		t.Errorf("options round trip = %v, want %v", got.Options, m.Options)
+	}
+}
+
+func TestDecodeEmptyCollections(t *testing.T) {
+	m := sampleManifest()
+	m.Options = map[string]string{}
+	m.Features = []string{}
+	m.Files = map[string]string{}
+	got, err := Decode(m.Encode())
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if len(got.Options) != 0 || len(got.Features) != 0 || len(got.Files) != 0 {
+		t.Errorf("Decode() = %+v, want empty options, features and files", got)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	current := fmt.Sprintf("schema_version: %d\n", SchemaVersion)
+	tests := []struct {
+		name, src, want string
+	}{
+		{"unknown key", current + "extras: 1\n", "unknown key"},
+		{"unknown nested key", current + "generator:\n  name: x\n", "unknown key generator.name"},
+		{"missing version", "generator:\n  version: x\n", "schema_version is missing"},
+		{"bad version", "schema_version: one\n", "must be a positive number"},
+		{"old version", "schema_version: 1\n", "not the current schema"},
+		{"features not a list", current + "features: db\n", "features must be a list"},
+		{"bad indentation", "project:\n  name: x\n    module: y\n", "unexpected indentation"},
+		{"flow syntax", current + "features: [db]\n", "unsupported YAML syntax"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Decode([]byte(tt.src))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Decode() error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Manifest)
+		want   string
+	}{
+		{"missing schema", func(m *Manifest) { m.SchemaVersion = 0 }, "schema_version is missing"},
+		{"future schema", func(m *Manifest) { m.SchemaVersion = SchemaVersion + 1 }, "newer than this goforge supports"},
+		{"missing module", func(m *Manifest) { m.Project.Module = "" }, "project.module is missing"},
+		{"duplicate feature", func(m *Manifest) { m.Features = []string{"db", "db"} }, "listed twice"},
+		{"escaping path", func(m *Manifest) { m.Files["../etc/passwd"] = Checksum(nil) }, "not a clean relative path"},
+		{"bad checksum", func(m *Manifest) { m.Files["go.sum"] = "md5:abc" }, "invalid checksum"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := sampleManifest()
+			tt.modify(m)
+			errs := m.Validate()
+			if len(errs) == 0 || !strings.Contains(errors.Join(errs...).Error(), tt.want) {
+				t.Errorf("Validate() = %v, want an error containing %q", errs, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadSave(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := Load(dir); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Load() without a manifest error = %v, want ErrNotFound", err)
+	}
+
+	want := sampleManifest()
+	if err := want.Save(dir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	got, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Load() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,141 @@
+package manifest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// migration upgrades a decoded manifest from one schema version to the next.
+// Migrations work on the raw mapping so they can read keys the current
+// Manifest type no longer has.
+type migration struct {
+	from        int
+	description string
+	apply       func(root *mapping) error
+}
+
+// migrations upgrade schema version `from` to `from+1`; one per version
+// below SchemaVersion, in order. Never edit a released migration: add a new
+// one and bump SchemaVersion instead.
+var migrations = []migration{
+	{
+		from:        1,
+		description: "record features by id (SEARCH_PGTRGM becomes search-pgtrgm)",
+		apply:       migrateFeatureIDs,
+	},
+	{
+		from:        2,
+		description: "record the template channel (projects before channels used stable)",
+		apply:       migrateTemplateChannel,
+	},
+}
+
+// Migrate decodes a manifest written with any supported schema version and
+// upgrades it to SchemaVersion. It returns a description of each migration
+// applied, oldest first.
+func Migrate(data []byte) (*Manifest, []string, error) {
+	root, err := parseYAML(string(data))
+	if err != nil {
+		return nil, nil, err
+	}
+	from, err := schemaVersion(root)
+	if err != nil {
+		return nil, nil, err
+	}
+	if from > SchemaVersion {
+		return nil, nil, fmt.Errorf("schema_version %d is newer than this goforge supports (%d): upgrade goforge", from, SchemaVersion)
+	}
+
+	var applied []string
+	for version := from; version < SchemaVersion; version++ {
+		mig, ok := migrationFrom(version)
+		if !ok {
+			return nil, nil, fmt.Errorf("no migration from schema_version %d", version)
+		}
+		if err := mig.apply(root); err != nil {
+			return nil, nil, fmt.Errorf("migrate schema %d to %d: %w", version, version+1, err)
+		}
+		root.set("schema_version", strconv.Itoa(version+1))
+		applied = append(applied, fmt.Sprintf("schema %d → %d: %s", version, version+1, mig.description))
+	}
+
+	m, err := decodeMapping(root)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(applied) > 0 {
+		m.migratedFrom = from
+	}
+	return m, applied, nil
+}
+
+func migrationFrom(version int) (migration, bool) {
+	for _, mig := range migrations {
+		if mig.from == version {
+			return mig, true
+		}
+	}
+	return migration{}, false
+}
+
+// schemaVersion reads schema_version from a raw manifest
+func schemaVersion(root *mapping) (int, error) {
+	value, ok := root.values["schema_version"]
+	if !ok {
+		return 0, fmt.Errorf("schema_version is missing")
+	}
+	s, ok := value.(string)
+	if !ok {
+		return 0, fmt.Errorf("schema_version must be a number")
+	}
+	version, err := strconv.Atoi(s)
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("schema_version must be a positive number, got %q", s)
+	}
+	return version, nil
+}
+
+// migrateFeatureIDs converts schema 1 feature names, which were the template
+// condition names, to the kebab-case feature ids of schema 2
+func migrateFeatureIDs(root *mapping) error {
+	value, ok := root.values["features"]
+	if !ok {
+		return nil
+	}
+	features, ok := value.([]string)
+	if !ok {
+		return fmt.Errorf("features must be a list")
+	}
+	ids := make([]string, len(features))
+	for i, f := range features {
+		ids[i] = strings.ReplaceAll(strings.ToLower(f), "_", "-")
+	}
+	root.set("features", ids)
+	return nil
+}
+
+// migrateTemplateChannel adds template.channel, introduced in schema 3.
+// Earlier projects were generated from what became the stable channel; their
+// bundle version is unknown and stays unset.
+func migrateTemplateChannel(root *mapping) error {
+	tmpl, ok := root.values["template"].(*mapping)
+	if !ok {
+		return fmt.Errorf("template must be a mapping")
+	}
+	if _, ok := tmpl.values["channel"]; ok {
+		return nil
+	}
+
+	// Keep the encoder's key order: source, channel, version
+	updated := newMapping()
+	for _, key := range tmpl.keys {
+		updated.set(key, tmpl.values[key])
+		if key == "source" {
+			updated.set("channel", "stable")
+		}
+	}
+	updated.set("channel", "stable")
+	root.set("template", updated)
+	return nil
+}
@@ -0,0 +1,118 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Every schema version goforge has released gets a fixture in testdata, as
+// the version wrote it, and an entry here
+func TestMigrateHistoricalVersions(t *testing.T) {
+	tests := []struct {
+		fixture      string
+		from         int
+		wantFeatures []string
+	}{
+		{"schema_v1.yaml", 1, []string{"db", "jobs", "search", "search-pgtrgm"}},
+		{"schema_v2.yaml", 2, []string{"db", "jobs", "search", "search-pgtrgm"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("testdata", tt.fixture))
+			if err != nil {
+				t.Fatal(err)
+			}
+			m, applied, err := Migrate(data)
+			if err != nil {
+				t.Fatalf("Migrate() error = %v", err)
+			}
+			if len(applied) != SchemaVersion-tt.from {
+				t.Errorf("Migrate() applied %v, want %d migrations", applied, SchemaVersion-tt.from)
+			}
+			if m.SchemaVersion != SchemaVersion || m.MigratedFrom() != tt.from {
+				t.Errorf("schema = %d from %d, want %d from %d", m.SchemaVersion, m.MigratedFrom(), SchemaVersion, tt.from)
+			}
+			if !reflect.DeepEqual(m.Features, tt.wantFeatures) {
+				t.Errorf("features = %v, want %v", m.Features, tt.wantFeatures)
+			}
+			if m.Template != (Template{Source: "embedded", Channel: "stable"}) {
+				t.Errorf("template = %+v, want the stable channel with an unknown version", m.Template)
+			}
+			if m.Project.Module != "github.com/acme/shop" || m.Options["search"] != "pg_trgm" || len(m.Files) != 2 {
+				t.Errorf("migration lost data: %+v", m)
+			}
+			if errs := m.Validate(); len(errs) > 0 {
+				t.Errorf("Validate() after migration = %v", errs)
+			}
+
+			// The migrated manifest is written in the current schema
+			again, err := Decode(m.Encode())
+			if err != nil {
+				t.Fatalf("Decode() of the migrated manifest error = %v", err)
+			}
+			if !reflect.DeepEqual(again.Features, tt.wantFeatures) {
+				t.Errorf("re-decoded features = %v", again.Features)
+			}
+		})
+	}
+}
+
+func TestMigrationsCoverEverySchema(t *testing.T) {
+	for version := 1; version < SchemaVersion; version++ {
+		if _, ok := migrationFrom(version); !ok {
+			t.Errorf("no migration from schema_version %d", version)
+		}
+	}
+	for _, mig := range migrations {
+		if mig.from < 1 || mig.from >= SchemaVersion {
+			t.Errorf("migration from %d is outside 1..%d", mig.from, SchemaVersion-1)
+		}
+	}
+}
+
+func TestMigrateCurrentSchema(t *testing.T) {
+	want := sampleManifest()
+	got, applied, err := Migrate(want.Encode())
+	if err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	if len(applied) > 0 || got.MigratedFrom() != 0 {
+		t.Errorf("Migrate() of a current manifest applied %v", applied)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Migrate() = %+v, want %+v", got, want)
+	}
+}
+
+func TestMigrateNewerSchema(t *testing.T) {
+	_, _, err := Migrate([]byte("schema_version: 99\n"))
+	if err == nil || !strings.Contains(err.Error(), "upgrade goforge") {
+		t.Errorf("Migrate() error = %v, want it to ask for a newer goforge", err)
+	}
+}
+
+func TestLoadMigrates(t *testing.T) {
+	dir := t.TempDir()
+	data, err := os.ReadFile(filepath.Join("testdata", "schema_v1.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(Path(dir), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if m.MigratedFrom() != 1 || m.SchemaVersion != SchemaVersion {
+		t.Errorf("Load() = schema %d from %d", m.SchemaVersion, m.MigratedFrom())
+	}
+	// Load never rewrites the file
+	if onDisk, _ := os.ReadFile(Path(dir)); string(onDisk) != string(data) {
+		t.Error("Load() rewrote the manifest")
+	}
+}
@@ -0,0 +1,335 @@
+package manifest
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// The manifest uses a small, fixed subset of YAML: nested block mappings,
+// block sequences of scalars, quoted or plain scalars, `{}`/`[]` for empty
+// collections and comments. Encoding it here keeps goforge free of a YAML
+// dependency; anything outside the subset is reported as an error.
+
+// mapping is a YAML block mapping that remembers key order
+type mapping struct {
+	keys   []string
+	values map[string]any // string, []string or *mapping
+}
+
+func newMapping() *mapping {
+	return &mapping{values: map[string]any{}}
+}
+
+func (m *mapping) set(key string, value any) {
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+// Document is a mapping in the manifest's YAML subset, for other goforge
+// files written in it, such as stack configs
+type Document struct {
+	m *mapping
+}
+
+// ParseDocument decodes src, which must stay within the manifest's YAML subset
+func ParseDocument(src string) (*Document, error) {
+	root, err := parseYAML(src)
+	if err != nil {
+		return nil, err
+	}
+	return &Document{m: root}, nil
+}
+
+// Keys lists the document's keys in file order
+func (d *Document) Keys() []string {
+	return d.m.keys
+}
+
+// String returns the scalar at key ("" when missing)
+func (d *Document) String(key string) (string, error) {
+	switch v := d.m.values[key].(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	}
+	return "", fmt.Errorf("%s must be a string", key)
+}
+
+// Strings returns the mapping of scalars at key, empty when missing
+func (d *Document) Strings(key string) (map[string]string, error) {
+	if _, ok := d.m.values[key]; !ok {
+		return map[string]string{}, nil
+	}
+	return stringFields(key, d.m.values[key])
+}
+
+// Document returns the nested mapping at key, empty when missing
+func (d *Document) Document(key string) (*Document, error) {
+	switch v := d.m.values[key].(type) {
+	case nil:
+		return &Document{m: newMapping()}, nil
+	case *mapping:
+		return &Document{m: v}, nil
+	}
+	return nil, fmt.Errorf("%s must be a mapping", key)
+}
+
+type line struct {
+	num    int
+	indent int
+	text   string
+}
+
+// parseYAML decodes the manifest subset into a mapping
+func parseYAML(src string) (*mapping, error) {
+	var lines []line
+	for i, raw := range strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimLeft(raw, " ")
+		if strings.HasPrefix(trimmed, "\t") {
+			return nil, fmt.Errorf("line %d: tabs are not allowed for indentation", i+1)
+		}
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || trimmed == "---" {
+			continue
+		}
+		lines = append(lines, line{num: i + 1, indent: len(raw) - len(trimmed), text: strings.TrimRight(trimmed, " ")})
+	}
+
+	p := &yamlParser{lines: lines}
+	root, err := p.parseMapping(0)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.lines) {
+		return nil, fmt.Errorf("line %d: unexpected indentation", p.lines[p.pos].num)
+	}
+	return root, nil
+}
+
+type yamlParser struct {
+	lines []line
+	pos   int
+}
+
+func (p *yamlParser) parseMapping(indent int) (*mapping, error) {
+	m := newMapping()
+	for p.pos < len(p.lines) {
+		l := p.lines[p.pos]
+		if l.indent < indent {
+			break
+		}
+		if l.indent > indent {
+			return nil, fmt.Errorf("line %d: unexpected indentation", l.num)
+		}
+		if strings.HasPrefix(l.text, "- ") || l.text == "-" {
+			return nil, fmt.Errorf("line %d: expected a key, found a list item", l.num)
+		}
+
+		key, rest, err := splitKey(l.text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", l.num, err)
+		}
+		if _, dup := m.values[key]; dup {
+			return nil, fmt.Errorf("line %d: duplicate key %q", l.num, key)
+		}
+		p.pos++
+
+		switch {
+		case rest == "{}":
+			m.set(key, newMapping())
+		case rest == "[]":
+			m.set(key, []string{})
+		case rest != "":
+			value, err := parseScalar(rest)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", l.num, err)
+			}
+			m.set(key, value)
+		case p.pos < len(p.lines) && p.lines[p.pos].indent > indent:
+			child := p.lines[p.pos]
+			if strings.HasPrefix(child.text, "- ") || child.text == "-" {
+				list, err := p.parseSequence(child.indent)
+				if err != nil {
+					return nil, err
+				}
+				m.set(key, list)
+			} else {
+				nested, err := p.parseMapping(child.indent)
+				if err != nil {
+					return nil, err
+				}
+				m.set(key, nested)
+			}
+		case p.pos < len(p.lines) && p.lines[p.pos].indent == indent &&
+			strings.HasPrefix(p.lines[p.pos].text, "- "):
+			// Sequences may sit at the parent key's indentation
+			list, err := p.parseSequence(indent)
+			if err != nil {
+				return nil, err
+			}
+			m.set(key, list)
+		default:
+			m.set(key, "")
+		}
+	}
+	return m, nil
+}
+
+func (p *yamlParser) parseSequence(indent int) ([]string, error) {
+	list := []string{}
+	for p.pos < len(p.lines) {
+		l := p.lines[p.pos]
+		if l.indent != indent || !(strings.HasPrefix(l.text, "- ") || l.text == "-") {
+			if l.indent > indent {
+				return nil, fmt.Errorf("line %d: only lists of plain values are supported", l.num)
+			}
+			break
+		}
+		value, err := parseScalar(strings.TrimSpace(strings.TrimPrefix(l.text, "-")))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", l.num, err)
+		}
+		list = append(list, value)
+		p.pos++
+	}
+	return list, nil
+}
+
+// splitKey splits `key: rest`, where key may be quoted
+func splitKey(text string) (string, string, error) {
+	if strings.HasPrefix(text, `"`) || strings.HasPrefix(text, "'") {
+		end := closingQuote(text)
+		if end < 0 {
+			return "", "", fmt.Errorf("unterminated quoted key")
+		}
+		key, err := parseScalar(text[:end+1])
+		if err != nil {
+			return "", "", err
+		}
+		rest := strings.TrimSpace(text[end+1:])
+		if !strings.HasPrefix(rest, ":") {
+			return "", "", fmt.Errorf("expected ':' after key %q", key)
+		}
+		return key, strings.TrimSpace(rest[1:]), nil
+	}
+
+	idx := strings.Index(text, ": ")
+	if idx < 0 {
+		if !strings.HasSuffix(text, ":") {
+			return "", "", fmt.Errorf("expected 'key: value', found %q", text)
+		}
+		idx = len(text) - 1
+	}
+	return strings.TrimSpace(text[:idx]), strings.TrimSpace(text[idx+1:]), nil
+}
+
+// closingQuote returns the index of the quote closing the scalar at text[0]
+func closingQuote(text string) int {
+	q := text[0]
+	for i := 1; i < len(text); i++ {
+		switch {
+		case q == '"' && text[i] == '\\':
+			i++
+		case text[i] == q && q == '\'' && i+1 < len(text) && text[i+1] == '\'':
+			i++
+		case text[i] == q:
+			return i
+		}
+	}
+	return -1
+}
+
+// parseScalar decodes a quoted or plain scalar, dropping trailing comments
+func parseScalar(text string) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+	switch text[0] {
+	case '"', '\'':
+		end := closingQuote(text)
+		if end < 0 {
+			return "", fmt.Errorf("unterminated string %s", text)
+		}
+		if rest := strings.TrimSpace(text[end+1:]); rest != "" && !strings.HasPrefix(rest, "#") {
+			return "", fmt.Errorf("unexpected %q after string", rest)
+		}
+		if text[0] == '\'' {
+			return strings.ReplaceAll(text[1:end], "''", "'"), nil
+		}
+		return strconv.Unquote(text[:end+1])
+	case '{', '[', '&', '*', '!', '|', '>':
+		return "", fmt.Errorf("unsupported YAML syntax %q", text)
+	}
+	if idx := strings.Index(text, " #"); idx >= 0 {
+		text = strings.TrimSpace(text[:idx])
+	}
+	return text, nil
+}
+
+// plainKey matches keys that need no quoting
+var plainKey = regexp.MustCompile(`^[A-Za-z_./][A-Za-z0-9_./-]*$`)
+
+func encodeKey(key string) string {
+	if plainKey.MatchString(key) {
+		return key
+	}
+	return strconv.Quote(key)
+}
+
+// plainValue matches scalars that read back unchanged without quotes
+var plainValue = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_./:+-]*$`)
+
+// implicitValue matches plain scalars that YAML 1.1 or 1.2 resolves to
+// something other than a string: null, booleans, integers (decimal, octal,
+// hex, binary, sexagesimal), floats and timestamps
+var implicitValue = regexp.MustCompile(`^(?:(?i:~|null|y|yes|n|no|true|false|on|off)` +
+	`|[-+]?(?:0b[01_]+|0o[0-7_]+|0x[0-9a-fA-F_]+|[0-9][0-9_]*(?::[0-5]?[0-9])*)` +
+	`|[-+]?(?:[0-9][0-9_]*(?::[0-5]?[0-9])*)?\.[0-9_]*(?:[eE][-+]?[0-9]+)?` +
+	`|[-+]?[0-9][0-9_]*[eE][-+]?[0-9]+` +
+	`|[0-9]{4}-[0-9]{1,2}-[0-9]{1,2}(?:[Tt].*)?)$`)
+
+// encodeScalar writes s plain when it reads back as the same string, and
+// double-quoted otherwise
+func encodeScalar(s string) string {
+	if plainValue.MatchString(s) && !implicitValue.MatchString(s) {
+		return s
+	}
+	return strconv.Quote(s)
+}
+
+// writeYAML encodes a mapping: strings through encodeScalar, numbers as
+// written
+func writeYAML(b *strings.Builder, m *mapping, indent string) {
+	for _, key := range m.keys {
+		b.WriteString(indent + encodeKey(key) + ":")
+		switch v := m.values[key].(type) {
+		case string:
+			b.WriteString(" " + encodeScalar(v) + "\n")
+		case rawScalar:
+			b.WriteString(" " + string(v) + "\n")
+		case []string:
+			if len(v) == 0 {
+				b.WriteString(" []\n")
+				continue
+			}
+			b.WriteString("\n")
+			for _, item := range v {
+				b.WriteString(indent + "  - " + encodeScalar(item) + "\n")
+			}
+		case *mapping:
+			if len(v.keys) == 0 {
+				b.WriteString(" {}\n")
+				continue
+			}
+			b.WriteString("\n")
+			writeYAML(b, v, indent+"  ")
+		}
+	}
+}
+
+// rawScalar is written unquoted (numbers)
+type rawScalar string
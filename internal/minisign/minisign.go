@@ -0,0 +1,123 @@
+// Package minisign verifies minisign signatures (https://jedisct1.github.io/minisign/),
+// the format goforge template bundles are signed in: Ed25519 over the file,
+// or over its BLAKE2b-512 hash (the default since minisign 0.10), plus a
+// signature over the trusted comment.
+package minisign
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	algEd     = "Ed" // signature over the file
+	algHashed = "ED" // signature over the file's BLAKE2b-512 hash
+)
+
+// PublicKey is a minisign public key
+type PublicKey struct {
+	// ID is the key id minisign prints, in hex
+	ID  string
+	key ed25519.PublicKey
+	id  [8]byte
+}
+
+// Signature is a decoded .minisig file
+type Signature struct {
+	// KeyID is the id of the key that made the signature, in hex
+	KeyID string
+	// TrustedComment is the signed comment (minisign records a timestamp
+	// and the file name)
+	TrustedComment string
+
+	algorithm string
+	id        [8]byte
+	sig       []byte
+	global    []byte
+}
+
+// ParsePublicKey reads a public key: the base64 line alone, as in
+// `minisign -P`, or a minisign.pub file with its comment line
+func ParsePublicKey(s string) (*PublicKey, error) {
+	lines := nonEmptyLines(s)
+	if len(lines) == 0 {
+		return nil, errors.New("empty public key")
+	}
+	raw, err := base64.StdEncoding.DecodeString(lines[len(lines)-1])
+	if err != nil || len(raw) != 2+8+ed25519.PublicKeySize || string(raw[:2]) != algEd {
+		return nil, errors.New("not a minisign public key")
+	}
+	pk := &PublicKey{key: ed25519.PublicKey(raw[10:])}
+	copy(pk.id[:], raw[2:10])
+	pk.ID = keyID(pk.id)
+	return pk, nil
+}
+
+// ParseSignature reads a .minisig file
+func ParseSignature(s string) (*Signature, error) {
+	lines := nonEmptyLines(s)
+	if len(lines) != 4 || !strings.HasPrefix(lines[0], "untrusted comment:") {
+		return nil, errors.New("not a minisign signature")
+	}
+	raw, err := base64.StdEncoding.DecodeString(lines[1])
+	if err != nil || len(raw) != 2+8+ed25519.SignatureSize {
+		return nil, errors.New("malformed signature line")
+	}
+	comment, ok := strings.CutPrefix(lines[2], "trusted comment: ")
+	if !ok {
+		return nil, errors.New("missing trusted comment")
+	}
+	global, err := base64.StdEncoding.DecodeString(lines[3])
+	if err != nil || len(global) != ed25519.SignatureSize {
+		return nil, errors.New("malformed global signature")
+	}
+	sig := &Signature{algorithm: string(raw[:2]), sig: raw[10:], global: global, TrustedComment: comment}
+	if sig.algorithm != algEd && sig.algorithm != algHashed {
+		return nil, fmt.Errorf("unsupported signature algorithm %q", sig.algorithm)
+	}
+	copy(sig.id[:], raw[2:10])
+	sig.KeyID = keyID(sig.id)
+	return sig, nil
+}
+
+// Verify checks that sig was made over message by key, trusted comment
+// included
+func (key *PublicKey) Verify(message []byte, sig *Signature) error {
+	if key.id != sig.id {
+		return fmt.Errorf("signed with key %s, not %s", sig.KeyID, key.ID)
+	}
+	signed := message
+	if sig.algorithm == algHashed {
+		sum := blake2b.Sum512(message)
+		signed = sum[:]
+	}
+	if !ed25519.Verify(key.key, signed, sig.sig) {
+		return errors.New("signature does not match the file")
+	}
+	if !ed25519.Verify(key.key, append(bytes.Clone(sig.sig), sig.TrustedComment...), sig.global) {
+		return errors.New("trusted comment has been tampered with")
+	}
+	return nil
+}
+
+// keyID formats a key id as minisign prints it
+func keyID(id [8]byte) string {
+	return fmt.Sprintf("%016X", binary.LittleEndian.Uint64(id[:]))
+}
+
+func nonEmptyLines(s string) []string {
+	var lines []string
+	for _, l := range strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n") {
+		if l = strings.TrimSpace(l); l != "" {
+			lines = append(lines, l)
+		}
+	}
+	return lines
+}
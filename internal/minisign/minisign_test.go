@@ -0,0 +1,82 @@
+package minisign
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// sign produces a .minisig for message the way minisign does
+func sign(t *testing.T, priv ed25519.PrivateKey, id [8]byte, alg string, message []byte, comment string) string {
+	t.Helper()
+	signed := message
+	if alg == algHashed {
+		sum := blake2b.Sum512(message)
+		signed = sum[:]
+	}
+	sig := ed25519.Sign(priv, signed)
+	global := ed25519.Sign(priv, append(append([]byte{}, sig...), comment...))
+	line := append(append([]byte(alg), id[:]...), sig...)
+	return "untrusted comment: signature from minisign secret key\n" +
+		base64.StdEncoding.EncodeToString(line) + "\n" +
+		"trusted comment: " + comment + "\n" +
+		base64.StdEncoding.EncodeToString(global) + "\n"
+}
+
+// newKey returns a key pair and the public key in minisign's format
+func newKey(t *testing.T, id [8]byte) (ed25519.PrivateKey, string) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return priv, base64.StdEncoding.EncodeToString(append(append([]byte(algEd), id[:]...), pub...))
+}
+
+func TestVerify(t *testing.T) {
+	id := [8]byte{1, 2, 3, 4, 5, 6, 7, 8}
+	priv, pubLine := newKey(t, id)
+	key, err := ParsePublicKey("untrusted comment: minisign public key\n" + pubLine + "\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key.ID != "0807060504030201" {
+		t.Errorf("key ID = %s", key.ID)
+	}
+	message := []byte("bundle archive")
+
+	for _, alg := range []string{algEd, algHashed} {
+		sig, err := ParseSignature(sign(t, priv, id, alg, message, "timestamp:1 file:bundle.tar.gz"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := key.Verify(message, sig); err != nil {
+			t.Errorf("%s: Verify() = %v", alg, err)
+		}
+		if err := key.Verify([]byte("tampered"), sig); err == nil {
+			t.Errorf("%s: Verify() accepted a modified file", alg)
+		}
+		sig.TrustedComment = "timestamp:2 file:other.tar.gz"
+		if err := key.Verify(message, sig); err == nil {
+			t.Errorf("%s: Verify() accepted a modified trusted comment", alg)
+		}
+	}
+
+	otherPriv, _ := newKey(t, [8]byte{9})
+	sig, _ := ParseSignature(sign(t, otherPriv, [8]byte{9}, algHashed, message, "c"))
+	if err := key.Verify(message, sig); err == nil || !strings.Contains(err.Error(), "signed with key") {
+		t.Errorf("Verify() with another key's signature: %v", err)
+	}
+
+	for _, bad := range []string{"", "not base64!", "untrusted comment: x\nAAAA\n"} {
+		if _, err := ParsePublicKey(bad); err == nil {
+			t.Errorf("ParsePublicKey(%q) succeeded", bad)
+		}
+		if _, err := ParseSignature(bad); err == nil {
+			t.Errorf("ParseSignature(%q) succeeded", bad)
+		}
+	}
+}